@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"context"
+
+	"fightbettr.com/auth/migrations"
+	"fightbettr.com/auth/pkg/cfg"
+	"fightbettr.com/pkg/migrate"
+	"fightbettr.com/pkg/pgxs"
+)
+
+func init() {
+	rootCmd.AddCommand(migrate.Command(migrations.Service, migrations.FS, cfg.ViperPostgres))
+}
+
+// applyMigrations applies all pending migrations of the service.
+// It is called on serve startup when postgres.migrate_on_start is enabled.
+func applyMigrations(ctx context.Context, repo pgxs.FbRepo) error {
+	m, err := migrations.New(repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.Up(ctx, 0)
+	return err
+}
@@ -19,7 +19,7 @@ func (c *Controller) Register(ctx context.Context, req *model.RegisterRequest) (
 		IsoLevel: pgx.Serializable,
 	})
 	if err != nil {
-		logs.Errorf("Unable to begin transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to begin transaction: %s", err)
 		cErr := internalErr.New(internalErr.Tx, err, 101)
 		return 0, cErr
 	}
@@ -27,18 +27,20 @@ func (c *Controller) Register(ctx context.Context, req *model.RegisterRequest) (
 	credentials, err := c.createUserCredentials(ctx, tx, req)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
-		logs.Errorf("Error while user credentials creation: %s", err)
+		logs.Ctx(ctx).Errorf("Error while user credentials creation: %s", err)
 		return 0, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		logs.Errorf("Unable to commit transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to commit transaction: %s", err)
 		cErr := internalErr.New(internalErr.TxCommit, err, 102)
 		return 0, cErr
 	}
 
+	registrations.Inc()
+
 	// TODO
 	go c.HandleEmailEvent(ctx, &model.EmailData{
 		Subject: model.EmailRegistration,
@@ -63,7 +65,7 @@ func (c *Controller) RegisterConfirm(ctx context.Context, req *model.UserCredent
 		if err == pgx.ErrNoRows {
 			return false, internalErr.New(internalErr.UserCredentialsToken, err, 401)
 		} else {
-			logs.Errorf("Failed to get user credentials: %s", err)
+			logs.Ctx(ctx).Errorf("Failed to get user credentials: %s", err)
 			return false, internalErr.NewDefault(internalErr.UserCredentials, 402)
 		}
 	}
@@ -77,7 +79,7 @@ func (c *Controller) RegisterConfirm(ctx context.Context, req *model.UserCredent
 		Token:     creds.Token,
 		TokenType: creds.TokenType,
 	}); err != nil {
-		logs.Errorf("Failed to update user credentials: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to update user credentials: %s", err)
 		return false, internalErr.New(internalErr.UserCredentialsUpdate, err, 403)
 	}
 
@@ -87,7 +89,9 @@ func (c *Controller) RegisterConfirm(ctx context.Context, req *model.UserCredent
 // Login verifies user credentials by email and password,
 // generates a JWT token for authentication, and returns it.
 // Returns an error if credentials are invalid or token generation fails.
-func (c *Controller) Login(ctx context.Context, req *model.AuthenticateRequest) (*model.AuthenticateResult, error) {
+func (c *Controller) Login(ctx context.Context, req *model.AuthenticateRequest) (_ *model.AuthenticateResult, err error) {
+	defer func() { observeLogin(err) }()
+
 	creds, err := c.repo.FindUserCredentials(ctx, model.UserCredentialsRequest{
 		Email: req.Email,
 	})
@@ -96,7 +100,7 @@ func (c *Controller) Login(ctx context.Context, req *model.AuthenticateRequest)
 		if err == pgx.ErrNoRows {
 			return nil, internalErr.New(internalErr.UserCredentialsNotExists, err, 404)
 		} else {
-			logs.Errorf("Failed to get user credentials: %s", err)
+			logs.Ctx(ctx).Errorf("Failed to get user credentials: %s", err)
 			return nil, internalErr.New(internalErr.UserCredentials, err, 405)
 		}
 
@@ -119,7 +123,7 @@ func (c *Controller) Login(ctx context.Context, req *model.AuthenticateRequest)
 
 	token, err := c.createJWTToken(ctx, &creds, req)
 	if err != nil {
-		logs.Errorf("Unable to create session for google JWT: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to create session for google JWT: %s", err)
 		return nil, internalErr.New(internalErr.Token, err, 602)
 	}
 
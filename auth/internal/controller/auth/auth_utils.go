@@ -48,13 +48,13 @@ func (c *Controller) createUserCredentials(ctx context.Context, tx pgx.Tx, req *
 	userId, err := c.repo.TxCreateUser(ctx, tx, user)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		pgErr, isPgError := err.(*pgconn.PgError)
 		if isPgError && pgErr.Code == pgerrcode.UniqueViolation {
 			return nil, internalErr.New(internalErr.TxNotUnique, pgErr, 103)
 		} else {
-			logs.Errorf("Failed to create user during registration transaction: %s", err)
+			logs.Ctx(ctx).Errorf("Failed to create user during registration transaction: %s", err)
 			return nil, internalErr.New(internalErr.TxUnknown, err, 104)
 		}
 	}
@@ -80,13 +80,13 @@ func (c *Controller) createUserCredentials(ctx context.Context, tx pgx.Tx, req *
 
 	if err := c.repo.TxNewAuthCredentials(ctx, tx, userCredentials); err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		pgErr, isPgError := err.(*pgconn.PgError)
 		if isPgError && pgErr.Code == pgerrcode.UniqueViolation {
 			return nil, internalErr.New(internalErr.TxNotUnique, pgErr, 105)
 		} else {
-			logs.Errorf("Failed to create user during registration transaction: %s", err)
+			logs.Ctx(ctx).Errorf("Failed to create user during registration transaction: %s", err)
 			return nil, internalErr.New(internalErr.TxUnknown, err, 106)
 		}
 	}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"fightbettr.com/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Login results counted by the logins metric.
+const (
+	loginSucceeded = "succeeded"
+	loginFailed    = "failed"
+)
+
+var (
+	registrations = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "auth",
+		Name:      "registrations_total",
+		Help:      "Number of registered users.",
+	})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "auth",
+		Name:      "logins_total",
+		Help:      "Number of login attempts by result.",
+	}, []string{"result"})
+)
+
+// observeLogin counts the login attempt by its result.
+func observeLogin(err error) {
+	if err != nil {
+		logins.WithLabelValues(loginFailed).Inc()
+		return
+	}
+
+	logins.WithLabelValues(loginSucceeded).Inc()
+}
@@ -14,7 +14,7 @@ import (
 func (c *Controller) Profile(ctx context.Context, req *model.UserRequest) (*model.User, error) {
 	user, err := c.repo.FindUser(ctx, req)
 	if err != nil {
-		logs.Errorf("Failed to get current user: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to get current user: %s", err)
 		return nil, internalErr.New(internalErr.DBGetUser, err, 801)
 	}
 
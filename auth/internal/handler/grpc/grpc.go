@@ -6,6 +6,7 @@ import (
 	"fightbettr.com/auth/internal/controller/auth"
 	"fightbettr.com/auth/pkg/model"
 	"fightbettr.com/gen"
+	"fightbettr.com/pkg/grpcauth"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -106,12 +107,13 @@ func (h *Handler) PasswordRecover(ctx context.Context, req *gen.PasswordRecovery
 }
 
 // Profile handles the gRPC request to fetch the user profile based on the current user ID in the context.
-// It verifies the presence of the user ID in the context, retrieves the user profile through the controller,
+// The user is the one of the verified token forwarded by the gateway; the user ID of the request
+// may name another user for admins only. It retrieves the user profile through the controller,
 // and returns the profile information or an error if fetching the profile fails.
 func (h *Handler) Profile(ctx context.Context, req *gen.ProfileRequest) (*gen.ProfileResponse, error) {
-	currentUserId := req.UserId
-	if currentUserId == 0 {
-		return nil, status.Errorf(codes.InvalidArgument, "request has no id")
+	currentUserId, err := grpcauth.UserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
 	}
 
 	profileReq := &model.UserRequest{UserId: currentUserId}
@@ -8,11 +8,17 @@ import (
 	grpchandler "fightbettr.com/auth/internal/handler/grpc"
 	"fightbettr.com/auth/pkg/version"
 	"fightbettr.com/gen"
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/grpcauth"
+	"fightbettr.com/pkg/health"
 	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/metrics"
 	"fightbettr.com/pkg/utils"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -22,14 +28,37 @@ type ApiService struct {
 	ServiceName string
 	Handler     *grpchandler.Handler
 	Server      *grpc.Server
+	Health      *grpchealth.Server
 }
 
-func New() ApiService {
-	srv := grpc.NewServer(grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()))
+// policy returns the permissions of the AuthService methods.
+func policy() grpcauth.Policy {
+	return grpcauth.MustPolicy(&gen.AuthService_ServiceDesc, map[string]grpcauth.Permission{
+		gen.AuthService_Register_FullMethodName:        grpcauth.Public,
+		gen.AuthService_RegisterConfirm_FullMethodName: grpcauth.Public,
+		gen.AuthService_Login_FullMethodName:           grpcauth.Public,
+		gen.AuthService_PasswordReset_FullMethodName:   grpcauth.Public,
+		gen.AuthService_PasswordRecover_FullMethodName: grpcauth.Public,
+		gen.AuthService_Profile_FullMethodName:         grpcauth.User,
+	})
+}
+
+func New(opts ...grpc.ServerOption) ApiService {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		correlation.UnaryServerInterceptor(),
+		metrics.UnaryServerInterceptor(),
+		grpcauth.UnaryServerInterceptor(policy(), grpcauth.JWTVerifier()),
+	)}, opts...)
+	srv := grpc.NewServer(opts...)
+
+	hs := health.NewGRPCServer(gen.AuthService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(srv, hs)
 
 	return ApiService{
 		ServiceName: version.Name,
 		Server:      srv,
+		Health:      hs,
 	}
 }
 
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.fb_user_credentials;
+
+DROP TABLE IF EXISTS public.fb_users;
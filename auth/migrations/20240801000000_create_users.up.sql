@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS public.fb_users (
+    user_id serial NOT NULL,
+    name character varying(255) NOT NULL,
+    claim character varying(50),
+    rank character varying(50),
+    flags bigint,
+    created_at bigint NOT NULL,
+    updated_at bigint,
+    CONSTRAINT fb_users_pkey PRIMARY KEY (user_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.fb_user_credentials (
+    user_id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    password_hash text NOT NULL,
+    salt text NOT NULL,
+    token text,
+    token_type character varying(50),
+    token_expire bigint,
+    active boolean DEFAULT false NOT NULL,
+    CONSTRAINT fb_user_credentials_pkey PRIMARY KEY (user_id),
+    CONSTRAINT fb_user_credentials_email_key UNIQUE (email),
+    CONSTRAINT fb_user_credentials_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.fb_users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS fb_user_credentials_token_index ON public.fb_user_credentials USING btree (token);
@@ -0,0 +1,21 @@
+package migrations
+
+import (
+	"embed"
+
+	"fightbettr.com/pkg/migrate"
+	"fightbettr.com/pkg/pgxs"
+)
+
+// Service is the name under which the auth migrations are tracked in the schema_migrations table.
+const Service = "auth"
+
+// FS contains the versioned up and down migrations of the auth service.
+//
+//go:embed *.sql
+var FS embed.FS
+
+// New creates a migrator for the auth schema that uses the connection pool of the given repository.
+func New(repo pgxs.FbRepo) (*migrate.Migrator, error) {
+	return migrate.New(repo, Service, FS)
+}
@@ -11,14 +11,19 @@ import (
 	grpchandler "fightbettr.com/events/internal/handler/grpc"
 	"fightbettr.com/events/internal/repository/psql"
 	service "fightbettr.com/events/internal/service/event"
+	"fightbettr.com/gen"
 	"fightbettr.com/pkg/discovery"
-	"fightbettr.com/pkg/discovery/consul"
+	"fightbettr.com/pkg/discovery/backend"
+	"fightbettr.com/pkg/health"
 	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/metrics"
 	"fightbettr.com/pkg/model"
 	"fightbettr.com/pkg/sigx"
+	"fightbettr.com/pkg/tlsx"
+	"fightbettr.com/pkg/tracing"
+	"fightbettr.com/pkg/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.uber.org/zap"
 )
 
 var allowedApiRoutes = []string{
@@ -72,27 +77,40 @@ func runServe(cmd *cobra.Command, args []string) {
 
 	route := args[0]
 
-	app := service.New()
-
-	registry, err := consul.NewRegistry("localhost:8500")
+	tlsOpts, err := tlsx.ServerOptions(ctx, tlsx.ViperConfig())
 	if err != nil {
-		panic(err)
+		logs.Errorf("Unable to configure TLS: %s", err)
+		return
 	}
-	instanceID := discovery.GenerateInstanceID(app.ServiceName)
-	if err := registry.Register(ctx, instanceID, app.ServiceName, fmt.Sprintf("localhost:%d", port)); err != nil {
-		panic(err)
+
+	app := service.New(tlsOpts...)
+
+	shutdownTracing, err := tracing.Init(ctx, tracing.ViperConfig())
+	if err != nil {
+		logs.Errorf("Unable to initialize tracing: %s", err)
+		return
 	}
 
-	go func() {
-		for {
-			if err := registry.ReportHealthyState(instanceID, app.ServiceName); err != nil {
-				logs.Error("Failed to report healthy state", zap.Error(err))
-			}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("tracing.shutdown_timeout"))
+		defer cancel()
 
-			time.Sleep(1 * time.Second)
+		if err := shutdownTracing(ctx); err != nil {
+			logs.Errorf("Unable to flush traces: %s", err)
 		}
 	}()
 
+	registry, err := backend.FromViper()
+	if err != nil {
+		logs.Errorf("Unable to create service registry: %s", err)
+		return
+	}
+	instanceID := discovery.GenerateInstanceID(app.ServiceName)
+	if err := registry.Register(ctx, instanceID, app.ServiceName, fmt.Sprintf("localhost:%d", port)); err != nil {
+		logs.Errorf("Unable to register service: %s", err)
+		return
+	}
+
 	defer registry.Deregister(ctx, instanceID, app.ServiceName)
 
 	repo, err := psql.New(ctx)
@@ -102,13 +120,45 @@ func runServe(cmd *cobra.Command, args []string) {
 	}
 	defer repo.GracefulShutdown()
 
+	if viper.GetBool("postgres.migrate_on_start") {
+		if err := applyMigrations(ctx, repo); err != nil {
+			logs.Errorf("Unable to apply migrations: %s", err)
+			return
+		}
+	}
+
 	ctl := event.New(repo)
 	h := grpchandler.New(ctl)
 
 	app.Init(h)
 
+	// The bets are made for the users of the tokens the gateway forwards, verified with the JWT certificate.
+	if err := utils.LoadJwtParseKey(); err != nil {
+		logs.Errorf("Unable to load JWT certificate: %s", err)
+		return
+	}
+
 	viper.Set("api.route", route)
 
+	// The instance is reported healthy to the registry only while the database is reachable.
+	checker := health.New(viper.GetDuration("health.timeout"))
+	checker.Add("postgres", repo.GetPool().Ping)
+
+	go health.Watch(ctx, checker, viper.GetDuration("health.interval"),
+		health.GRPCReporter(app.Health, gen.EventService_ServiceDesc.ServiceName),
+		health.RegistryReporter(registry, instanceID, app.ServiceName),
+	)
+
+	if err := metrics.RegisterPgxPool("main", repo.GetPool()); err != nil {
+		logs.Errorf("Unable to register connection pool metrics: %s", err)
+	}
+
+	go func() {
+		if err := metrics.ListenAndServe(ctx, viper.GetString("metrics.addr")); err != nil {
+			logs.Errorf("Unable to serve metrics: %s", err)
+		}
+	}()
+
 	sigx.Listen(func(signal os.Signal) {
 		time.AfterFunc(15*time.Second, func() {
 			logs.Fatal("Failed to shutdown normally. Closed after 15 sec shutdown")
@@ -117,6 +167,7 @@ func runServe(cmd *cobra.Command, args []string) {
 			os.Exit(1)
 		})
 
+		app.Health.Shutdown()
 		app.Server.GracefulStop()
 	})
 
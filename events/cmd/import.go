@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"fightbettr.com/events/internal/controller/event"
+	fightersgateway "fightbettr.com/events/internal/gateway/fighters/grpc"
+	"fightbettr.com/events/internal/repository/psql"
+	"fightbettr.com/events/pkg/model"
+	"fightbettr.com/pkg/discovery/backend"
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/tlsx"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().String("source", "", "Path to the scraped events collection (default is import.source)")
+	importCmd.Flags().String("registry", "localhost:8500", "Address of the service registry used to reach the fighters service")
+
+	bindViperFlag(importCmd, "import.source", "source")
+	bindViperFlag(importCmd, "registry.addr", "registry")
+}
+
+// importCmd represents the import command. It is used to load the events collection produced by the scraper.
+// Fighters are matched to their ids by the fighter url through the fighters service.
+var importCmd = &cobra.Command{
+	Use:          "import",
+	Short:        "Imports scraped event cards and fight results",
+	Long:         ``,
+	SilenceUsage: true,
+	RunE:         runImport,
+}
+
+// runImport is the function executed when the import command is run.
+// It reads the events collection, resolves the fighter urls and loads the events
+// through the event controller. Fights with unknown fighters are skipped.
+func runImport(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	collection, err := readEventsCollection(viper.GetString("import.source"))
+	if err != nil {
+		return err
+	}
+
+	registry, err := backend.FromViper()
+	if err != nil {
+		return err
+	}
+
+	opts, err := tlsx.DialOptions(ctx, tlsx.ViperConfig())
+	if err != nil {
+		return err
+	}
+
+	ids, err := fightersgateway.New(registry, opts...).FighterIdsByUrls(ctx, fighterUrls(collection))
+	if err != nil {
+		logs.Errorf("Failed to resolve fighters: %s", err)
+		return err
+	}
+
+	events, skipped := resolveEvents(collection, ids)
+	for _, url := range skipped {
+		logs.Warnf("Unknown fighter '%s', fight skipped", url)
+	}
+
+	repo, err := psql.New(ctx)
+	if err != nil {
+		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return err
+	}
+	defer repo.GracefulShutdown()
+
+	res, err := event.New(repo).ImportEvents(ctx, events)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Events created: %d, Fights created: %d, Results set: %d, Events done: %d, Unknown fighters: %d\n",
+		res.EventsCreated, res.FightsCreated, res.ResultsSet, res.EventsDone, len(skipped))
+
+	return nil
+}
+
+// readEventsCollection reads the events collection from the file.
+func readEventsCollection(path string) (*model.EventsCollection, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var collection model.EventsCollection
+	if err := json.NewDecoder(file).Decode(&collection); err != nil {
+		return nil, fmt.Errorf("failed to decode events collection '%s': %w", path, err)
+	}
+
+	return &collection, nil
+}
+
+// fighterUrls returns the unique urls of all fighters of the collection.
+func fighterUrls(c *model.EventsCollection) []string {
+	seen := make(map[string]struct{})
+	var urls []string
+
+	for _, e := range c.Events {
+		for _, f := range e.Fights {
+			for _, url := range []string{f.FighterRedUrl, f.FighterBlueUrl} {
+				if _, ok := seen[url]; !ok {
+					seen[url] = struct{}{}
+					urls = append(urls, url)
+				}
+			}
+		}
+	}
+
+	return urls
+}
+
+// resolveEvents converts the scraped events to events with fighter ids.
+// Fights with a fighter that is not in ids are dropped, and the unknown urls are returned.
+func resolveEvents(c *model.EventsCollection, ids map[string]int32) ([]*model.Event, []string) {
+	var events []*model.Event
+	var unknown []string
+	seen := make(map[string]struct{})
+
+	for _, e := range c.Events {
+		event := &model.Event{Name: e.Name}
+
+		for _, f := range e.Fights {
+			redId, redOk := ids[f.FighterRedUrl]
+			blueId, blueOk := ids[f.FighterBlueUrl]
+
+			if !redOk || !blueOk {
+				for _, url := range []string{f.FighterRedUrl, f.FighterBlueUrl} {
+					if _, ok := ids[url]; ok {
+						continue
+					}
+
+					if _, reported := seen[url]; !reported {
+						seen[url] = struct{}{}
+						unknown = append(unknown, url)
+					}
+				}
+
+				continue
+			}
+
+			fight := model.Fight{
+				FighterRedId:  redId,
+				FighterBlueId: blueId,
+				FightDate:     int(e.Timestamp),
+				IsDone:        f.IsDone,
+			}
+
+			switch f.Outcome {
+			case model.OutcomeRed:
+				fight.Result = redId
+			case model.OutcomeBlue:
+				fight.Result = blueId
+			case model.OutcomeNoContest:
+				fight.NotContest = true
+			}
+
+			event.Fights = append(event.Fights, fight)
+		}
+
+		if len(event.Fights) > 0 {
+			events = append(events, event)
+		}
+	}
+
+	return events, unknown
+}
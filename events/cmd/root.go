@@ -8,6 +8,7 @@ import (
 
 	"fightbettr.com/events/pkg/logger"
 	"fightbettr.com/events/pkg/version"
+	"fightbettr.com/gen"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap/zapcore"
@@ -105,6 +106,42 @@ func setConfigDefaults() {
 	viper.SetDefault("postgres.main.port", "5432")
 	viper.SetDefault("postgres.main.name", "postgres")
 	viper.SetDefault("postgres.main.user", "postgres")
+	viper.SetDefault("postgres.migrate_on_start", false)
+
+	// import
+	viper.SetDefault("import.source", "../scraper/collection/events.json")
+
+	// auth config
+	viper.SetDefault("auth.jwt.cert", "")
+
+	// service registry
+	viper.SetDefault("registry.backend", "consul")
+	viper.SetDefault("registry.addr", "localhost:8500")
+
+	// health checks
+	viper.SetDefault("health.interval", time.Second)
+	viper.SetDefault("health.timeout", 2*time.Second)
+
+	// tracing
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlp.endpoint", "localhost:4317")
+	viper.SetDefault("tracing.otlp.insecure", true)
+	viper.SetDefault("tracing.file.path", "logs/traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("tracing.shutdown_timeout", 5*time.Second)
+
+	// gRPC TLS
+	viper.SetDefault("grpc.tls.enabled", false)
+	viper.SetDefault("grpc.tls.cert_file", "")
+	viper.SetDefault("grpc.tls.key_file", "")
+	viper.SetDefault("grpc.tls.ca_file", "")
+	viper.SetDefault("grpc.tls.client_auth", false)
+	viper.SetDefault("grpc.tls.reload_interval", time.Minute)
+	// only the gateway may set the results of the fights
+	viper.SetDefault("grpc.tls.authorize", []string{gen.EventService_SetResult_FullMethodName + "=Fightbettr-gateway-service"})
+
+	// metrics
+	viper.SetDefault("metrics.addr", "localhost:9194")
 }
 
 // bindViperPersistentFlag binds a Viper configuration flag to a persistent Cobra command flag.
@@ -113,3 +150,10 @@ func bindViperPersistentFlag(cmd *cobra.Command, viperVal, flagName string) {
 		log.Printf("Failed to bind viper flag: %s", err)
 	}
 }
+
+// bindViperFlag binds a Viper configuration flag to a local Cobra command flag.
+func bindViperFlag(cmd *cobra.Command, viperVal, flagName string) {
+	if err := viper.BindPFlag(viperVal, cmd.Flags().Lookup(flagName)); err != nil {
+		log.Printf("Failed to bind viper flag: %s", err)
+	}
+}
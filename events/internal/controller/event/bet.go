@@ -14,33 +14,35 @@ func (c *Controller) CreateBet(ctx context.Context, req *eventmodel.Bet) (int32,
 		IsoLevel: pgx.Serializable,
 	})
 	if err != nil {
-		logs.Errorf("Unable to begin transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to begin transaction: %s", err)
 		cErr := internalErr.New(internalErr.Tx, err, 112)
 		return 0, cErr
 	}
 
 	betId, err := c.repo.TxCreateBet(ctx, tx, req)
 	if err != nil {
-		logs.Errorf("Error while user credentials creation: %s", err)
+		logs.Ctx(ctx).Errorf("Error while user credentials creation: %s", err)
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		return 0, err
 	}
 
 	if txErr := tx.Commit(ctx); txErr != nil {
-		logs.Errorf("Unable to commit transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to commit transaction: %s", err)
 		cErr := internalErr.New(internalErr.TxCommit, err, 113)
 		return 0, cErr
 	}
 
+	betsPlaced.Inc()
+
 	return betId, nil
 }
 
 func (c *Controller) GetBets(ctx context.Context, userId int32) (*eventmodel.BetsResponse, error) {
 	count, err := c.repo.SearchBetsCount(ctx, userId)
 	if err != nil {
-		logs.Errorf("Failed to get bets count: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to get bets count: %s", err)
 		intErr := internalErr.NewDefault(internalErr.BetsCount, 1201)
 
 		return nil, intErr
@@ -53,7 +55,7 @@ func (c *Controller) GetBets(ctx context.Context, userId int32) (*eventmodel.Bet
 
 	bets, err := c.repo.SearchBets(ctx, userId)
 	if err != nil {
-		logs.Errorf("Failed to find bets: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to find bets: %s", err)
 		intErr := internalErr.NewDefault(internalErr.Bets, 1203)
 		return nil, intErr
 	}
@@ -16,7 +16,9 @@ type eventRepository interface {
 	pgxs.FbRepo
 
 	TxCreateEvent(ctx context.Context, tx pgx.Tx, e *eventmodel.EventRequest) (int32, error)
-	TxCreateEventFight(ctx context.Context, tx pgx.Tx, f eventmodel.Fight) error
+	TxCreateEventFight(ctx context.Context, tx pgx.Tx, f eventmodel.Fight) (int32, error)
+	GetEventIdByName(ctx context.Context, tx pgx.Tx, name string) (int32, error)
+	SearchEventFights(ctx context.Context, tx pgx.Tx, eventId int32) ([]eventmodel.Fight, error)
 	SearchEventsCount(ctx context.Context) (int32, error)
 	SearchEvents(ctx context.Context) ([]*eventmodel.Event, error)
 	TxCreateBet(ctx context.Context, tx pgx.Tx, req *eventmodel.Bet) (int32, error)
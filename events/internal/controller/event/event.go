@@ -14,24 +14,24 @@ func (c *Controller) CreateEvent(ctx context.Context, req *model.EventRequest) (
 		IsoLevel: pgx.Serializable,
 	})
 	if err != nil {
-		logs.Errorf("Unable to begin transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to begin transaction: %s", err)
 		cErr := internalErr.New(internalErr.Tx, err, 112)
 		return 0, cErr
 	}
 
 	event, err := c.handleEventCreation(ctx, tx, req)
 	if err != nil {
-		logs.Errorf("Error while user credentials creation: %s", err)
+		logs.Ctx(ctx).Errorf("Error while user credentials creation: %s", err)
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		return 0, err
 	}
 
 	if txErr := tx.Commit(ctx); txErr != nil {
-		logs.Errorf("Unable to commit transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to commit transaction: %s", err)
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		cErr := internalErr.New(internalErr.TxCommit, err, 113)
 		return 0, cErr
@@ -43,7 +43,7 @@ func (c *Controller) CreateEvent(ctx context.Context, req *model.EventRequest) (
 func (c *Controller) GetEvents(ctx context.Context) (*model.EventsResponse, error) {
 	count, err := c.repo.SearchEventsCount(ctx)
 	if err != nil {
-		logs.Errorf("Failed to get events count: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to get events count: %s", err)
 		intErr := internalErr.NewDefault(internalErr.EventsCount, 901)
 
 		return nil, intErr
@@ -55,7 +55,7 @@ func (c *Controller) GetEvents(ctx context.Context) (*model.EventsResponse, erro
 
 	events, err := c.repo.SearchEvents(ctx)
 	if err != nil {
-		logs.Errorf("Failed to find events: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to find events: %s", err)
 		intErr := internalErr.NewDefault(internalErr.Events, 903)
 		return nil, intErr
 	}
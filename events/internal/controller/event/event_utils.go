@@ -19,7 +19,7 @@ func (c *Controller) handleEventCreation(ctx context.Context, tx pgx.Tx, req *ev
 	eventId, err := c.repo.TxCreateEvent(ctx, tx, req)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 
 		if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -29,7 +29,7 @@ func (c *Controller) handleEventCreation(ctx context.Context, tx pgx.Tx, req *ev
 			}
 		} else {
 			intErr := internalErr.NewDefault(internalErr.TxUnknown, 115)
-			logs.Errorf("Failed to create event during registration transaction: %s", err)
+			logs.Ctx(ctx).Errorf("Failed to create event during registration transaction: %s", err)
 			return nil, intErr
 		}
 	}
@@ -49,9 +49,9 @@ func (c *Controller) handleEventCreation(ctx context.Context, tx pgx.Tx, req *ev
 			IsCanceled:    false,
 		}
 
-		if err := c.repo.TxCreateEventFight(ctx, tx, fight); err != nil {
+		if _, err := c.repo.TxCreateEventFight(ctx, tx, fight); err != nil {
 			if txErr := tx.Rollback(ctx); txErr != nil {
-				logs.Errorf("Unable to rollback transaction: %s", txErr)
+				logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 			}
 
 			if pgErr, ok := err.(*pgconn.PgError); ok {
@@ -61,7 +61,7 @@ func (c *Controller) handleEventCreation(ctx context.Context, tx pgx.Tx, req *ev
 				}
 			} else {
 				intErr := internalErr.NewDefault(internalErr.TxUnknown, 117)
-				logs.Errorf("Failed to create fight during registration transaction: %s", err)
+				logs.Ctx(ctx).Errorf("Failed to create fight during registration transaction: %s", err)
 				return nil, intErr
 			}
 		}
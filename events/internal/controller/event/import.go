@@ -0,0 +1,125 @@
+package event
+
+import (
+	"context"
+	"errors"
+
+	internalErr "fightbettr.com/events/pkg/errors"
+	"fightbettr.com/events/pkg/model"
+	logs "fightbettr.com/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// ImportEvents loads the events with their fights, and the results of completed fights.
+// Fighters of the events must already be resolved to their ids. Events are matched by name
+// and fights by the pair of fighters, so importing the same events twice creates nothing new.
+// Results are set only for fights that are not done yet. Every event is imported in its own
+// transaction; the import stops at the first failed event and returns the summary so far.
+func (c *Controller) ImportEvents(ctx context.Context, events []*model.Event) (*model.ImportResult, error) {
+	res := &model.ImportResult{}
+
+	for _, e := range events {
+		if err := c.importEvent(ctx, e, res); err != nil {
+			logs.Ctx(ctx).Errorf("Failed to import event '%s': %s", e.Name, err)
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// importEvent imports a single event within a transaction and updates the import summary.
+func (c *Controller) importEvent(ctx context.Context, e *model.Event, res *model.ImportResult) error {
+	tx, err := c.repo.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.Serializable,
+	})
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Unable to begin transaction: %s", err)
+		return internalErr.New(internalErr.Tx, err, 120)
+	}
+	defer tx.Rollback(ctx)
+
+	var summary model.ImportResult
+
+	eventId, err := c.repo.GetEventIdByName(ctx, tx, e.Name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		eventId, err = c.repo.TxCreateEvent(ctx, tx, &model.EventRequest{Name: e.Name})
+		summary.EventsCreated++
+	}
+	if err != nil {
+		return internalErr.New(internalErr.EventsImport, err, 906)
+	}
+
+	stored, err := c.repo.SearchEventFights(ctx, tx, eventId)
+	if err != nil {
+		return internalErr.New(internalErr.EventsImport, err, 907)
+	}
+
+	fights := make(map[[2]int32]model.Fight, len(stored))
+	for _, f := range stored {
+		fights[[2]int32{f.FighterRedId, f.FighterBlueId}] = f
+		fights[[2]int32{f.FighterBlueId, f.FighterRedId}] = f
+	}
+
+	for _, f := range e.Fights {
+		fight, ok := fights[[2]int32{f.FighterRedId, f.FighterBlueId}]
+		if !ok {
+			fight = model.Fight{
+				EventId:       eventId,
+				FighterRedId:  f.FighterRedId,
+				FighterBlueId: f.FighterBlueId,
+				FightDate:     f.FightDate,
+			}
+
+			fight.FightId, err = c.repo.TxCreateEventFight(ctx, tx, fight)
+			if err != nil {
+				return internalErr.New(internalErr.EventsImport, err, 908)
+			}
+
+			fights[[2]int32{f.FighterRedId, f.FighterBlueId}] = fight
+			summary.FightsCreated++
+		}
+
+		if !f.IsDone || fight.IsDone {
+			continue
+		}
+
+		fr := &model.FightResultRequest{
+			FightId:    fight.FightId,
+			WinnerId:   f.Result,
+			NotContest: f.NotContest,
+		}
+		if err := c.repo.SetFightResult(ctx, tx, fr); err != nil {
+			return internalErr.New(internalErr.EventsFightResult, err, 909)
+		}
+
+		summary.ResultsSet++
+	}
+
+	if summary.ResultsSet > 0 {
+		count, err := c.repo.GetUndoneFightsCount(ctx, tx, eventId)
+		if err != nil {
+			return internalErr.New(internalErr.EventIsDone, err, 910)
+		}
+
+		if count == 0 {
+			if err := c.repo.SetEventDone(ctx, tx, eventId); err != nil {
+				return internalErr.New(internalErr.EventIsDone, err, 911)
+			}
+
+			summary.EventsDone++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		logs.Ctx(ctx).Errorf("Unable to commit transaction: %s", err)
+		return internalErr.New(internalErr.TxCommit, err, 121)
+	}
+
+	res.EventsCreated += summary.EventsCreated
+	res.FightsCreated += summary.FightsCreated
+	res.ResultsSet += summary.ResultsSet
+	res.EventsDone += summary.EventsDone
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package event
+
+import (
+	"fightbettr.com/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	betsPlaced = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "events",
+		Name:      "bets_placed_total",
+		Help:      "Number of placed bets.",
+	})
+
+	fightResultsSet = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: metrics.Namespace,
+		Subsystem: "events",
+		Name:      "fight_results_set_total",
+		Help:      "Number of set fight results.",
+	})
+)
@@ -14,7 +14,7 @@ func (c *Controller) SetFightResult(ctx context.Context, req *model.FightResultR
 		IsoLevel: pgx.Serializable,
 	})
 	if err != nil {
-		logs.Errorf("Unable to begin transaction: %s", err)
+		logs.Ctx(ctx).Errorf("Unable to begin transaction: %s", err)
 		intErr := internalErr.NewDefault(internalErr.Tx, 118)
 		return 0, intErr
 	}
@@ -22,7 +22,7 @@ func (c *Controller) SetFightResult(ctx context.Context, req *model.FightResultR
 	err = c.repo.SetFightResult(ctx, tx, req)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		intErr := internalErr.New(internalErr.EventsFightResult, err, 904)
 		return 0, intErr
@@ -31,17 +31,19 @@ func (c *Controller) SetFightResult(ctx context.Context, req *model.FightResultR
 	err = c.checkEventIsDone(ctx, tx, req.FightId)
 	if err != nil {
 		if txErr := tx.Rollback(ctx); txErr != nil {
-			logs.Errorf("Unable to rollback transaction: %s", txErr)
+			logs.Ctx(ctx).Errorf("Unable to rollback transaction: %s", txErr)
 		}
 		intErr := internalErr.New(internalErr.EventIsDone, err, 905)
 		return 0, intErr
 	}
 
 	if txErr := tx.Commit(ctx); txErr != nil {
-		logs.Errorf("Unable to commit transaction: %s", txErr)
+		logs.Ctx(ctx).Errorf("Unable to commit transaction: %s", txErr)
 		intErr := internalErr.New(internalErr.TxCommit, txErr, 119)
 		return 0, intErr
 	}
 
+	fightResultsSet.Inc()
+
 	return req.FightId, nil
 }
@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+
+	fightersmodel "fightbettr.com/fighters/pkg/model"
+	"fightbettr.com/gen"
+	"fightbettr.com/internal/grpcutil"
+	"fightbettr.com/pkg/discovery"
+	"google.golang.org/grpc"
+)
+
+// Gateway defines an gRPC gateway for a fighters service.
+type Gateway struct {
+	registry discovery.Registry
+	opts     []grpc.DialOption
+}
+
+// New creates a new gRPC gateway for a fighters service that connects with the given dial options.
+func New(registry discovery.Registry, opts ...grpc.DialOption) *Gateway {
+	return &Gateway{registry: registry, opts: opts}
+}
+
+// FighterIdsByUrls resolves the fighter urls to the fighter ids.
+// It establishes a gRPC connection to the Fighters service, searches the fighters with
+// the given urls and returns a map from the fighter url to the fighter id.
+// Urls of unknown fighters are absent from the map.
+func (g *Gateway) FighterIdsByUrls(ctx context.Context, urls []string) (map[string]int32, error) {
+	ids := make(map[string]int32, len(urls))
+	if len(urls) == 0 {
+		return ids, nil
+	}
+
+	conn, err := grpcutil.ServiceConnection(ctx, "fighters-service", g.registry, g.opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	client := gen.NewFightersServiceClient(conn)
+
+	fReq := fightersmodel.FightersReqToProto(fightersmodel.FightersRequest{FighterUrls: urls})
+	resp, err := client.SearchFighters(ctx, fReq)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range resp.Fighters {
+		ids[f.FighterUrl] = f.FighterId
+	}
+
+	return ids, nil
+}
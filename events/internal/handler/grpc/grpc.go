@@ -6,6 +6,7 @@ import (
 	"fightbettr.com/events/internal/controller/event"
 	"fightbettr.com/events/pkg/model"
 	"fightbettr.com/gen"
+	"fightbettr.com/pkg/grpcauth"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -51,7 +52,13 @@ func (h *Handler) CreateBet(ctx context.Context, req *gen.CreateBetRequest) (*ge
 		return nil, status.Errorf(codes.InvalidArgument, "nil request")
 	}
 
+	userId, err := grpcauth.UserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
 	betReq := model.BetRequestFromProto(req)
+	betReq.UserId = userId
 	v, err := h.ctrl.CreateBet(ctx, betReq)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
@@ -61,7 +68,12 @@ func (h *Handler) CreateBet(ctx context.Context, req *gen.CreateBetRequest) (*ge
 }
 
 func (h *Handler) GetBets(ctx context.Context, req *gen.BetsRequest) (*gen.BetsResponse, error) {
-	resp, err := h.ctrl.GetBets(ctx, req.UserId)
+	userId, err := grpcauth.UserID(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := h.ctrl.GetBets(ctx, userId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, err.Error())
 	}
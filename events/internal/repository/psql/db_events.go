@@ -33,6 +33,26 @@ func (r *Repository) TxCreateEvent(ctx context.Context, tx pgx.Tx, e *eventmodel
 	return eventId, nil
 }
 
+// GetEventIdByName retrieves the ID of the event with the given name from the 'fb_events' table.
+// It uses a transaction (tx) if provided, otherwise, it uses the repository's connection pool.
+// If there is no such event, it returns pgx.ErrNoRows.
+func (r *Repository) GetEventIdByName(ctx context.Context, tx pgx.Tx, name string) (int32, error) {
+	q := `SELECT event_id FROM public.fb_events WHERE name = $1 ORDER BY event_id LIMIT 1`
+
+	var eventId int32
+	if tx != nil {
+		if err := tx.QueryRow(ctx, q, name).Scan(&eventId); err != nil {
+			return 0, r.DebugLogSqlErr(q, err)
+		}
+	} else {
+		if err := r.GetPool().QueryRow(ctx, q, name).Scan(&eventId); err != nil {
+			return 0, r.DebugLogSqlErr(q, err)
+		}
+	}
+
+	return eventId, nil
+}
+
 // SearchEventsCount returns the count of events in the system, considering the limit constraint.
 func (r *Repository) SearchEventsCount(ctx context.Context) (int32, error) {
 	limit := 5
@@ -9,27 +9,67 @@ import (
 
 // TxCreateEventFight creates a new fight in the 'fb_fights' table within a transaction.
 // It takes a context, a transaction, and a Fight model.
-// It returns an error if the insertion fails.
-func (r *Repository) TxCreateEventFight(ctx context.Context, tx pgx.Tx, f eventmodel.Fight) error {
+// It returns the fight ID, or an error if the insertion fails.
+func (r *Repository) TxCreateEventFight(ctx context.Context, tx pgx.Tx, f eventmodel.Fight) (int32, error) {
 	q := `INSERT INTO
-		public.fb_fights(event_id, fighter_red_id, fighter_blue_id, is_done, not_contest)
-		VALUES ($1, $2, $3, $4, $5)`
+		public.fb_fights(event_id, fighter_red_id, fighter_blue_id, is_done, not_contest, fight_date)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING fight_id`
 
 	args := []any{
-		f.EventId, f.FighterRedId, f.FighterBlueId, f.IsDone, f.NotContest,
+		f.EventId, f.FighterRedId, f.FighterBlueId, f.IsDone, f.NotContest, f.FightDate,
 	}
 
+	var fightId int32
 	if tx != nil {
-		if _, err := tx.Exec(ctx, q, args...); err != nil {
-			return r.DebugLogSqlErr(q, err)
+		if err := tx.QueryRow(ctx, q, args...).Scan(&fightId); err != nil {
+			return 0, r.DebugLogSqlErr(q, err)
 		}
 	} else {
-		if _, err := r.GetPool().Exec(ctx, q, args...); err != nil {
-			return r.DebugLogSqlErr(q, err)
+		if err := r.GetPool().QueryRow(ctx, q, args...).Scan(&fightId); err != nil {
+			return 0, r.DebugLogSqlErr(q, err)
 		}
 	}
 
-	return nil
+	return fightId, nil
+}
+
+// SearchEventFights retrieves all fights of the event from the 'fb_fights' table.
+// It uses a transaction (tx) if provided, otherwise, it uses the repository's connection pool.
+func (r *Repository) SearchEventFights(ctx context.Context, tx pgx.Tx, eventId int32) ([]eventmodel.Fight, error) {
+	q := `SELECT fight_id, event_id, fighter_red_id, fighter_blue_id, is_done,
+		is_canceled, not_contest, result, created_at, fight_date
+		FROM public.fb_fights
+		WHERE event_id = $1
+		ORDER BY fight_id`
+
+	var rows pgx.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.Query(ctx, q, eventId)
+	} else {
+		rows, err = r.GetPool().Query(ctx, q, eventId)
+	}
+	if err != nil {
+		return nil, r.DebugLogSqlErr(q, err)
+	}
+	defer rows.Close()
+
+	var fights []eventmodel.Fight
+
+	for rows.Next() {
+		var f eventmodel.Fight
+		if err := rows.Scan(
+			&f.FightId, &f.EventId, &f.FighterRedId, &f.FighterBlueId, &f.IsDone,
+			&f.IsCanceled, &f.NotContest, &f.Result, &f.CreatedAt, &f.FightDate,
+		); err != nil {
+			return nil, r.DebugLogSqlErr(q, err)
+		}
+
+		fights = append(fights, f)
+	}
+
+	return fights, rows.Err()
 }
 
 // SetFightResult updates the result of a fight in the 'fb_fights' table.
@@ -8,10 +8,16 @@ import (
 	grpchandler "fightbettr.com/events/internal/handler/grpc"
 	"fightbettr.com/events/pkg/version"
 	"fightbettr.com/gen"
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/grpcauth"
+	"fightbettr.com/pkg/health"
 	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/metrics"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -19,14 +25,36 @@ type ApiService struct {
 	ServiceName string
 	Handler     *grpchandler.Handler
 	Server      *grpc.Server
+	Health      *grpchealth.Server
 }
 
-func New() ApiService {
-	srv := grpc.NewServer(grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()))
+// policy returns the permissions of the EventService methods.
+func policy() grpcauth.Policy {
+	return grpcauth.MustPolicy(&gen.EventService_ServiceDesc, map[string]grpcauth.Permission{
+		gen.EventService_CreateEvent_FullMethodName: grpcauth.Admin,
+		gen.EventService_GetEvents_FullMethodName:   grpcauth.Public,
+		gen.EventService_CreateBet_FullMethodName:   grpcauth.User,
+		gen.EventService_GetBets_FullMethodName:     grpcauth.User,
+		gen.EventService_SetResult_FullMethodName:   grpcauth.Admin,
+	})
+}
+
+func New(opts ...grpc.ServerOption) ApiService {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		correlation.UnaryServerInterceptor(),
+		metrics.UnaryServerInterceptor(),
+		grpcauth.UnaryServerInterceptor(policy(), grpcauth.JWTVerifier()),
+	)}, opts...)
+	srv := grpc.NewServer(opts...)
+
+	hs := health.NewGRPCServer(gen.EventService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(srv, hs)
 
 	return ApiService{
 		ServiceName: version.Name,
 		Server:      srv,
+		Health:      hs,
 	}
 }
 
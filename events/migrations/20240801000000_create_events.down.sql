@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS public.fb_bets;
+
+DROP TABLE IF EXISTS public.fb_fights;
+
+DROP TABLE IF EXISTS public.fb_events;
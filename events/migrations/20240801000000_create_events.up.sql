@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS public.fb_events (
+    event_id serial NOT NULL,
+    name character varying(255) NOT NULL,
+    is_done boolean DEFAULT false NOT NULL,
+    CONSTRAINT fb_events_pkey PRIMARY KEY (event_id)
+);
+
+CREATE TABLE IF NOT EXISTS public.fb_fights (
+    fight_id serial NOT NULL,
+    event_id integer NOT NULL,
+    fighter_red_id integer NOT NULL,
+    fighter_blue_id integer NOT NULL,
+    is_done boolean DEFAULT false NOT NULL,
+    is_canceled boolean DEFAULT false NOT NULL,
+    not_contest boolean DEFAULT false NOT NULL,
+    result integer DEFAULT 0 NOT NULL,
+    created_at bigint DEFAULT (EXTRACT(epoch FROM now()))::bigint NOT NULL,
+    fight_date bigint DEFAULT 0 NOT NULL,
+    CONSTRAINT fb_fights_pkey PRIMARY KEY (fight_id),
+    CONSTRAINT fb_fights_event_id_fkey FOREIGN KEY (event_id) REFERENCES public.fb_events(event_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS fb_fights_event_id_index ON public.fb_fights USING btree (event_id);
+
+CREATE TABLE IF NOT EXISTS public.fb_bets (
+    bet_id serial NOT NULL,
+    user_id integer NOT NULL,
+    fight_id integer NOT NULL,
+    bet integer NOT NULL,
+    CONSTRAINT fb_bets_pkey PRIMARY KEY (bet_id),
+    CONSTRAINT fb_bets_fight_id_fkey FOREIGN KEY (fight_id) REFERENCES public.fb_fights(fight_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS fb_bets_user_id_index ON public.fb_bets USING btree (user_id);
@@ -53,6 +53,7 @@ const (
 	EventIsDone       = 902
 	EventsCount       = 903
 	EventsNoRows      = 904
+	EventsImport      = 905
 
 	Bets       = 1200
 	BetsCount  = 1201
@@ -92,6 +93,7 @@ var defaultErrors = DefaultMessagesList{
 	EventIsDone:                Error{ErrCode: EventIsDone, Message: "[Events]: Failed to set event done"},
 	EventsCount:                Error{ErrCode: EventIsDone, Message: "[Events]: Failed to get events count"},
 	EventsNoRows:               Error{ErrCode: EventIsDone, Message: "[Events]: No Rows"},
+	EventsImport:               Error{ErrCode: EventsImport, Message: "[Events]: Failed to import event"},
 	Bets:                       Error{ErrCode: EventIsDone, Message: "[Bets]: Error"},
 	BetsCount:                  Error{ErrCode: EventIsDone, Message: "[Bets]: Failed to get bets count"},
 	BetsNoRows:                 Error{ErrCode: EventIsDone, Message: "[Bets]: No Rows"},
@@ -0,0 +1,44 @@
+package model
+
+// Outcomes of a scraped fight.
+const (
+	OutcomeRed       = "red"
+	OutcomeBlue      = "blue"
+	OutcomeDraw      = "draw"
+	OutcomeNoContest = "no_contest"
+)
+
+// ScrapedFight represents a bout of the scraped event card with the urls of both fighters
+// and, for completed bouts, the result.
+type ScrapedFight struct {
+	FighterRedUrl  string `json:"fighterRedUrl"`
+	FighterBlueUrl string `json:"fighterBlueUrl"`
+	IsDone         bool   `json:"isDone"`
+	Outcome        string `json:"outcome,omitempty"`
+	WinnerUrl      string `json:"winnerUrl,omitempty"`
+	Method         string `json:"method,omitempty"`
+	Round          int    `json:"round,omitempty"`
+	Time           string `json:"time,omitempty"`
+}
+
+// ScrapedEvent represents an event of the scraper events collection.
+type ScrapedEvent struct {
+	Name      string         `json:"name"`
+	EventUrl  string         `json:"eventUrl"`
+	Timestamp int64          `json:"timestamp"`
+	IsDone    bool           `json:"isDone"`
+	Fights    []ScrapedFight `json:"fights"`
+}
+
+// EventsCollection represents the events collection produced by the scraper.
+type EventsCollection struct {
+	Events []ScrapedEvent
+}
+
+// ImportResult represents the summary of an events import.
+type ImportResult struct {
+	EventsCreated int `json:"events_created"`
+	FightsCreated int `json:"fights_created"`
+	ResultsSet    int `json:"results_set"`
+	EventsDone    int `json:"events_done"`
+}
@@ -13,15 +13,20 @@ import (
 	fightersgateway "fightbettr.com/fightbettr/internal/gateway/fighters/grpc"
 	httphandler "fightbettr.com/fightbettr/internal/handler/http"
 	service "fightbettr.com/fightbettr/internal/service/fightbettr"
+	"fightbettr.com/internal/grpcutil"
 	logs "fightbettr.com/pkg/logger"
 	"fightbettr.com/fightbettr/pkg/version"
 	"fightbettr.com/pkg/discovery"
-	"fightbettr.com/pkg/discovery/consul"
+	"fightbettr.com/pkg/discovery/backend"
+	"fightbettr.com/pkg/health"
+	"fightbettr.com/pkg/media"
 	"fightbettr.com/pkg/model"
+	"fightbettr.com/pkg/ratelimit"
 	"fightbettr.com/pkg/sigx"
+	"fightbettr.com/pkg/tlsx"
+	"fightbettr.com/pkg/tracing"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.uber.org/zap"
 )
 
 var allowedApiRoutes = []string{
@@ -76,34 +81,80 @@ func runServe(cmd *cobra.Command, args []string) {
 
 	route := args[0]
 
-	registry, err := consul.NewRegistry("localhost:8500")
+	shutdownTracing, err := tracing.Init(ctx, tracing.ViperConfig())
 	if err != nil {
-		panic(err)
+		logs.Errorf("Unable to initialize tracing: %s", err)
+		return
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("tracing.shutdown_timeout"))
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			logs.Errorf("Unable to flush traces: %s", err)
+		}
+	}()
+
+	registry, err := backend.FromViper()
+	if err != nil {
+		logs.Errorf("Unable to create service registry: %s", err)
+		return
 	}
 
 	instanceID := discovery.GenerateInstanceID(serviceName)
 
 	if err := registry.Register(ctx, instanceID, serviceName, fmt.Sprintf("localhost:%d", port)); err != nil {
-		panic(err)
+		logs.Errorf("Unable to register service: %s", err)
+		return
 	}
 
-	go func() {
-		for {
-			if err := registry.ReportHealthyState(instanceID, serviceName); err != nil {
-				logs.Error("Failed to report healthy state", zap.Error(err))
-			}
+	defer registry.Deregister(ctx, instanceID, serviceName)
 
-			time.Sleep(1 * time.Second)
-		}
-	}()
+	dialOpts, err := tlsx.DialOptions(ctx, tlsx.ViperConfig())
+	if err != nil {
+		logs.Errorf("Unable to configure TLS: %s", err)
+		return
+	}
 
-	defer registry.Deregister(ctx, instanceID, serviceName)
+	conns := grpcutil.NewConnManager(registry, viper.GetDuration("registry.refresh_interval"), dialOpts...)
+	defer conns.Close()
+
+	authGateway := authgateway.New(conns)
+	eventGateway := eventgateway.New(conns)
+	fightersGateway := fightersgateway.New(conns)
+	mediaDir := viper.GetString("media.dir")
+	if mediaDir == "" {
+		logs.Errorf("Unable to open media store: media.dir is not set, it must be the directory the scraper mirrors the images into")
+		return
+	}
+
+	mediaStore := media.New(media.NewDirBucket(mediaDir))
+	ctl := fightbettr.New(authGateway, eventGateway, fightersGateway, mediaStore)
+
+	// The gateway is ready while every downstream service is reachable and serving.
+	checker := health.New(viper.GetDuration("health.timeout"))
+	for _, name := range []string{"auth-service", "event-service", "fighters-service"} {
+		checker.Add(name, conns.HealthCheck(name))
+	}
+
+	go health.Watch(ctx, checker, viper.GetDuration("health.interval"),
+		health.RegistryReporter(registry, instanceID, serviceName),
+	)
+
+	limitCfg, err := ratelimit.ViperConfig()
+	if err != nil {
+		logs.Errorf("Invalid rate limit config: %s", err)
+		return
+	}
+
+	limiter, err := ratelimit.New(ctx, limitCfg)
+	if err != nil {
+		logs.Errorf("Unable to create rate limiter: %s", err)
+		return
+	}
 
-	authGateway := authgateway.New(registry)
-	eventGateway := eventgateway.New(registry)
-	fightersGateway := fightersgateway.New(registry)
-	ctl := fightbettr.New(authGateway, eventGateway, fightersGateway)
-	h := httphandler.New(ctl)
+	h := httphandler.New(ctl, checker, limiter)
 	app := service.New(h)
 
 	viper.Set("api.route", route)
@@ -3,10 +3,13 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"net/http"
 	"time"
 
 	"fightbettr.com/fightbettr/pkg/logger"
 	"fightbettr.com/fightbettr/pkg/version"
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/ratelimit"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"go.uber.org/zap/zapcore"
@@ -102,6 +105,87 @@ func setConfigDefaults() {
 	viper.SetDefault("auth.cookie_name", "fb_api_token")
 	viper.SetDefault("auth.jwt.cert", "")
 	viper.SetDefault("auth.jwt.key", "")
+
+	// media config
+	// the directory the scraper mirrors the fighter images into; it must be shared with the scraper
+	viper.SetDefault("media.dir", "")
+	viper.SetDefault("media.max_age", 24*time.Hour)
+	viper.SetDefault("media.placeholder_max_age", 5*time.Minute)
+
+	// registry config
+	viper.SetDefault("registry.backend", "consul")
+	viper.SetDefault("registry.addr", "localhost:8500")
+	viper.SetDefault("registry.refresh_interval", 5*time.Second)
+
+	// health checks
+	viper.SetDefault("health.interval", time.Second)
+	viper.SetDefault("health.timeout", 2*time.Second)
+
+	// tracing
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlp.endpoint", "localhost:4317")
+	viper.SetDefault("tracing.otlp.insecure", true)
+	viper.SetDefault("tracing.file.path", "logs/traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("tracing.shutdown_timeout", 5*time.Second)
+
+	// gRPC TLS
+	viper.SetDefault("grpc.tls.enabled", false)
+	viper.SetDefault("grpc.tls.cert_file", "")
+	viper.SetDefault("grpc.tls.key_file", "")
+	viper.SetDefault("grpc.tls.ca_file", "")
+	viper.SetDefault("grpc.tls.reload_interval", time.Minute)
+
+	// rate limits, as requests/period; the store is memory or redis
+	viper.SetDefault("ratelimit.enabled", true)
+	viper.SetDefault("ratelimit.store", "memory")
+	viper.SetDefault("ratelimit.redis.addr", "localhost:6379")
+	viper.SetDefault("ratelimit.redis.username", "")
+	viper.SetDefault("ratelimit.redis.password", "")
+	viper.SetDefault("ratelimit.redis.db", 0)
+	viper.SetDefault("ratelimit.redis.prefix", "fightbettr:ratelimit:")
+	viper.SetDefault("ratelimit.fail_open", true)
+	// limits by the CF-Connecting-IP header instead of the remote address; turn it on only when the
+	// gateway is reachable through Cloudflare alone, since any client can send the header
+	viper.SetDefault("ratelimit.trust_cf_connecting_ip", false)
+	viper.SetDefault("ratelimit.routes.login.ip", "10/1m")
+	viper.SetDefault("ratelimit.routes.register.ip", "5/1h")
+	viper.SetDefault("ratelimit.routes.password_reset.ip", "3/1h")
+	viper.SetDefault("ratelimit.routes.create_bet.ip", "60/1m")
+	viper.SetDefault("ratelimit.routes.create_bet.user", "20/1m")
+
+	// web client, the same as in the auth service
+	viper.SetDefault("web.host", "http://localhost")
+	viper.SetDefault("web.port", "4200")
+
+	// CORS; the origins are scheme://host[:port], a *. host prefix allows the subdomains;
+	// without allowed origins the origin of the web client (web.host:web.port) is allowed
+	viper.SetDefault("cors.allowed_origins", []string{})
+	viper.SetDefault("cors.allowed_methods", []string{
+		http.MethodGet,
+		http.MethodPost,
+		http.MethodPatch,
+		http.MethodPut,
+		http.MethodDelete,
+	})
+	viper.SetDefault("cors.allowed_headers", []string{
+		"Accept",
+		"Content-Type",
+		"Authorization",
+		"X-Requested-With",
+		correlation.Header,
+	})
+	viper.SetDefault("cors.exposed_headers", []string{
+		correlation.Header,
+		ratelimit.HeaderLimit,
+		ratelimit.HeaderRemaining,
+		ratelimit.HeaderReset,
+		ratelimit.HeaderRetryAfter,
+	})
+	viper.SetDefault("cors.allow_credentials", true)
+	viper.SetDefault("cors.max_age", 10*time.Minute)
+	viper.SetDefault("cors.routes.media.path_prefix", "/media")
+	viper.SetDefault("cors.routes.media.methods", []string{http.MethodGet})
 }
 
 // bindViperPersistentFlag binds a Viper configuration flag to a persistent Cobra command flag.
@@ -7,10 +7,13 @@ import (
 	eventmodel "fightbettr.com/events/pkg/model"
 	gatewaymodel "fightbettr.com/fightbettr/pkg/model"
 	fightersmodel "fightbettr.com/fighters/pkg/model"
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/media"
 )
 
 type fightersGateway interface {
 	SearchFighters(ctx context.Context, req fightersmodel.FightersRequest) ([]*fightersmodel.Fighter, error)
+	PredictFights(ctx context.Context, pairs []fightersmodel.FightPair) ([]*fightersmodel.FightPrediction, error)
 }
 
 type authGateway interface {
@@ -30,19 +33,26 @@ type eventGateway interface {
 	SetResult(ctx context.Context, req *eventmodel.FightResultRequest) (int32, error)
 }
 
+type mediaStore interface {
+	FighterImage(ctx context.Context, fighterKey string, size media.Size) (media.Image, error)
+	Placeholder(size media.Size) media.Image
+}
+
 // Controller defines a gateway service controller.
 type Controller struct {
 	authGateway     authGateway
 	eventGateway    eventGateway
 	fightersGateway fightersGateway
+	mediaStore      mediaStore
 }
 
 // New creates new Controller instance
-func New(authGateway authGateway, eventGateway eventGateway, fightersGateway fightersGateway) *Controller {
+func New(authGateway authGateway, eventGateway eventGateway, fightersGateway fightersGateway, mediaStore mediaStore) *Controller {
 	return &Controller{
 		authGateway,
 		eventGateway,
 		fightersGateway,
+		mediaStore,
 	}
 }
 
@@ -58,6 +68,31 @@ func (c *Controller) SearchFighters(ctx context.Context, req fightersmodel.Fight
 	return fighters, nil
 }
 
+// FighterImage returns the image of the fighter with the given id in the given size from the media store.
+// The images are linked to the fighters by their fighter url. It returns media.ErrNotFound when the fighter
+// does not exist or its image has not been mirrored by the scraper.
+func (c *Controller) FighterImage(ctx context.Context, fighterId int32, size media.Size) (media.Image, error) {
+	fighters, err := c.fightersGateway.SearchFighters(ctx, fightersmodel.FightersRequest{
+		FightersIds: []int32{fighterId},
+	})
+	if err != nil {
+		return media.Image{}, err
+	}
+
+	for _, f := range fighters {
+		if f.FighterId == fighterId && f.FighterUrl != "" {
+			return c.mediaStore.FighterImage(ctx, f.FighterUrl, size)
+		}
+	}
+
+	return media.Image{}, media.ErrNotFound
+}
+
+// FighterImagePlaceholder returns the image served in the given size when no image of a fighter is available.
+func (c *Controller) FighterImagePlaceholder(size media.Size) media.Image {
+	return c.mediaStore.Placeholder(size)
+}
+
 // * * * * * Auth Controller Methods * * * * *
 
 // Register handles the registration of a new user. It takes a context and a
@@ -151,6 +186,16 @@ func (c *Controller) SearchEvents(ctx context.Context) (*gatewaymodel.EventsResp
 
 	events := c.eventsPretify(resp.Events, fighters)
 
+	pairs := c.getUpcomingFightPairs(resp.Events)
+	if len(pairs) > 0 {
+		predictions, err := c.fightersGateway.PredictFights(ctx, pairs)
+		if err != nil {
+			logs.Ctx(ctx).Warnf("Failed to get fight predictions: %s", err)
+		} else {
+			c.attachPredictions(events, predictions)
+		}
+	}
+
 	return &gatewaymodel.EventsResponse{Count: resp.Count, Events: events}, nil
 }
 
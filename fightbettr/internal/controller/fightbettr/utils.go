@@ -53,3 +53,43 @@ func (c *Controller) eventsPretify(events []*eventmodel.Event, fighters []*fight
 
 	return updatedEvents
 }
+
+func (c *Controller) getUpcomingFightPairs(events []*eventmodel.Event) []fightersmodel.FightPair {
+	var pairs []fightersmodel.FightPair
+
+	for _, event := range events {
+		for _, fight := range event.Fights {
+			if fight.IsDone || fight.IsCanceled {
+				continue
+			}
+
+			pairs = append(pairs, fightersmodel.FightPair{
+				FightId:       fight.FightId,
+				FighterRedId:  fight.FighterRedId,
+				FighterBlueId: fight.FighterBlueId,
+			})
+		}
+	}
+
+	return pairs
+}
+
+func (c *Controller) attachPredictions(events []*gatewaymodel.Event, predictions []*fightersmodel.FightPrediction) {
+	list := make(map[int32]*fightersmodel.FightPrediction)
+
+	for _, p := range predictions {
+		list[p.FightId] = p
+	}
+
+	for _, event := range events {
+		for i := range event.Fights {
+			p, ok := list[event.Fights[i].FightId]
+			if !ok {
+				continue
+			}
+
+			event.Fights[i].RedWinProbability = p.RedWinProbability
+			event.Fights[i].BlueWinProbability = p.BlueWinProbability
+		}
+	}
+}
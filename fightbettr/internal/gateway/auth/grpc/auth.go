@@ -6,29 +6,27 @@ import (
 	authmodel "fightbettr.com/auth/pkg/model"
 	"fightbettr.com/gen"
 	"fightbettr.com/internal/grpcutil"
-	"fightbettr.com/pkg/discovery"
 	"fightbettr.com/pkg/model"
 )
 
 // Gateway defines an gRPC gateway for a auth service.
 type Gateway struct {
-	registry discovery.Registry
+	conns *grpcutil.ConnManager
 }
 
-// New creates a new gRPC gateway for a auth service.
-func New(registry discovery.Registry) *Gateway {
-	return &Gateway{registry}
+// New creates a new gRPC gateway for a auth service that calls it over the shared connections of conns.
+func New(conns *grpcutil.ConnManager) *Gateway {
+	return &Gateway{conns}
 }
 
 // Register registers a new user via the auth-service.
-// It establishes a gRPC connection, sends the registration request,
+// It sends the registration request over the shared connection,
 // and returns the user's credentials if successful.
 func (g *Gateway) Register(ctx context.Context, req *authmodel.RegisterRequest) (*authmodel.UserCredentials, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
@@ -44,11 +42,10 @@ func (g *Gateway) Register(ctx context.Context, req *authmodel.RegisterRequest)
 }
 
 func (g *Gateway) ConfirmRegistration(ctx context.Context, token string) (bool, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return false, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
@@ -62,14 +59,13 @@ func (g *Gateway) ConfirmRegistration(ctx context.Context, token string) (bool,
 }
 
 // Login authenticates a user via the auth-service.
-// It establishes a gRPC connection, sends the authentication request,
+// It sends the authentication request over the shared connection,
 // and returns the authentication result if successful.
 func (g *Gateway) Login(ctx context.Context, req *authmodel.AuthenticateRequest) (*authmodel.AuthenticateResult, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
@@ -85,14 +81,13 @@ func (g *Gateway) Login(ctx context.Context, req *authmodel.AuthenticateRequest)
 }
 
 // ResetPassword initiates a password reset process via the auth-service.
-// It establishes a gRPC connection, sends the password reset request,
+// It sends the password reset request over the shared connection,
 // and returns true if the request was successfully processed.
 func (g *Gateway) ResetPassword(ctx context.Context, req *authmodel.ResetPasswordRequest) (bool, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return false, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
@@ -106,14 +101,13 @@ func (g *Gateway) ResetPassword(ctx context.Context, req *authmodel.ResetPasswor
 }
 
 // PasswordRecover initiates the password recovery process via the auth-service.
-// It establishes a gRPC connection, sends the password recovery request,
+// It sends the password recovery request over the shared connection,
 // and returns true if the request was successfully processed.
 func (g *Gateway) PasswordRecover(ctx context.Context, req *authmodel.RecoverPasswordRequest) (bool, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return false, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
@@ -127,14 +121,13 @@ func (g *Gateway) PasswordRecover(ctx context.Context, req *authmodel.RecoverPas
 }
 
 // GetCurrentUser retrieves the current authenticated user's profile via the auth-service.
-// It establishes a gRPC connection, sends a profile request,
+// It sends a profile request over the shared connection,
 // and returns the user's profile if successfully retrieved.
 func (g *Gateway) GetCurrentUser(ctx context.Context) (*authmodel.User, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "auth-service", g.registry)
+	conn, err := g.conns.Conn("auth-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewAuthServiceClient(conn)
 
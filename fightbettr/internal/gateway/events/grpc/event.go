@@ -6,25 +6,23 @@ import (
 	eventmodel "fightbettr.com/events/pkg/model"
 	"fightbettr.com/gen"
 	"fightbettr.com/internal/grpcutil"
-	"fightbettr.com/pkg/discovery"
 )
 
 // Gateway defines an gRPC gateway for a event service.
 type Gateway struct {
-	registry discovery.Registry
+	conns *grpcutil.ConnManager
 }
 
-// New creates a new gRPC gateway for a event service.
-func New(registry discovery.Registry) *Gateway {
-	return &Gateway{registry}
+// New creates a new gRPC gateway for a event service that calls it over the shared connections of conns.
+func New(conns *grpcutil.ConnManager) *Gateway {
+	return &Gateway{conns}
 }
 
 func (g *Gateway) CreateEvent(ctx context.Context, req *eventmodel.EventRequest) (*eventmodel.Event, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "event-service", g.registry)
+	conn, err := g.conns.Conn("event-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewEventServiceClient(conn)
 
@@ -40,11 +38,10 @@ func (g *Gateway) CreateEvent(ctx context.Context, req *eventmodel.EventRequest)
 }
 
 func (g *Gateway) SearchEvents(ctx context.Context) (*eventmodel.EventsResponse, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "event-service", g.registry)
+	conn, err := g.conns.Conn("event-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewEventServiceClient(conn)
 
@@ -59,11 +56,10 @@ func (g *Gateway) SearchEvents(ctx context.Context) (*eventmodel.EventsResponse,
 }
 
 func (g *Gateway) CreateBet(ctx context.Context, req *eventmodel.Bet) (*eventmodel.Bet, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "event-service", g.registry)
+	conn, err := g.conns.Conn("event-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewEventServiceClient(conn)
 
@@ -79,11 +75,10 @@ func (g *Gateway) CreateBet(ctx context.Context, req *eventmodel.Bet) (*eventmod
 }
 
 func (g *Gateway) SearchBets(ctx context.Context, userId int32) (*eventmodel.BetsResponse, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "event-service", g.registry)
+	conn, err := g.conns.Conn("event-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewEventServiceClient(conn)
 
@@ -98,11 +93,10 @@ func (g *Gateway) SearchBets(ctx context.Context, userId int32) (*eventmodel.Bet
 }
 
 func (g *Gateway) SetResult(ctx context.Context, req *eventmodel.FightResultRequest) (int32, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "event-service", g.registry)
+	conn, err := g.conns.Conn("event-service")
 	if err != nil {
 		return 0, err
 	}
-	defer conn.Close()
 
 	client := gen.NewEventServiceClient(conn)
 
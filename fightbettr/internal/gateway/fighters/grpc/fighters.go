@@ -6,28 +6,26 @@ import (
 	fightersmodel "fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/gen"
 	"fightbettr.com/internal/grpcutil"
-	"fightbettr.com/pkg/discovery"
 )
 
 // Gateway defines an gRPC gateway for a fighters service.
 type Gateway struct {
-	registry discovery.Registry
+	conns *grpcutil.ConnManager
 }
 
-// New creates a new gRPC gateway for a fighters service.
-func New(registry discovery.Registry) *Gateway {
-	return &Gateway{registry}
+// New creates a new gRPC gateway for a fighters service that calls it over the shared connections of conns.
+func New(conns *grpcutil.ConnManager) *Gateway {
+	return &Gateway{conns}
 }
 
 // SearchFighters searches for fighters with the given status.
-// It establishes a gRPC connection to the Fighters service, sends a search request,
+// It sends a search request over the shared connection,
 // and returns a list of fighters.
 func (g *Gateway) SearchFighters(ctx context.Context, req fightersmodel.FightersRequest) ([]*fightersmodel.Fighter, error) {
-	conn, err := grpcutil.ServiceConnection(ctx, "fighters-service", g.registry)
+	conn, err := g.conns.Conn("fighters-service")
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Close()
 
 	client := gen.NewFightersServiceClient(conn)
 
@@ -41,3 +39,25 @@ func (g *Gateway) SearchFighters(ctx context.Context, req fightersmodel.Fighters
 
 	return fighters, nil
 }
+
+// PredictFights retrieves the win probability of each corner for the given fights.
+// It sends a prediction request over the shared connection,
+// and returns the list of predictions.
+func (g *Gateway) PredictFights(ctx context.Context, pairs []fightersmodel.FightPair) ([]*fightersmodel.FightPrediction, error) {
+	conn, err := g.conns.Conn("fighters-service")
+	if err != nil {
+		return nil, err
+	}
+
+	client := gen.NewFightersServiceClient(conn)
+
+	pReq := &gen.PredictFightsRequest{Fights: fightersmodel.FightPairsToProto(pairs)}
+	resp, err := client.PredictFights(ctx, pReq)
+	if err != nil {
+		return nil, err
+	}
+
+	predictions := fightersmodel.FightPredictionsFromProto(resp.Predictions)
+
+	return predictions, nil
+}
@@ -1,11 +1,13 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -13,8 +15,10 @@ import (
 	eventmodel "fightbettr.com/events/pkg/model"
 	fightersmodel "fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/pkg/httplib"
+	"fightbettr.com/pkg/media"
 	"fightbettr.com/pkg/model"
 	"fightbettr.com/pkg/utils"
+	"github.com/gorilla/mux"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/spf13/viper"
 
@@ -23,13 +27,14 @@ import (
 
 // * * * * * Fighters Handlers * * * * *
 
-// GetFighters handles HTTP requests to retrieve fighters based on status.
+// GetFighters handles HTTP requests to retrieve fighters based on status and lifecycle state.
 func (h *Handler) GetFighters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	status := utils.Capitalize(r.FormValue("status"))
+	state := strings.ToLower(r.FormValue("state"))
 
-	fighters, err := h.ctrl.SearchFighters(ctx, fightersmodel.FightersRequest{Status: status})
+	fighters, err := h.ctrl.SearchFighters(ctx, fightersmodel.FightersRequest{Status: status, State: state})
 	if err != nil {
 		log.Printf("Repository get error: %v\n", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -42,6 +47,46 @@ func (h *Handler) GetFighters(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// GetFighterImage handles the HTTP request for the image of the fighter with the id from the path,
+// in one of the sizes small, medium, large or original. The image is served with an entity tag and
+// a Cache-Control header of media.max_age; when the fighter has no mirrored image a placeholder is
+// served instead, cached for media.placeholder_max_age.
+func (h *Handler) GetFighterImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	vars := mux.Vars(r)
+
+	id, err := strconv.ParseInt(vars["id"], 10, 32)
+	if err != nil {
+		httplib.ErrorResponseJSON(w, http.StatusBadRequest, internalErr.MediaFormat, err)
+		return
+	}
+
+	size, err := media.ParseSize(vars["size"])
+	if err != nil {
+		httplib.ErrorResponseJSON(w, http.StatusNotFound, internalErr.MediaSize, err)
+		return
+	}
+
+	maxAge := viper.GetDuration("media.max_age")
+
+	img, err := h.ctrl.FighterImage(ctx, int32(id), size)
+	if media.IsNotFound(err) {
+		img = h.ctrl.FighterImagePlaceholder(size)
+		maxAge = viper.GetDuration("media.placeholder_max_age")
+	} else if err != nil {
+		log.Printf("Fighter image error: %v\n", err)
+		httplib.ErrorResponseJSON(w, http.StatusInternalServerError, internalErr.Media, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", img.ContentType)
+	w.Header().Set("ETag", img.ETag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+
+	// ServeContent answers conditional requests with the ETag as 304 Not Modified.
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(img.Data))
+}
+
 // * * * * * Auth Handlers * * * * *
 
 // Register handles the registration of a new user.
@@ -5,37 +5,23 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"fightbettr.com/fightbettr/internal/controller/fightbettr"
 	"fightbettr.com/fightbettr/pkg/version"
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/cors"
+	"fightbettr.com/pkg/health"
 	"fightbettr.com/pkg/httplib"
 	"fightbettr.com/pkg/ipaddr"
 	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/metrics"
+	"fightbettr.com/pkg/ratelimit"
 	"github.com/gorilla/mux"
 	"github.com/spf13/viper"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gorilla/mux/otelmux"
 )
 
-// allowedHeaders defines the list of allowed HTTP headers that can be used in CORS requests.
-var allowedHeaders = []string{
-	"Accept",
-	"Content-Type",
-	"Content-Length",
-	"Authorization",
-	"X-Requested-With",
-	// "X-HTTP-Method-Override",
-	"Cookie",
-}
-
-// allowedMethods defines the list of allowed HTTP methods that can be used in CORS requests.
-var allowedMethods = []string{
-	http.MethodOptions,
-	http.MethodGet,
-	http.MethodPost,
-	http.MethodPatch,
-	http.MethodPut,
-	http.MethodDelete,
-}
-
 // ContextKey represents a custom type for identifying context keys in HTTP requests.
 type ContextKey string
 
@@ -49,56 +35,95 @@ const (
 
 // Handler defines a movie handler.
 type Handler struct {
-	ctrl   *fightbettr.Controller
-	router *mux.Router
+	ctrl       *fightbettr.Controller
+	checker    *health.Checker
+	limiter    *ratelimit.Limiter
+	trustProxy bool
+	router     *mux.Router
+	// handler is the router behind the CORS policy.
+	handler http.Handler
 }
 
-func New(ctrl *fightbettr.Controller) *Handler {
+// New creates a new handler. The checker runs the readiness checks of the /readyz route and the limiter
+// limits the requests of the routes with a rate limit policy; a nil limiter limits none.
+func New(ctrl *fightbettr.Controller, checker *health.Checker, limiter *ratelimit.Limiter) *Handler {
 	return &Handler{
-		ctrl:   ctrl,
-		router: mux.NewRouter(),
+		ctrl:    ctrl,
+		checker: checker,
+		limiter: limiter,
+		router:  mux.NewRouter(),
 	}
 }
 
-// ServeHTTP handles the incoming HTTP request by setting CORS headers, processing preflight OPTIONS requests,
+// ServeHTTP handles the incoming HTTP request by applying the CORS policy, which answers the preflight requests,
 // and forwarding the request to the underlying router with additional context values.
-// It checks for the "Origin" header to set CORS headers and responds to OPTIONS requests appropriately.
-// The function logs details of the incoming request and forwards it to the router for further handling.
+// Every request gets a request ID, taken from the X-Request-ID header if the client sent a valid one, which is
+// returned in the X-Request-ID response header, propagated to the services and added to the logs of the request.
+// The function writes the access log line of the request with its status, latency and response size.
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
 	ctx := r.Context()
 
-	if origin := r.Header.Get("Origin"); origin != "" {
-		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Origin", r.Header.Get("Origin"))
-		w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ","))
-		w.Header().Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ","))
+	requestID := r.Header.Get(correlation.Header)
+	if !correlation.ValidRequestID(requestID) {
+		requestID = correlation.NewRequestID()
 	}
+	w.Header().Set(correlation.Header, requestID)
 
-	if r.Method == http.MethodOptions {
-		w.WriteHeader(http.StatusOK)
-		return
-	}
+	info := &requestInfo{route: unmatchedRoute}
 
 	ctx = context.WithValue(ctx, ContextKeyHost, r.Host)
 	ctx = context.WithValue(ctx, ContextKeyPath, r.URL.Path)
 	ctx = context.WithValue(ctx, ContextKeyRemoteAddr, r.RemoteAddr)
 	ctx = context.WithValue(ctx, ContextKeyCFConnectingIP, r.Header.Get(ipaddr.CFConnectingIp))
+	ctx = context.WithValue(ctx, contextKeyRequestInfo, info)
+	ctx = correlation.WithRequestID(ctx, requestID)
+	ctx = logs.With(ctx, "request_id", requestID)
+
+	rec := httplib.NewResponseRecorder(w)
+	h.handler.ServeHTTP(rec, r.WithContext(ctx))
+
+	fields := []any{
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"route", info.route,
+		"status", rec.Status(),
+		"latency", time.Since(start).Seconds(),
+		"size", rec.Size(),
+		"remote_addr", r.RemoteAddr,
+	}
+	if info.hasUser {
+		fields = append(fields, "user_id", info.userID)
+	}
 
-	logs.Infow("Handling request", "method", r.Method, "path", r.URL.Path, "query", r.URL.RawQuery)
-
-	h.router.ServeHTTP(w, r.WithContext(ctx))
+	logs.Ctx(ctx).Infow("access", fields...)
 }
 
 // RunHTTPServer starts the HTTP server for the API handler with specified routes and services.
-// It sets the health check route and adds routes for each registered service.
+// It applies the CORS policy of the cors config to every request and fails if the policy is invalid.
+// It sets the liveness (/healthz) and readiness (/readyz) probe routes and the Prometheus metrics (/metrics) route,
+// traces and counts every request of the routes with the tracing and metrics middlewares and adds routes
+// for each registered service.
 // The server listens on the specified address, and if successful, it prints the server address.
 // It returns an error if the service address is not specified or if there is an issue starting the server.
 func (h *Handler) RunHTTPServer(ctx context.Context) error {
 	serviceName := version.Name
 	httplib.SetCookieName(viper.GetString("auth.cookie_name"))
+	h.trustProxy = viper.GetBool("ratelimit.trust_cf_connecting_ip")
+
+	corsPolicy, err := cors.New(cors.ViperConfig())
+	if err != nil {
+		return err
+	}
+	h.handler = corsPolicy.Handler(h.router)
 
 	// sys routes
-	// h.router.HandleFunc("/health", h.HealthCheck).Methods(http.MethodGet)
+	h.router.HandleFunc("/healthz", health.LivenessHandler()).Methods(http.MethodGet)
+	h.router.HandleFunc("/readyz", health.ReadinessHandler(h.checker)).Methods(http.MethodGet)
+	h.router.Handle("/metrics", metrics.Handler()).Methods(http.MethodGet)
+
+	h.router.Use(otelmux.Middleware(serviceName, otelmux.WithFilter(isTraced)), metrics.HTTPMiddleware, withRoute)
 
 	h.ApplyRoutes()
 
@@ -113,16 +138,27 @@ func (h *Handler) RunHTTPServer(ctx context.Context) error {
 	return http.ListenAndServe(srvAddr, h)
 }
 
+// isTraced reports whether the request is traced. The probes and metrics scrapes are polled
+// every few seconds and would bury the traces of the API requests.
+func isTraced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	}
+
+	return true
+}
+
 // ApplyRoutes sets up the API routes for related services.
 // It associates each route with the corresponding handler method from the service.
 // The routes include user registration, login, logout, password reset, password recovery, and profile retrieval.
 func (h *Handler) ApplyRoutes() {
 	// auth
-	h.router.HandleFunc("/register", h.Register).Methods(http.MethodPost)
+	h.router.HandleFunc("/register", h.RateLimited(rateLimitRegister, h.Register)).Methods(http.MethodPost)
 	h.router.HandleFunc("/register/confirm", h.ConfirmRegistration).Methods(http.MethodPost)
-	h.router.HandleFunc("/login", h.Login).Methods(http.MethodPost)
+	h.router.HandleFunc("/login", h.RateLimited(rateLimitLogin, h.Login)).Methods(http.MethodPost)
 	h.router.HandleFunc("/logout", h.IfLoggedIn(h.Logout)).Methods(http.MethodGet)
-	h.router.HandleFunc("/password/reset", h.ResetPassword).Methods(http.MethodPost)
+	h.router.HandleFunc("/password/reset", h.RateLimited(rateLimitPasswordReset, h.ResetPassword)).Methods(http.MethodPost)
 	h.router.HandleFunc("/password/recover", h.RecoverPassword).Methods(http.MethodPost)
 
 	// profile
@@ -132,11 +168,14 @@ func (h *Handler) ApplyRoutes() {
 	h.router.HandleFunc("/create/event", h.CheckIsAdmin(h.CreateEvent)).Methods(http.MethodPost)
 	h.router.HandleFunc("/events", h.GetEvents).Methods(http.MethodGet)
 
-	h.router.HandleFunc("/create/bet", h.IfLoggedIn(h.CreateBet)).Methods(http.MethodPost)
+	h.router.HandleFunc("/create/bet", h.IfLoggedIn(h.RateLimited(rateLimitCreateBet, h.CreateBet))).Methods(http.MethodPost)
 	h.router.HandleFunc("/bets", h.IfLoggedIn(h.GetBets)).Methods(http.MethodGet)
 
 	h.router.HandleFunc("/create/result", h.CheckIsAdmin(h.AddResult)).Methods(http.MethodPost)
 
 	// fighters
 	h.router.HandleFunc("/fighters", h.GetFighters).Methods(http.MethodGet)
+
+	// media
+	h.router.HandleFunc("/media/fighters/{id:[0-9]+}/{size}", h.GetFighterImage).Methods(http.MethodGet)
 }
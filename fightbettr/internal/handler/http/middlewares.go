@@ -6,14 +6,62 @@ import (
 	"net/http"
 	"time"
 
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/grpcauth"
 	"fightbettr.com/pkg/httplib"
 	logs "fightbettr.com/pkg/logger"
 	"fightbettr.com/pkg/model"
+	"github.com/gorilla/mux"
 	"github.com/lestrrat-go/jwx/v2/jwa"
 	"github.com/lestrrat-go/jwx/v2/jwt"
 	"github.com/spf13/viper"
 )
 
+// unmatchedRoute is the route of the access log of the requests that match no route.
+const unmatchedRoute = "unmatched"
+
+// contextKeyRequestInfo is the context key of the requestInfo of a request.
+const contextKeyRequestInfo ContextKey = "request_info"
+
+// requestInfo collects the details of a request known only to the router and the handlers
+// for the access log written by ServeHTTP once the request is handled.
+type requestInfo struct {
+	route   string
+	userID  int32
+	hasUser bool
+}
+
+// withRoute is a middleware that adds the path template of the matched route to the access log
+// and to the log fields of the request.
+func withRoute(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if tpl, err := mux.CurrentRoute(r).GetPathTemplate(); err == nil {
+			if info, ok := ctx.Value(contextKeyRequestInfo).(*requestInfo); ok {
+				info.route = tpl
+			}
+
+			ctx = logs.With(ctx, "route", tpl)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// withUser adds the ID of the logged in user to the access log and to the log fields of the request,
+// and propagates it to the services.
+func withUser(ctx context.Context, userID int32) context.Context {
+	if info, ok := ctx.Value(contextKeyRequestInfo).(*requestInfo); ok {
+		info.userID = userID
+		info.hasUser = true
+	}
+
+	ctx = correlation.WithUserID(ctx, userID)
+
+	return logs.With(ctx, "user_id", userID)
+}
+
 // verifyJWT parses a raw JWT string and verifies its signature using the specified algorithm and public key.
 // It returns a parsed JWT token on success, or an error if parsing or verification fails.
 func (h *Handler) verifyJWT(jwtRawValue string) (jwt.Token, error) {
@@ -37,7 +85,7 @@ func (h *Handler) IfLoggedIn(fn http.HandlerFunc) http.HandlerFunc {
 
 		cookie, err := r.Cookie(httplib.CookieName)
 		if err != nil {
-			logs.Debugf("access token not found: %s", err)
+			logs.Ctx(ctx).Debugf("access token not found: %s", err)
 			httplib.ErrorResponseJSON(w, http.StatusUnauthorized, http.StatusUnauthorized,
 				fmt.Errorf("unauthorized request: auth cookie or headers not found"))
 			return
@@ -45,7 +93,7 @@ func (h *Handler) IfLoggedIn(fn http.HandlerFunc) http.HandlerFunc {
 
 		token, err := h.verifyJWT(cookie.Value)
 		if err != nil {
-			logs.Debugf("Failed to parse JWT token: %s", err)
+			logs.Ctx(ctx).Debugf("Failed to parse JWT token: %s", err)
 			httplib.ErrorResponseJSON(w, http.StatusUnauthorized, http.StatusUnauthorized,
 				fmt.Errorf("unauthorized request: invalid token format"))
 			return
@@ -67,6 +115,7 @@ func (h *Handler) IfLoggedIn(fn http.HandlerFunc) http.HandlerFunc {
 		uid, valid := userId.(float64)
 		if valid {
 			ctx = context.WithValue(ctx, model.ContextUserId, int32(uid))
+			ctx = withUser(ctx, int32(uid))
 		}
 
 		// TODO admin claim?
@@ -79,6 +128,8 @@ func (h *Handler) IfLoggedIn(fn http.HandlerFunc) http.HandlerFunc {
 		// }
 
 		ctx = context.WithValue(ctx, model.ContextJWTPointer, token)
+		// The services authorise the calls with the verified token the gateway forwards.
+		ctx = grpcauth.WithToken(ctx, cookie.Value)
 
 		fn(w, r.WithContext(ctx))
 	}
@@ -107,7 +158,7 @@ func (h *Handler) CheckIsAdmin(fn http.HandlerFunc) http.HandlerFunc {
 			}
 
 		} else {
-			logs.Debugf("Failed to get JWT from context")
+			logs.Ctx(ctx).Debugf("Failed to get JWT from context")
 		}
 
 		fn(w, r.WithContext(ctx))
@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	internalErr "fightbettr.com/fightbettr/pkg/errors"
+	"fightbettr.com/pkg/httplib"
+	"fightbettr.com/pkg/ipaddr"
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/model"
+	"fightbettr.com/pkg/ratelimit"
+)
+
+// Names of the rate limited routes, the keys of their policies under ratelimit.routes.
+const (
+	rateLimitLogin         = "login"
+	rateLimitRegister      = "register"
+	rateLimitPasswordReset = "password_reset"
+	rateLimitCreateBet     = "create_bet"
+)
+
+// RateLimited is a middleware that limits the requests by the policy of the route name. The requests are
+// limited by the IP address of the client and, when the middleware runs after IfLoggedIn, by the user.
+// It sets the RateLimit-* headers of the limit and responds with 429 Too Many Requests and the Retry-After
+// header when the limit is exceeded. When the limits cannot be checked the request is handled if the
+// limiter fails open, and rejected with 503 Service Unavailable otherwise.
+func (h *Handler) RateLimited(route string, fn http.HandlerFunc) http.HandlerFunc {
+	if h.limiter == nil {
+		return fn
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userId, _ := ctx.Value(model.ContextUserId).(int32)
+
+		res, err := h.limiter.Allow(ctx, route, ipaddr.ClientIP(r, h.trustProxy), userId)
+		if err != nil {
+			logs.Ctx(ctx).Errorf("Unable to check rate limit: %s", err)
+
+			if !res.Allowed {
+				httplib.ErrorResponseJSON(w, http.StatusServiceUnavailable, internalErr.RateLimitUnavailable,
+					fmt.Errorf("unable to check rate limit"))
+				return
+			}
+		}
+
+		if res.Limit > 0 {
+			ratelimit.SetHeaders(w.Header(), res)
+		}
+
+		if !res.Allowed {
+			logs.Ctx(ctx).Warnw("Rate limit exceeded", "limit", route)
+			httplib.ErrorResponseJSON(w, http.StatusTooManyRequests, internalErr.RateLimit,
+				fmt.Errorf("too many requests, retry in %d seconds", int(math.Ceil(res.RetryAfter.Seconds()))))
+			return
+		}
+
+		fn(w, r)
+	}
+}
@@ -54,6 +54,13 @@ const (
 
 	Bets      = 1200
 	CountBets = 1201
+
+	Media       = 1300
+	MediaSize   = 1301
+	MediaFormat = 1302
+
+	RateLimit            = 1400
+	RateLimitUnavailable = 1401
 )
 
 var defaultErrors = DefaultMessagesList{
@@ -89,6 +96,11 @@ var defaultErrors = DefaultMessagesList{
 	EventIsDone:                Error{ErrCode: EventIsDone, Message: "[Events]: Failed to set event done"},
 	Bets:                       Error{ErrCode: EventIsDone, Message: "[Bets]: Error"},
 	CountBets:                  Error{ErrCode: EventIsDone, Message: "[Bets]: Failed to get bets count"},
+	Media:                      Error{ErrCode: Media, Message: "[Media]: Failed to get image"},
+	MediaSize:                  Error{ErrCode: MediaSize, Message: "[Media]: Unknown image size"},
+	MediaFormat:                Error{ErrCode: MediaFormat, Message: "[Media]: Fighter id is invalid"},
+	RateLimit:                  Error{ErrCode: RateLimit, Message: "[Rate Limit]: Too many requests"},
+	RateLimitUnavailable:       Error{ErrCode: RateLimitUnavailable, Message: "[Rate Limit]: Failed to check rate limit"},
 }
 
 var unknownError = Error{ErrCode: 9999, Message: "Unknown Error"}
@@ -30,4 +30,7 @@ type Fight struct {
 	Result      int32                 `json:"result"`
 	CreatedAt   int64                 `json:"created_at"`
 	FightDate   int                   `json:"fight_date,omitempty"`
+
+	RedWinProbability  float32 `json:"redWinProbability,omitempty"`
+	BlueWinProbability float32 `json:"blueWinProbability,omitempty"`
 }
@@ -3,30 +3,38 @@ package cmd
 import (
 	"context"
 
-	"fightbettr.com/auth/pkg/cfg"
+	"fightbettr.com/fighters/pkg/cfg"
+	logs "fightbettr.com/pkg/logger"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	repoCmd.AddCommand(clearTableCmd)
+
+	clearTableCmd.Flags().Bool("hard", false, "Permanently delete fighters that are not referenced by any fight")
 }
 
-// clearTableCmd represents the clear-table command. It is used to delete all records from the fb_fighters and fb_fighter_stats tables.
-// Expects one argument - the table name.
+// clearTableCmd represents the clear-table command. It is used to delete all fighters from the fb_fighters table.
+// Fighters are soft-deleted unless the --hard flag is set.
 var clearTableCmd = &cobra.Command{
 	Use:   "clear",
-	Short: "Delete all records from the fb_fighters and fb_fighter_stats tables",
-	Args:  cobra.ExactArgs(1),
+	Short: "Delete all fighters from the fb_fighters table",
+	Args:  cobra.NoArgs,
 	Run:   runClearTable,
 }
 
 // runClearTable is the function executed when the clear-table command is run.
-// It deletes all records from the fb_fighters and fb_fighter_stats tables.
+// It soft-deletes all fighters; with --hard, fighters without fights are deleted with their stats.
+// Fighters referenced by fb_fights are never deleted permanently.
 func runClearTable(cmd *cobra.Command, args []string) {
 	ctx := context.Background()
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	hard, _ := cmd.Flags().GetBool("hard")
+
 	cfg := cfg.ViperPostgres()
-	DeleteFighterData(ctx, cfg)
+	if err := DeleteFighterData(ctx, cfg, hard); err != nil {
+		logs.Fatalf("Error while deleting fighter data: %s", err)
+	}
 }
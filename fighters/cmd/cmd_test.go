@@ -12,6 +12,7 @@ import (
 
 	"fightbettr.com/fighters/internal/repository/psql"
 	"fightbettr.com/fighters/pkg/cfg"
+	"fightbettr.com/fighters/pkg/importer"
 	fightersmodel "fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/pkg/model"
 	"github.com/spf13/cobra"
@@ -124,10 +125,41 @@ func TestValidateServerArgs(t *testing.T) {
 }
 
 func TestReadFighterData(t *testing.T) {
-	fighters, err := ReadFighterData()
+	fighters, err := ReadFighterData(context.Background(), "testdata/fighters.json")
 
 	assert.NoError(t, err)
-	assert.True(t, len(fighters) > 0)
+	assert.Len(t, fighters, 2)
+	assert.Equal(t, "https://www.ufc.com/athlete/john-doe", fighters[0].FighterUrl)
+}
+
+func TestImportSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		config   string
+		expected string
+		err      error
+	}{
+		{name: "Flag", flag: "testdata/fighters.json", config: "other.json", expected: "testdata/fighters.json"},
+		{name: "Config", config: "testdata/fighters.json", expected: "testdata/fighters.json"},
+		{name: "Not set", err: errNoSource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("import.source", tt.config)
+			defer viper.Set("import.source", "")
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("source", "", "")
+			assert.NoError(t, cmd.Flags().Set("source", tt.flag))
+
+			source, err := importSource(cmd)
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, source)
+		})
+	}
 }
 
 func TestWriteFighterData(t *testing.T) {
@@ -144,10 +176,27 @@ func TestWriteFighterData(t *testing.T) {
 		getRandomFighter(),
 	}
 
-	err := WriteFighterData(ctx, fighterData, config)
+	err := WriteFighterData(ctx, fighterData, config, false)
 	assert.NoError(t, err)
 }
 
+func TestWriteFighterDataEmptyFullRoster(t *testing.T) {
+	tests := []struct {
+		name string
+		data []fightersmodel.Fighter
+	}{
+		{name: "No fighters", data: nil},
+		{name: "No fighter urls", data: []fightersmodel.Fighter{{Name: "No Url"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WriteFighterData(context.Background(), tt.data, nil, true)
+			assert.ErrorIs(t, err, importer.ErrEmptyRoster)
+		})
+	}
+}
+
 func TestDeleteFighterData(t *testing.T) {
 	// initTestConfig()
 	// defer viper.Reset()
@@ -155,7 +204,7 @@ func TestDeleteFighterData(t *testing.T) {
 	// ctx := context.Background()
 	// config := cfg.ViperTestPostgres()
 
-	// err := DeleteFighterData(ctx, config)
+	// err := DeleteFighterData(ctx, config, false)
 	// assert.NoError(t, err)
 }
 
@@ -192,6 +241,65 @@ func TestUpdateFighter(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDiffRoster(t *testing.T) {
+	stored := testFighter
+	missing := getRandomFighter()
+
+	updated := testFighter
+	updated.FighterId = 0
+	updated.Wins = 11
+	updated.Stats.StatId = 0
+	updated.Stats.WinByKO = 6
+
+	unchanged := missing
+	unchanged.FighterId = 0
+	unchanged.Stats.StatId = 0
+	unchanged.Stats.FighterId = 0
+
+	created := getRandomFighter()
+
+	diff := DiffRoster([]*fightersmodel.Fighter{&stored, &missing}, []fightersmodel.Fighter{updated, created})
+
+	assert.Equal(t, []fightersmodel.Fighter{created}, diff.Created)
+	assert.Len(t, diff.Updated, 1)
+	assert.Equal(t, stored.FighterId, diff.Updated[0].Fighter.FighterId)
+	assert.Equal(t, []FieldChange{
+		{Field: "Wins", Old: 10, New: 11},
+		{Field: "Stats.WinByKO", Old: 5, New: 6},
+	}, diff.Updated[0].Changes)
+	assert.Equal(t, []fightersmodel.Fighter{missing}, diff.Missing)
+	assert.Empty(t, diff.Unchanged)
+
+	diff = DiffRoster([]*fightersmodel.Fighter{&stored, &missing}, []fightersmodel.Fighter{unchanged})
+
+	assert.Equal(t, []fightersmodel.Fighter{unchanged}, diff.Unchanged)
+	assert.Equal(t, []fightersmodel.Fighter{stored}, diff.Missing)
+
+	var buf bytes.Buffer
+	diff.Print(&buf)
+	assert.Contains(t, buf.String(), "Created: 0, Updated: 0, Unchanged: 1, Missing: 1")
+}
+
+func TestDiffRosterLifecycle(t *testing.T) {
+	stored := testFighter
+	stored.State = fightersmodel.StateReleased
+	stored.DeletedAt = 1700000000
+
+	retired := getRandomFighter()
+	retired.State = fightersmodel.StateRetired
+
+	incoming := testFighter
+	incoming.FighterId = 0
+	incoming.Stats.StatId = 0
+	incoming.Stats.FighterId = 0
+
+	diff := DiffRoster([]*fightersmodel.Fighter{&stored, &retired}, []fightersmodel.Fighter{incoming})
+
+	assert.Equal(t, []fightersmodel.Fighter{incoming}, diff.Unchanged)
+	assert.Empty(t, diff.Updated)
+	assert.Empty(t, diff.Missing)
+}
+
 func initTestConfig() {
 	viper.SetConfigName("config")
 	viper.AddConfigPath("../configs")
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+
+	"fightbettr.com/fighters/pkg/model"
+)
+
+// FieldChange represents a single field that differs between the stored and the imported fighter.
+type FieldChange struct {
+	Field string
+	Old   any
+	New   any
+}
+
+// FighterChange represents an imported fighter together with the fields that will be changed.
+type FighterChange struct {
+	Fighter model.Fighter
+	Changes []FieldChange
+}
+
+// RosterDiff represents the result of comparing the imported roster with the stored one.
+// Fighters are matched by their fighter_url. Missing fighters are active in the database,
+// but absent from the import; a full import marks them as released.
+type RosterDiff struct {
+	Created   []model.Fighter
+	Updated   []FighterChange
+	Unchanged []model.Fighter
+	Missing   []model.Fighter
+}
+
+// DiffRoster compares the imported fighters with the stored ones field by field.
+// Identifiers and lifecycle fields that are maintained by the database are ignored.
+func DiffRoster(existing []*model.Fighter, incoming []model.Fighter) RosterDiff {
+	var diff RosterDiff
+
+	stored := make(map[string]*model.Fighter, len(existing))
+	for _, f := range existing {
+		stored[f.FighterUrl] = f
+	}
+
+	seen := make(map[string]struct{}, len(incoming))
+
+	for _, f := range incoming {
+		seen[f.FighterUrl] = struct{}{}
+
+		old, ok := stored[f.FighterUrl]
+		if !ok {
+			diff.Created = append(diff.Created, f)
+			continue
+		}
+
+		changes := fighterChanges(*old, f)
+		if len(changes) == 0 {
+			diff.Unchanged = append(diff.Unchanged, f)
+			continue
+		}
+
+		f.FighterId = old.FighterId
+		diff.Updated = append(diff.Updated, FighterChange{Fighter: f, Changes: changes})
+	}
+
+	for _, f := range existing {
+		if f.State != "" && f.State != model.StateActive {
+			continue
+		}
+
+		if _, ok := seen[f.FighterUrl]; !ok {
+			diff.Missing = append(diff.Missing, *f)
+		}
+	}
+
+	sort.Slice(diff.Missing, func(i, j int) bool {
+		return diff.Missing[i].Name < diff.Missing[j].Name
+	})
+
+	return diff
+}
+
+// Print writes a human readable report of the diff to w.
+// Every updated fighter is listed with the old and the new value of each changed field.
+func (d RosterDiff) Print(w io.Writer) {
+	for _, f := range d.Created {
+		fmt.Fprintf(w, "[Created] %s (%s)\n", f.Name, f.FighterUrl)
+	}
+
+	for _, c := range d.Updated {
+		fmt.Fprintf(w, "[Updated] %s (%s)\n", c.Fighter.Name, c.Fighter.FighterUrl)
+		for _, ch := range c.Changes {
+			fmt.Fprintf(w, "    %s: %v -> %v\n", ch.Field, ch.Old, ch.New)
+		}
+	}
+
+	for _, f := range d.Missing {
+		fmt.Fprintf(w, "[Missing] %s (%s)\n", f.Name, f.FighterUrl)
+	}
+
+	fmt.Fprintf(w, "Created: %d, Updated: %d, Unchanged: %d, Missing: %d\n",
+		len(d.Created), len(d.Updated), len(d.Unchanged), len(d.Missing))
+}
+
+// fighterChanges returns the fields of the fighter and its stats that differ between old and new.
+func fighterChanges(old, new model.Fighter) []FieldChange {
+	changes := structChanges("", reflect.ValueOf(old), reflect.ValueOf(new), "FighterId", "Stats", "State", "DeletedAt")
+	changes = append(changes, structChanges("Stats.", reflect.ValueOf(old.Stats), reflect.ValueOf(new.Stats), "StatId", "FighterId")...)
+
+	return changes
+}
+
+// structChanges compares two values of the same struct type field by field, skipping the ignored fields.
+func structChanges(prefix string, old, new reflect.Value, ignored ...string) []FieldChange {
+	var changes []FieldChange
+
+	skip := make(map[string]struct{}, len(ignored))
+	for _, name := range ignored {
+		skip[name] = struct{}{}
+	}
+
+	t := old.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := t.Field(i).Name
+		if _, ok := skip[name]; ok {
+			continue
+		}
+
+		o, n := old.Field(i).Interface(), new.Field(i).Interface()
+		if !reflect.DeepEqual(o, n) {
+			changes = append(changes, FieldChange{Field: prefix + name, Old: o, New: n})
+		}
+	}
+
+	return changes
+}
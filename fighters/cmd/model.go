@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCmd.AddCommand(modelCmd)
+}
+
+var modelCmd = &cobra.Command{
+	Use:          "model",
+	Short:        "helps to manage the fight prediction model",
+	Long:         ``,
+	SilenceUsage: true,
+}
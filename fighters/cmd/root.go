@@ -106,6 +106,42 @@ func setConfigDefaults() {
 	viper.SetDefault("postgres.main.port", "5432")
 	viper.SetDefault("postgres.main.name", "postgres")
 	viper.SetDefault("postgres.main.user", "postgres")
+	viper.SetDefault("postgres.migrate_on_start", false)
+
+	// prediction model
+	viper.SetDefault("prediction.model_path", "./configs/prediction_model.json")
+	// the model is trained on the fb_fights table of the events service, so both services must use one database
+	viper.SetDefault("prediction.shared_database", false)
+
+	// auth config
+	viper.SetDefault("auth.jwt.cert", "")
+
+	// service registry
+	viper.SetDefault("registry.backend", "consul")
+	viper.SetDefault("registry.addr", "localhost:8500")
+
+	// health checks
+	viper.SetDefault("health.interval", time.Second)
+	viper.SetDefault("health.timeout", 2*time.Second)
+
+	// tracing
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlp.endpoint", "localhost:4317")
+	viper.SetDefault("tracing.otlp.insecure", true)
+	viper.SetDefault("tracing.file.path", "logs/traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("tracing.shutdown_timeout", 5*time.Second)
+
+	// gRPC TLS
+	viper.SetDefault("grpc.tls.enabled", false)
+	viper.SetDefault("grpc.tls.cert_file", "")
+	viper.SetDefault("grpc.tls.key_file", "")
+	viper.SetDefault("grpc.tls.ca_file", "")
+	viper.SetDefault("grpc.tls.client_auth", false)
+	viper.SetDefault("grpc.tls.reload_interval", time.Minute)
+
+	// metrics
+	viper.SetDefault("metrics.addr", "localhost:9193")
 }
 
 // bindViperPersistentFlag binds a Viper configuration flag to a persistent Cobra command flag.
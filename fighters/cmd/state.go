@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"fightbettr.com/fighters/pkg/cfg"
+	"fightbettr.com/fighters/pkg/model"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	repoCmd.AddCommand(setStateCmd)
+}
+
+// setStateCmd represents the state command. It is used to change the lifecycle state of a fighter.
+// Expects two arguments - the fighter id and one of the states: active, retired, released, deceased.
+var setStateCmd = &cobra.Command{
+	Use:   "state [fighter_id] [state]",
+	Short: "Sets the lifecycle state of a fighter (active, retired, released, deceased)",
+	Args:  cobra.ExactArgs(2),
+	RunE:  runSetState,
+}
+
+// runSetState is the function executed when the state command is run.
+func runSetState(cmd *cobra.Command, args []string) error {
+	fighterId, err := strconv.ParseInt(args[0], 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid fighter id '%s'", args[0])
+	}
+
+	state := model.FighterState(args[1])
+
+	if err := SetFighterState(context.Background(), cfg.ViperPostgres(), int32(fighterId), state); err != nil {
+		return err
+	}
+
+	fmt.Printf("Fighter %d is %s\n", fighterId, state)
+
+	return nil
+}
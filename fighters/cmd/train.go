@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"fightbettr.com/fighters/internal/controller/fighters"
+	"fightbettr.com/fighters/internal/prediction"
+	"fightbettr.com/fighters/internal/repository/psql"
+	"fightbettr.com/fighters/pkg/cfg"
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/pgxs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	modelCmd.AddCommand(trainModelCmd)
+
+	defaults := prediction.DefaultTrainOptions()
+
+	trainModelCmd.Flags().String("output", "", "Model file path (default is prediction.model_path from config)")
+	trainModelCmd.Flags().Int("iterations", defaults.Iterations, "Number of gradient descent iterations")
+	trainModelCmd.Flags().Float64("learning_rate", defaults.LearningRate, "Gradient descent learning rate")
+	trainModelCmd.Flags().Float64("l2", defaults.L2, "L2 regularization strength")
+}
+
+// trainModelCmd represents the train command. It is used to fit the prediction model on stored fight results.
+var trainModelCmd = &cobra.Command{
+	Use:   "train",
+	Short: "Trains the fight prediction model on finished fights",
+	Long: `Trains the fight prediction model on the finished fights of the events service.
+
+The fights are read from the fb_fights table of the events service, so the fighters and events
+services must use one database and prediction.shared_database must be set to true.
+
+Every fight is paired with the snapshots of both fighters taken by the last import before the
+fight date, not with their current stats, which already include the outcome of the fight.
+Snapshots are taken on every import, so fights that happened before the first import of their
+fighters are not used for training.`,
+	RunE: runTrainModel,
+}
+
+// errNoSharedDatabase is returned by the train command when prediction.shared_database is not set.
+var errNoSharedDatabase = errors.New("prediction.shared_database is not set: " +
+	"the model is trained on the fights of the events service, which must share the database with the fighters service")
+
+// runTrainModel is the function executed when the train command is run.
+// It trains the model and writes it to the output path, which the serve command loads on start.
+func runTrainModel(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if !viper.GetBool("prediction.shared_database") {
+		return errNoSharedDatabase
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+	if output == "" {
+		output = viper.GetString("prediction.model_path")
+	}
+
+	opts := prediction.DefaultTrainOptions()
+	opts.Iterations, _ = cmd.Flags().GetInt("iterations")
+	opts.LearningRate, _ = cmd.Flags().GetFloat64("learning_rate")
+	opts.L2, _ = cmd.Flags().GetFloat64("l2")
+
+	m, err := TrainPredictionModel(ctx, cfg.ViperPostgres(), opts)
+	if err != nil {
+		return err
+	}
+
+	if err := m.Save(output); err != nil {
+		logs.Errorf("Unable to save prediction model: %s", err)
+		return err
+	}
+
+	fmt.Printf("Model trained on %d fights and saved to %s\n", m.Samples, output)
+	for i, name := range m.Features {
+		fmt.Printf("  %-18s %+.4f\n", name, m.Weights[i])
+	}
+
+	return nil
+}
+
+// TrainPredictionModel connects to the database and trains the prediction model on all finished fights.
+func TrainPredictionModel(ctx context.Context, cfg *pgxs.Config, opts prediction.TrainOptions) (*prediction.Model, error) {
+	rep, err := psql.New(ctx, cfg)
+	if err != nil {
+		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return nil, err
+	}
+	defer rep.GracefulShutdown()
+
+	return fighters.New(rep).TrainPredictionModel(ctx, opts)
+}
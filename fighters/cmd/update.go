@@ -2,16 +2,25 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"os"
 
 	"fightbettr.com/fighters/pkg/cfg"
 	logs "fightbettr.com/pkg/logger"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func init() {
 	repoCmd.AddCommand(updateRosterCmd)
+
+	updateRosterCmd.Flags().String("source", "", "Fighters source: file, directory, HTTP(S) URL or - for stdin (required unless import.source is set in config)")
+	updateRosterCmd.Flags().Bool("full", false, "Treat the source as the complete roster and mark absent active fighters as released")
+	updateRosterCmd.Flags().Bool("dry-run", false, "Print the changes without writing them to the database")
 }
 
+var errNoSource = errors.New("the fighters source should be specified with --source or import.source")
+
 // updateRosterCmd represents the update command. It is used to update the fighters table using a JSON list.
 var updateRosterCmd = &cobra.Command{
 	Use:              "update",
@@ -22,14 +31,53 @@ var updateRosterCmd = &cobra.Command{
 }
 
 // runUpdate is the function executed when the update command is run.
-// The table will be updated from the existing JSON file.
+// The table will be updated from the source given by the --source flag or the import.source config value,
+// one of which is required.
+// With the --dry-run flag the per-field diff is printed and nothing is written.
+// With the --full flag active fighters absent from the source are marked as released.
 func runUpdate(cmd *cobra.Command, args []string) {
 	ctx := context.Background()
-	fighters, err := ReadFighterData()
+
+	source, err := importSource(cmd)
+	if err != nil {
+		logs.Fatal(err)
+	}
+
+	fighters, err := ReadFighterData(ctx, source)
 	if err != nil {
 		logs.Fatalf("Error while reading figheter data: %s", err)
 	}
 
 	cfg := cfg.ViperPostgres()
-	WriteFighterData(ctx, fighters, cfg)
+
+	if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+		diff, err := DiffFighterData(ctx, fighters, cfg)
+		if err != nil {
+			logs.Fatalf("Error while comparing fighter data: %s", err)
+		}
+
+		diff.Print(os.Stdout)
+		return
+	}
+
+	full, _ := cmd.Flags().GetBool("full")
+
+	if err := WriteFighterData(ctx, fighters, cfg, full); err != nil {
+		logs.Fatalf("Error while writing fighter data: %s", err)
+	}
+}
+
+// importSource returns the fighters source given by the --source flag or, if the flag is empty,
+// the import.source config value. It returns errNoSource if neither is set.
+func importSource(cmd *cobra.Command) (string, error) {
+	source, _ := cmd.Flags().GetString("source")
+	if source == "" {
+		source = viper.GetString("import.source")
+	}
+
+	if source == "" {
+		return "", errNoSource
+	}
+
+	return source, nil
 }
@@ -2,13 +2,14 @@ package cmd
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
 
+	"fightbettr.com/fighters/internal/controller/fighters"
 	"fightbettr.com/fighters/internal/repository/psql"
+	"fightbettr.com/fighters/internal/roster"
 	internalErr "fightbettr.com/fighters/pkg/errors"
+	"fightbettr.com/fighters/pkg/importer"
 	"fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/pkg/httplib"
 	logs "fightbettr.com/pkg/logger"
@@ -18,92 +19,150 @@ import (
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
-// ReadFighterData reads fighter data from a JSON file and returns a slice of model.Fighter.
-// The file path is set to "../scraper/collection/fighters.json".
-func ReadFighterData() ([]model.Fighter, error) {
-	filePath := "../../scraper/collection/fighters.json"
+// ReadFighterData reads fighter data from the given source and returns a slice of model.Fighter.
+// The source is a file, a directory of collection shards, an HTTP(S) URL or "-" for stdin.
+// Both the {"Fighters": [...]} envelope and NDJSON are accepted, and every fighter is validated
+// against the versioned fighter schema.
+func ReadFighterData(ctx context.Context, source string) ([]model.Fighter, error) {
+	return roster.Read(ctx, source)
+}
+
+// WriteFighterData writes fighter data to a PostgreSQL database using the provided context,
+// and a slice of model.Fighter. It connects to the database using the configuration
+// from ViperPostgres and imports the fighters through the fighters controller, the same way
+// as the ImportFighters gRPC method: all fighters are upserted in a single transaction, so the
+// import is either applied as a whole or not at all. Running the same import twice is a no-op.
+// If full is true, the data is treated as the complete roster and active fighters
+// absent from it are marked as released in the same transaction. A full import without
+// any fighter with a fighter url is refused, since it would release every active fighter.
+func WriteFighterData(ctx context.Context, data []model.Fighter, cfg *pgxs.Config, full bool) error {
+	unique, _ := importer.Unique(data)
+
+	if full && len(unique) == 0 {
+		return fmt.Errorf("full import refused: %w, no fighter has a fighter url", importer.ErrEmptyRoster)
+	}
 
-	jsonData, err := os.ReadFile(filePath)
+	rep, err := psql.New(ctx, cfg)
 	if err != nil {
-		return nil, err
+		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return err
 	}
+	defer rep.PoolClose()
 
-	var fightersData struct {
-		Fighters []model.Fighter `json:"Fighters"`
+	existing, err := rep.SearchFighters(ctx, nil)
+	if err != nil {
+		logs.Errorf("Failed to find fighters: %s", err)
+		return err
 	}
 
-	if err := json.Unmarshal(jsonData, &fightersData); err != nil {
-		return nil, err
+	diff := DiffRoster(existing, unique)
+
+	ctrl := fighters.New(rep)
+
+	var res model.ImportResult
+	if full {
+		res, err = ctrl.ImportRoster(ctx, data)
+	} else {
+		res, err = ctrl.ImportFighters(ctx, data)
+	}
+	if err != nil {
+		intErr := internalErr.NewDefault(internalErr.TxUnknown, 124)
+		return httplib.NewApiErrFromInternalErr(intErr, http.StatusInternalServerError)
 	}
 
-	return fightersData.Fighters, nil
+	fmt.Printf("Created: %d, Updated: %d, Unchanged: %d, Missing: %d, Released: %d, Skipped: %d\n",
+		len(diff.Created), len(diff.Updated), len(diff.Unchanged), len(diff.Missing), res.Released, res.Skipped)
+
+	return nil
 }
 
-// WriteFighterData writes fighter data to a PostgreSQL database using the provided context,
-// and a slice of model.Fighter. It connects to the database using the configuration
-// from ViperPostgres and performs create or update operations for each fighter.
-func WriteFighterData(ctx context.Context, data []model.Fighter, cfg *pgxs.Config) error {
+// DiffFighterData compares fighter data with the fighters stored in the PostgreSQL database
+// without writing anything. It is used by the dry-run mode of the update command.
+func DiffFighterData(ctx context.Context, data []model.Fighter, cfg *pgxs.Config) (RosterDiff, error) {
 	rep, err := psql.New(ctx, cfg)
 	if err != nil {
 		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return RosterDiff{}, err
 	}
 	defer rep.PoolClose()
 
-	counter := 1
-
-	for _, fighter := range data {
-		fighterId, err := rep.FindFighter(ctx, fighter)
-		if err != nil {
-			if err == pgx.ErrNoRows {
-				if err := createFighter(ctx, rep, fighter); err != nil {
-					logs.Errorf("Error while fighter transaction: %s", err)
-					return err
-				}
-
-				fmt.Printf("[Operation №%d] Created: %s\n", counter, fighter.Name)
-			} else {
-				logs.Errorf("Failed to find fighter: %s", err)
-				return err
-			}
-		} else {
-			fighter.FighterId = fighterId
-
-			if err := updateFighter(ctx, rep, fighter); err != nil {
-				logs.Errorf("Error while fighter transaction: %s", err)
-				return err
-			}
-
-			fmt.Printf("[Operation №%d] Updated: %s\n", counter, fighter.Name)
-		}
-		counter += 1
+	existing, err := rep.SearchFighters(ctx, nil)
+	if err != nil {
+		logs.Errorf("Failed to find fighters: %s", err)
+		return RosterDiff{}, err
 	}
 
-	return nil
+	unique, _ := importer.Unique(data)
+
+	return DiffRoster(existing, unique), nil
 }
 
-// DeleteFighterData deletes all records from the fb_fighters and fb_fighter_stats tables.
-func DeleteFighterData(ctx context.Context, cfg *pgxs.Config) error {
+// DeleteFighterData removes fighters from the fb_fighters table.
+// By default all fighters are soft-deleted and stay available to the fights that reference them.
+// If hard is true, fighters that are not referenced by any fight are deleted permanently
+// together with their stats, and the referenced ones are soft-deleted.
+func DeleteFighterData(ctx context.Context, cfg *pgxs.Config, hard bool) error {
 	rep, err := psql.New(ctx, cfg)
 	if err != nil {
 		logs.Errorf("Unable to start postgresql connection: %s", err)
 		return err
 	}
+	defer rep.PoolClose()
 
-	fightersTableNames := []string{"fb_fighter_stats", "fb_fighters"}
-	handledTableNames := []string{}
+	tx, err := rep.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+	if err != nil {
+		logs.Errorf("Unable to begin transaction: %s", err)
+		return err
+	}
+	defer tx.Rollback(ctx)
 
-	for _, name := range fightersTableNames {
-		err = rep.DeleteRecords(ctx, name)
+	var deleted int64
+	if hard {
+		deleted, err = rep.DeleteUnreferencedFighters(ctx, tx)
 		if err != nil {
-			logs.Fatalf("Error deleting records: %s", err)
+			logs.Errorf("Error deleting records: %s", err)
 			return err
 		}
+	}
 
-		handledTableNames = append(handledTableNames, name)
+	softDeleted, err := rep.SoftDeleteFighters(ctx, tx)
+	if err != nil {
+		logs.Errorf("Error soft-deleting records: %s", err)
+		return err
 	}
 
-	for _, name := range handledTableNames {
-		fmt.Printf("All records from table '%s' deleted successfully\n", name)
+	if err := tx.Commit(ctx); err != nil {
+		logs.Errorf("Unable to commit transaction: %s", err)
+		return err
+	}
+
+	fmt.Printf("Deleted: %d, Soft-deleted: %d\n", deleted, softDeleted)
+
+	return nil
+}
+
+// SetFighterState changes the lifecycle state of the fighter with the given id.
+func SetFighterState(ctx context.Context, cfg *pgxs.Config, fighterId int32, state model.FighterState) error {
+	if !state.IsValid() {
+		return fmt.Errorf("unknown fighter state '%s'", state)
+	}
+
+	rep, err := psql.New(ctx, cfg)
+	if err != nil {
+		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return err
+	}
+	defer rep.PoolClose()
+
+	if err := rep.SetFighterState(ctx, nil, fighterId, state); err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("fighter %d not found", fighterId)
+		}
+
+		logs.Errorf("Failed to set fighter state: %s", err)
+		return err
 	}
 
 	return nil
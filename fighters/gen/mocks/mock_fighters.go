@@ -185,6 +185,21 @@ func (mr *MockFightersRepositoryMockRecorder) GracefulShutdown() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GracefulShutdown", reflect.TypeOf((*MockFightersRepository)(nil).GracefulShutdown))
 }
 
+// ReleaseMissingFighters mocks base method.
+func (m *MockFightersRepository) ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMissingFighters", ctx, tx, urls)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseMissingFighters indicates an expected call of ReleaseMissingFighters.
+func (mr *MockFightersRepositoryMockRecorder) ReleaseMissingFighters(ctx, tx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMissingFighters", reflect.TypeOf((*MockFightersRepository)(nil).ReleaseMissingFighters), ctx, tx, urls)
+}
+
 // SanitizeString mocks base method.
 func (m *MockFightersRepository) SanitizeString(s string) string {
 	m.ctrl.T.Helper()
@@ -199,6 +214,21 @@ func (mr *MockFightersRepositoryMockRecorder) SanitizeString(s any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SanitizeString", reflect.TypeOf((*MockFightersRepository)(nil).SanitizeString), s)
 }
 
+// SearchFightResults mocks base method.
+func (m *MockFightersRepository) SearchFightResults(ctx context.Context) ([]model.FightResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchFightResults", ctx)
+	ret0, _ := ret[0].([]model.FightResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchFightResults indicates an expected call of SearchFightResults.
+func (mr *MockFightersRepositoryMockRecorder) SearchFightResults(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchFightResults", reflect.TypeOf((*MockFightersRepository)(nil).SearchFightResults), ctx)
+}
+
 // SearchFighters mocks base method.
 func (m *MockFightersRepository) SearchFighters(ctx context.Context, req *model.FightersRequest) ([]*model.Fighter, error) {
 	m.ctrl.T.Helper()
@@ -257,3 +287,18 @@ func (mr *MockFightersRepositoryMockRecorder) UpdateFighterStats(ctx, tx, stats
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFighterStats", reflect.TypeOf((*MockFightersRepository)(nil).UpdateFighterStats), ctx, tx, stats)
 }
+
+// UpsertFighters mocks base method.
+func (m *MockFightersRepository) UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertFighters", ctx, tx, fighters)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertFighters indicates an expected call of UpsertFighters.
+func (mr *MockFightersRepositoryMockRecorder) UpsertFighters(ctx, tx, fighters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertFighters", reflect.TypeOf((*MockFightersRepository)(nil).UpsertFighters), ctx, tx, fighters)
+}
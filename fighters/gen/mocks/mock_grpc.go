@@ -40,6 +40,51 @@ func (m *MockFightersController) EXPECT() *MockFightersControllerMockRecorder {
 	return m.recorder
 }
 
+// ImportFighters mocks base method.
+func (m *MockFightersController) ImportFighters(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportFighters", ctx, fighters)
+	ret0, _ := ret[0].(model.ImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportFighters indicates an expected call of ImportFighters.
+func (mr *MockFightersControllerMockRecorder) ImportFighters(ctx, fighters any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportFighters", reflect.TypeOf((*MockFightersController)(nil).ImportFighters), ctx, fighters)
+}
+
+// PredictFights mocks base method.
+func (m *MockFightersController) PredictFights(ctx context.Context, pairs []model.FightPair) ([]*model.FightPrediction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PredictFights", ctx, pairs)
+	ret0, _ := ret[0].([]*model.FightPrediction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PredictFights indicates an expected call of PredictFights.
+func (mr *MockFightersControllerMockRecorder) PredictFights(ctx, pairs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PredictFights", reflect.TypeOf((*MockFightersController)(nil).PredictFights), ctx, pairs)
+}
+
+// ReleaseMissingFighters mocks base method.
+func (m *MockFightersController) ReleaseMissingFighters(ctx context.Context, urls []string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseMissingFighters", ctx, urls)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseMissingFighters indicates an expected call of ReleaseMissingFighters.
+func (mr *MockFightersControllerMockRecorder) ReleaseMissingFighters(ctx, urls any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseMissingFighters", reflect.TypeOf((*MockFightersController)(nil).ReleaseMissingFighters), ctx, urls)
+}
+
 // SearchFighters mocks base method.
 func (m *MockFightersController) SearchFighters(ctx context.Context, req *model.FightersRequest) ([]*model.Fighter, error) {
 	m.ctrl.T.Helper()
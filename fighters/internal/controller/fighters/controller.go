@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 
+	"fightbettr.com/fighters/internal/prediction"
+	"fightbettr.com/fighters/pkg/importer"
 	"fightbettr.com/fighters/pkg/model"
 	logs "fightbettr.com/pkg/logger"
 	"fightbettr.com/pkg/pgxs"
@@ -22,11 +24,15 @@ type FightersRepository interface {
 	CreateNewFighterStats(ctx context.Context, tx pgx.Tx, stats model.FighterStats) error
 	UpdateFighter(ctx context.Context, tx pgx.Tx, fighter model.Fighter) (int32, error)
 	UpdateFighterStats(ctx context.Context, tx pgx.Tx, stats model.FighterStats) error
+	UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error)
+	ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error)
+	SearchFightResults(ctx context.Context) ([]model.FightResult, error)
 }
 
 // Controller defines a metadata service controller.
 type Controller struct {
-	repo FightersRepository
+	repo      FightersRepository
+	predictor *prediction.Model
 }
 
 // New creates a Fighters service controller.
@@ -42,7 +48,7 @@ func New(repo FightersRepository) *Controller {
 func (c *Controller) SearchFightersCount(ctx context.Context, req *model.FightersRequest) (int32, error) {
 	count, err := c.repo.SearchFightersCount(ctx, req)
 	if err != nil {
-		logs.Errorf("Failed to get fighters count: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to get fighters count: %s", err)
 
 		// TODO errors package for grpc. Mb it should be handled by a handler on higher level
 		// httplib.ErrorResponseJSON(w, http.StatusInternalServerError, internalErr.CountFighters, err)
@@ -61,7 +67,7 @@ func (c *Controller) SearchFightersCount(ctx context.Context, req *model.Fighter
 func (c *Controller) SearchFighters(ctx context.Context, req *model.FightersRequest) ([]*model.Fighter, error) {
 	count, err := c.repo.SearchFightersCount(ctx, req)
 	if err != nil {
-		logs.Errorf("Failed to get fighters count: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to get fighters count: %s", err)
 
 		// TODO errors package for grpc. Mb it should be handled by a handler on higher level
 		// httplib.ErrorResponseJSON(w, http.StatusInternalServerError, internalErr.CountFighters, err)
@@ -75,7 +81,7 @@ func (c *Controller) SearchFighters(ctx context.Context, req *model.FightersRequ
 
 	fighters, err := c.repo.SearchFighters(ctx, req)
 	if err != nil {
-		logs.Errorf("Failed to find fighters: %s", err)
+		logs.Ctx(ctx).Errorf("Failed to find fighters: %s", err)
 
 		// TODO errors package for grpc. Mb it should be handled by a handler on higher level
 		// httplib.ErrorResponseJSON(w, http.StatusInternalServerError, internalErr.Fighters, err)
@@ -85,3 +91,49 @@ func (c *Controller) SearchFighters(ctx context.Context, req *model.FightersRequ
 
 	return fighters, nil
 }
+
+// ImportFighters upserts the provided fighters by their fighter url in a single transaction.
+// Fighters without a fighter url are skipped, and for a duplicated fighter url the last entry is used.
+// It returns the number of created or changed fighters together with the number of skipped ones.
+func (c *Controller) ImportFighters(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error) {
+	res, err := importer.New(c.repo).Import(ctx, fighters)
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Failed to import fighters: %s", err)
+		return model.ImportResult{}, err
+	}
+
+	fightersImported.Add(float64(res.Affected))
+
+	return res, nil
+}
+
+// ImportRoster imports the provided fighters like ImportFighters and treats them as the complete roster:
+// the active fighters absent from it are marked as released in the same transaction. It returns
+// importer.ErrEmptyRoster if no fighter has a fighter url.
+func (c *Controller) ImportRoster(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error) {
+	res, err := importer.New(c.repo).ImportRoster(ctx, fighters)
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Failed to import roster: %s", err)
+		return model.ImportResult{}, err
+	}
+
+	fightersImported.Add(float64(res.Affected))
+	fightersReleased.Add(float64(res.Released))
+
+	return res, nil
+}
+
+// ReleaseMissingFighters marks the active fighters whose fighter url is not in the urls of a complete
+// roster as released. Empty urls are ignored, and importer.ErrEmptyRoster is returned if no url is left.
+// It returns the number of released fighters.
+func (c *Controller) ReleaseMissingFighters(ctx context.Context, urls []string) (int64, error) {
+	released, err := importer.New(c.repo).Release(ctx, urls)
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Failed to release missing fighters: %s", err)
+		return 0, err
+	}
+
+	fightersReleased.Add(float64(released))
+
+	return released, nil
+}
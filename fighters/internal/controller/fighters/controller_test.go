@@ -6,7 +6,9 @@ import (
 	"testing"
 
 	"fightbettr.com/fighters/gen/mocks"
+	"fightbettr.com/fighters/internal/prediction"
 	"fightbettr.com/fighters/internal/repository/psql"
+	"fightbettr.com/fighters/pkg/importer"
 	"fightbettr.com/fighters/pkg/model"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -155,3 +157,247 @@ func TestSearchFighters(t *testing.T) {
 		})
 	}
 }
+
+func TestPredictFights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockFightersRepository(ctrl)
+
+	red := &model.Fighter{FighterId: 1, Wins: 10, Stats: model.FighterStats{StrAccuracy: 60}}
+	blue := &model.Fighter{FighterId: 2, Wins: 2, Loses: 8, Stats: model.FighterStats{StrAccuracy: 30}}
+
+	m, err := prediction.Train([]prediction.Sample{{Red: red, Blue: blue, RedWon: true}}, prediction.DefaultTrainOptions())
+	assert.NoError(t, err)
+
+	pairs := []model.FightPair{
+		{FightId: 1, FighterRedId: 1, FighterBlueId: 2},
+		{FightId: 2, FighterRedId: 1, FighterBlueId: 3},
+	}
+
+	t.Run("Model not loaded", func(t *testing.T) {
+		controller := &Controller{repo: mockRepo}
+
+		predictions, err := controller.PredictFights(context.Background(), pairs)
+
+		assert.Nil(t, predictions)
+		assert.Equal(t, prediction.ErrModelNotLoaded, err)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		controller := &Controller{repo: mockRepo, predictor: m}
+
+		mockRepo.EXPECT().
+			SearchFighters(gomock.Any(), &model.FightersRequest{FightersIds: []int32{1, 2, 3}}).
+			Return(nil, errors.New("database error")).
+			Times(1)
+
+		predictions, err := controller.PredictFights(context.Background(), pairs)
+
+		assert.Nil(t, predictions)
+		assert.Equal(t, errors.New("database error"), err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		controller := &Controller{repo: mockRepo, predictor: m}
+
+		mockRepo.EXPECT().
+			SearchFighters(gomock.Any(), &model.FightersRequest{FightersIds: []int32{1, 2, 3}}).
+			Return([]*model.Fighter{red, blue}, nil).
+			Times(1)
+
+		predictions, err := controller.PredictFights(context.Background(), pairs)
+
+		assert.NoError(t, err)
+		assert.Len(t, predictions, 1)
+		assert.Equal(t, int32(1), predictions[0].FightId)
+		assert.Greater(t, predictions[0].RedWinProbability, float32(0.5))
+		assert.InDelta(t, 1, predictions[0].RedWinProbability+predictions[0].BlueWinProbability, 1e-6)
+	})
+}
+
+func TestTrainPredictionModel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockFightersRepository(ctrl)
+
+	controller := &Controller{
+		repo: mockRepo,
+	}
+
+	red := &model.Fighter{FighterId: 1, Wins: 10, Stats: model.FighterStats{StrAccuracy: 60}}
+	blue := &model.Fighter{FighterId: 2, Wins: 2, Loses: 8, Stats: model.FighterStats{StrAccuracy: 30}}
+
+	t.Run("Repository error", func(t *testing.T) {
+		mockRepo.EXPECT().
+			SearchFightResults(gomock.Any()).
+			Return(nil, errors.New("database error")).
+			Times(1)
+
+		m, err := controller.TrainPredictionModel(context.Background(), prediction.DefaultTrainOptions())
+
+		assert.Nil(t, m)
+		assert.Equal(t, errors.New("database error"), err)
+	})
+
+	t.Run("No samples", func(t *testing.T) {
+		mockRepo.EXPECT().
+			SearchFightResults(gomock.Any()).
+			Return([]model.FightResult{}, nil).
+			Times(1)
+
+		m, err := controller.TrainPredictionModel(context.Background(), prediction.DefaultTrainOptions())
+
+		assert.Nil(t, m)
+		assert.Equal(t, prediction.ErrNoSamples, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.EXPECT().
+			SearchFightResults(gomock.Any()).
+			Return([]model.FightResult{
+				{FightId: 1, FighterRedId: 1, FighterBlueId: 2, WinnerId: 1, Red: red, Blue: blue},
+				{FightId: 2, FighterRedId: 2, FighterBlueId: 1, WinnerId: 1, Red: blue, Blue: red},
+				{FightId: 3, FighterRedId: 1, FighterBlueId: 2, WinnerId: 2, Red: red},
+			}, nil).
+			Times(1)
+
+		m, err := controller.TrainPredictionModel(context.Background(), prediction.DefaultTrainOptions())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, m.Samples, "the fight without a snapshot is skipped")
+
+		redWin, _ := m.Predict(red, blue)
+		assert.Greater(t, redWin, 0.5)
+	})
+}
+
+func TestImportFighters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockFightersRepository(ctrl)
+
+	controller := &Controller{
+		repo: mockRepo,
+	}
+
+	tests := []struct {
+		name           string
+		fighters       []model.Fighter
+		mockUpsert     []model.Fighter
+		mockAffected   int64
+		mockErr        error
+		expectedResult model.ImportResult
+		expectedErr    error
+	}{
+		{
+			name:           "Empty import",
+			fighters:       nil,
+			expectedResult: model.ImportResult{},
+		},
+		{
+			name:           "Fighters without url are skipped",
+			fighters:       []model.Fighter{{Name: "No Url"}},
+			expectedResult: model.ImportResult{Skipped: 1},
+		},
+		{
+			name: "Duplicated fighters use the last entry",
+			fighters: []model.Fighter{
+				{Name: "Jon Jones", FighterUrl: "url-1"},
+				{Name: "No Url"},
+				{Name: "Amanda Nunes", FighterUrl: "url-2"},
+				{Name: "Jon 'Bones' Jones", FighterUrl: "url-1"},
+			},
+			mockUpsert: []model.Fighter{
+				{Name: "Jon 'Bones' Jones", FighterUrl: "url-1"},
+				{Name: "Amanda Nunes", FighterUrl: "url-2"},
+			},
+			mockAffected:   2,
+			expectedResult: model.ImportResult{Affected: 2, Skipped: 1},
+		},
+		{
+			name:           "Upsert error",
+			fighters:       []model.Fighter{{Name: "Jon Jones", FighterUrl: "url-1"}},
+			mockUpsert:     []model.Fighter{{Name: "Jon Jones", FighterUrl: "url-1"}},
+			mockErr:        errors.New("database error"),
+			expectedResult: model.ImportResult{},
+			expectedErr:    errors.New("database error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.mockUpsert != nil {
+				mockRepo.EXPECT().
+					UpsertFighters(gomock.Any(), nil, tc.mockUpsert).
+					Return(tc.mockAffected, tc.mockErr).
+					Times(1)
+			}
+
+			res, err := controller.ImportFighters(context.Background(), tc.fighters)
+
+			assert.Equal(t, tc.expectedResult, res)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
+
+func TestReleaseMissingFighters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockRepo := mocks.NewMockFightersRepository(ctrl)
+
+	controller := &Controller{
+		repo: mockRepo,
+	}
+
+	tests := []struct {
+		name             string
+		urls             []string
+		mockUrls         []string
+		mockReleased     int64
+		mockErr          error
+		expectedReleased int64
+		expectedErr      error
+	}{
+		{
+			name:        "Empty roster",
+			urls:        nil,
+			expectedErr: importer.ErrEmptyRoster,
+		},
+		{
+			name:        "Roster without urls",
+			urls:        []string{"", ""},
+			expectedErr: importer.ErrEmptyRoster,
+		},
+		{
+			name:             "Empty urls are ignored",
+			urls:             []string{"url-1", "", "url-2"},
+			mockUrls:         []string{"url-1", "url-2"},
+			mockReleased:     3,
+			expectedReleased: 3,
+		},
+		{
+			name:        "Release error",
+			urls:        []string{"url-1"},
+			mockUrls:    []string{"url-1"},
+			mockErr:     errors.New("database error"),
+			expectedErr: errors.New("database error"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if tc.mockUrls != nil {
+				mockRepo.EXPECT().
+					ReleaseMissingFighters(gomock.Any(), nil, tc.mockUrls).
+					Return(tc.mockReleased, tc.mockErr).
+					Times(1)
+			}
+
+			released, err := controller.ReleaseMissingFighters(context.Background(), tc.urls)
+
+			assert.Equal(t, tc.expectedReleased, released)
+			assert.Equal(t, tc.expectedErr, err)
+		})
+	}
+}
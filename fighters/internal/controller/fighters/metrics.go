@@ -0,0 +1,21 @@
+package fighters
+
+import (
+	"fightbettr.com/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var fightersImported = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "fighters",
+	Name:      "fighters_imported_total",
+	Help:      "Number of fighters inserted or updated by the roster imports.",
+})
+
+var fightersReleased = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: metrics.Namespace,
+	Subsystem: "fighters",
+	Name:      "fighters_released_total",
+	Help:      "Number of active fighters released because they are absent from a complete roster.",
+})
@@ -0,0 +1,112 @@
+package fighters
+
+import (
+	"context"
+
+	"fightbettr.com/fighters/internal/prediction"
+	"fightbettr.com/fighters/pkg/model"
+	logs "fightbettr.com/pkg/logger"
+)
+
+// SetPredictionModel sets the model used by PredictFights.
+func (c *Controller) SetPredictionModel(m *prediction.Model) {
+	c.predictor = m
+}
+
+// PredictFights returns the win probability of each corner for the provided fights.
+// Fights with a fighter that can not be found are skipped.
+// If no prediction model is loaded, it returns prediction.ErrModelNotLoaded.
+func (c *Controller) PredictFights(ctx context.Context, pairs []model.FightPair) ([]*model.FightPrediction, error) {
+	if c.predictor == nil {
+		return nil, prediction.ErrModelNotLoaded
+	}
+
+	fighters, err := c.fightersByIds(ctx, pairIds(pairs))
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Failed to find fighters for prediction: %s", err)
+		return nil, err
+	}
+
+	predictions := make([]*model.FightPrediction, 0, len(pairs))
+
+	for _, p := range pairs {
+		red, okRed := fighters[p.FighterRedId]
+		blue, okBlue := fighters[p.FighterBlueId]
+		if !okRed || !okBlue {
+			continue
+		}
+
+		redWin, blueWin := c.predictor.Predict(red, blue)
+
+		predictions = append(predictions, &model.FightPrediction{
+			FightId:            p.FightId,
+			FighterRedId:       p.FighterRedId,
+			FighterBlueId:      p.FighterBlueId,
+			RedWinProbability:  float32(redWin),
+			BlueWinProbability: float32(blueWin),
+		})
+	}
+
+	return predictions, nil
+}
+
+// TrainPredictionModel fits a new prediction model on the finished fights stored in the database.
+// Every fight is paired with the snapshots of both fighters taken before the fight, not with their
+// current stats, which already include the outcome of the fight; fights without snapshots are not returned
+// by the repository, so only the fights after the first import of their fighters are used.
+func (c *Controller) TrainPredictionModel(ctx context.Context, opts prediction.TrainOptions) (*prediction.Model, error) {
+	results, err := c.repo.SearchFightResults(ctx)
+	if err != nil {
+		logs.Ctx(ctx).Errorf("Failed to find fight results: %s", err)
+		return nil, err
+	}
+
+	samples := make([]prediction.Sample, 0, len(results))
+
+	for _, r := range results {
+		if r.Red == nil || r.Blue == nil {
+			logs.Ctx(ctx).Warnf("Fight %d skipped: no fighter snapshot before the fight", r.FightId)
+			continue
+		}
+
+		samples = append(samples, prediction.Sample{Red: r.Red, Blue: r.Blue, RedWon: r.WinnerId == r.FighterRedId})
+	}
+
+	return prediction.Train(samples, opts)
+}
+
+// fightersByIds retrieves fighters with the given ids and maps them by id.
+func (c *Controller) fightersByIds(ctx context.Context, ids []int32) (map[int32]*model.Fighter, error) {
+	list := make(map[int32]*model.Fighter)
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	fighters, err := c.repo.SearchFighters(ctx, &model.FightersRequest{FightersIds: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range fighters {
+		list[f.FighterId] = f
+	}
+
+	return list, nil
+}
+
+// pairIds returns the unique fighter ids of both corners of the given fights.
+func pairIds(pairs []model.FightPair) []int32 {
+	var ids []int32
+	seen := make(map[int32]struct{})
+
+	for _, p := range pairs {
+		for _, id := range []int32{p.FighterRedId, p.FighterBlueId} {
+			if _, exists := seen[id]; !exists {
+				seen[id] = struct{}{}
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	return ids
+}
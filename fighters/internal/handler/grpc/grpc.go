@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"fightbettr.com/fighters/internal/controller/fighters"
+	"fightbettr.com/fighters/internal/prediction"
+	"fightbettr.com/fighters/pkg/importer"
 	"fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/gen"
 	"google.golang.org/grpc/codes"
@@ -14,6 +16,9 @@ import (
 type FightersController interface {
 	SearchFightersCount(ctx context.Context, req *model.FightersRequest) (int32, error)
 	SearchFighters(ctx context.Context, req *model.FightersRequest) ([]*model.Fighter, error)
+	PredictFights(ctx context.Context, pairs []model.FightPair) ([]*model.FightPrediction, error)
+	ImportFighters(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error)
+	ReleaseMissingFighters(ctx context.Context, urls []string) (int64, error)
 }
 
 // Handler defines a Fighters gRPC handler.
@@ -36,6 +41,7 @@ func (h *Handler) SearchFightersCount(ctx context.Context, req *gen.FightersRequ
 
 	fReq := &model.FightersRequest{
 		Status: req.Status,
+		State:  req.State,
 	}
 
 	v, err := h.ctrl.SearchFightersCount(ctx, fReq)
@@ -57,6 +63,8 @@ func (h *Handler) SearchFighters(ctx context.Context, req *gen.FightersRequest)
 	fReq := &model.FightersRequest{
 		Status:      req.Status,
 		FightersIds: req.FightersIds,
+		State:       req.State,
+		FighterUrls: req.FighterUrls,
 	}
 
 	f, err := h.ctrl.SearchFighters(ctx, fReq)
@@ -70,3 +78,67 @@ func (h *Handler) SearchFighters(ctx context.Context, req *gen.FightersRequest)
 		Fighters: model.FightersToProto(f),
 	}, nil
 }
+
+// PredictFights returns the win probability of each corner for the fights in the request.
+// If the prediction model is not loaded, it returns a FailedPrecondition error.
+func (h *Handler) PredictFights(ctx context.Context, req *gen.PredictFightsRequest) (*gen.PredictFightsResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil request")
+	}
+
+	p, err := h.ctrl.PredictFights(ctx, model.FightPairsFromProto(req.Fights))
+	if err != nil && errors.Is(err, prediction.ErrModelNotLoaded) {
+		return nil, status.Errorf(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &gen.PredictFightsResponse{
+		Predictions: model.FightPredictionsToProto(p),
+	}, nil
+}
+
+// ImportFighters upserts the fighters of the request by their fighter url.
+// It is used by the scraper to push scraped fighters to the service without a file handoff.
+// It returns the number of created or changed fighters and the number of fighters skipped
+// because of a missing fighter url.
+func (h *Handler) ImportFighters(ctx context.Context, req *gen.ImportFightersRequest) (*gen.ImportFightersResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil request")
+	}
+
+	fighters := make([]model.Fighter, len(req.Fighters))
+	for i, f := range req.Fighters {
+		fighters[i] = *model.FighterFromProto(f)
+	}
+
+	res, err := h.ctrl.ImportFighters(ctx, fighters)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &gen.ImportFightersResponse{
+		Affected: res.Affected,
+		Skipped:  res.Skipped,
+	}, nil
+}
+
+// ReleaseMissingFighters marks the active fighters absent from the fighter urls of the request as released.
+// It is called by the scraper after the fighters of a complete scrape are imported. A request without
+// any fighter url is rejected, since it would release every active fighter.
+func (h *Handler) ReleaseMissingFighters(ctx context.Context, req *gen.ReleaseMissingFightersRequest) (*gen.ReleaseMissingFightersResponse, error) {
+	if req == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nil request")
+	}
+
+	released, err := h.ctrl.ReleaseMissingFighters(ctx, req.FighterUrls)
+	if err != nil {
+		if errors.Is(err, importer.ErrEmptyRoster) {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, status.Errorf(codes.Internal, err.Error())
+	}
+
+	return &gen.ReleaseMissingFightersResponse{Released: released}, nil
+}
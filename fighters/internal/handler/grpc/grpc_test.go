@@ -8,6 +8,8 @@ import (
 
 	"fightbettr.com/fighters/gen/mocks"
 	"fightbettr.com/fighters/internal/controller/fighters"
+	"fightbettr.com/fighters/internal/prediction"
+	"fightbettr.com/fighters/pkg/importer"
 	"fightbettr.com/fighters/pkg/model"
 	"fightbettr.com/gen"
 	"go.uber.org/mock/gomock"
@@ -160,3 +162,219 @@ func TestSearchFighters(t *testing.T) {
 		})
 	}
 }
+
+func TestPredictFights(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtrl := mocks.NewMockFightersController(ctrl)
+	handler := &Handler{ctrl: mockCtrl}
+	ctx := context.Background()
+
+	req := &gen.PredictFightsRequest{
+		Fights: []*gen.FightPair{{FightId: 1, FighterRedId: 1, FighterBlueId: 2}},
+	}
+
+	tests := []struct {
+		name            string
+		req             *gen.PredictFightsRequest
+		mockBehavior    func()
+		expectedResp    *gen.PredictFightsResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name:            "Nil request",
+			req:             nil,
+			mockBehavior:    func() {},
+			expectedResp:    nil,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Model not loaded",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().PredictFights(ctx, gomock.Any()).Return(nil, prediction.ErrModelNotLoaded)
+			},
+			expectedResp:    nil,
+			expectedErrCode: codes.FailedPrecondition,
+		},
+		{
+			name: "Controller error",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().PredictFights(ctx, gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			expectedResp:    nil,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Success",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().PredictFights(ctx, []model.FightPair{{FightId: 1, FighterRedId: 1, FighterBlueId: 2}}).Return([]*model.FightPrediction{
+					{FightId: 1, FighterRedId: 1, FighterBlueId: 2, RedWinProbability: 0.7, BlueWinProbability: 0.3},
+				}, nil)
+			},
+			expectedResp: &gen.PredictFightsResponse{
+				Predictions: []*gen.FightPrediction{
+					{FightId: 1, FighterRedId: 1, FighterBlueId: 2, RedWinProbability: 0.7, BlueWinProbability: 0.3},
+				},
+			},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			resp, err := handler.PredictFights(ctx, tc.req)
+			assert.Equal(t, tc.expectedErrCode, status.Code(err))
+
+			if tc.expectedResp == nil {
+				assert.Equal(t, true, resp == nil)
+				return
+			}
+
+			assert.Equal(t, len(tc.expectedResp.Predictions), len(resp.Predictions))
+			for i, p := range tc.expectedResp.Predictions {
+				assert.Equal(t, p.FightId, resp.Predictions[i].FightId)
+				assert.Equal(t, p.RedWinProbability, resp.Predictions[i].RedWinProbability)
+				assert.Equal(t, p.BlueWinProbability, resp.Predictions[i].BlueWinProbability)
+			}
+		})
+	}
+}
+
+func TestImportFighters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtrl := mocks.NewMockFightersController(ctrl)
+	handler := &Handler{ctrl: mockCtrl}
+	ctx := context.Background()
+
+	req := &gen.ImportFightersRequest{
+		Fighters: []*gen.Fighter{{Name: "Jon Jones", Height: 76, FighterUrl: "url-1", Stats: &gen.FighterStats{WinByKO: 10}}},
+	}
+
+	tests := []struct {
+		name            string
+		req             *gen.ImportFightersRequest
+		mockBehavior    func()
+		expectedResp    *gen.ImportFightersResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name:            "Nil request",
+			req:             nil,
+			mockBehavior:    func() {},
+			expectedResp:    nil,
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Controller error",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().ImportFighters(ctx, gomock.Any()).Return(model.ImportResult{}, errors.New("some error"))
+			},
+			expectedResp:    nil,
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Success",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().ImportFighters(ctx, []model.Fighter{
+					{Name: "Jon Jones", Height: 76, FighterUrl: "url-1", Stats: model.FighterStats{WinByKO: 10}},
+				}).Return(model.ImportResult{Affected: 1}, nil)
+			},
+			expectedResp:    &gen.ImportFightersResponse{Affected: 1},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			resp, err := handler.ImportFighters(ctx, tc.req)
+			assert.Equal(t, tc.expectedErrCode, status.Code(err))
+
+			if tc.expectedResp == nil {
+				assert.Equal(t, true, resp == nil)
+				return
+			}
+
+			assert.Equal(t, tc.expectedResp.Affected, resp.Affected)
+			assert.Equal(t, tc.expectedResp.Skipped, resp.Skipped)
+		})
+	}
+}
+
+func TestReleaseMissingFighters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCtrl := mocks.NewMockFightersController(ctrl)
+	handler := &Handler{ctrl: mockCtrl}
+	ctx := context.Background()
+
+	req := &gen.ReleaseMissingFightersRequest{FighterUrls: []string{"url-1", "url-2"}}
+
+	tests := []struct {
+		name            string
+		req             *gen.ReleaseMissingFightersRequest
+		mockBehavior    func()
+		expectedResp    *gen.ReleaseMissingFightersResponse
+		expectedErrCode codes.Code
+	}{
+		{
+			name:            "Nil request",
+			req:             nil,
+			mockBehavior:    func() {},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Empty roster",
+			req:  &gen.ReleaseMissingFightersRequest{},
+			mockBehavior: func() {
+				mockCtrl.EXPECT().ReleaseMissingFighters(ctx, gomock.Any()).Return(int64(0), importer.ErrEmptyRoster)
+			},
+			expectedErrCode: codes.InvalidArgument,
+		},
+		{
+			name: "Controller error",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().ReleaseMissingFighters(ctx, req.FighterUrls).Return(int64(0), errors.New("some error"))
+			},
+			expectedErrCode: codes.Internal,
+		},
+		{
+			name: "Success",
+			req:  req,
+			mockBehavior: func() {
+				mockCtrl.EXPECT().ReleaseMissingFighters(ctx, req.FighterUrls).Return(int64(3), nil)
+			},
+			expectedResp:    &gen.ReleaseMissingFightersResponse{Released: 3},
+			expectedErrCode: codes.OK,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			resp, err := handler.ReleaseMissingFighters(ctx, tc.req)
+			assert.Equal(t, tc.expectedErrCode, status.Code(err))
+
+			if tc.expectedResp == nil {
+				assert.Equal(t, true, resp == nil)
+				return
+			}
+
+			assert.Equal(t, tc.expectedResp.Released, resp.Released)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package prediction
+
+import "fightbettr.com/fighters/pkg/model"
+
+// FeatureNames lists the stat differentials used by the model, in the order returned by Differentials.
+// The names are stored alongside the weights so a trained model file can be read without the code.
+var FeatureNames = []string{
+	"str_accuracy",
+	"sig_str_landed",
+	"sig_str_absorbed",
+	"sig_str_defense",
+	"tkd_accuracy",
+	"takedown_avg",
+	"takedown_defense",
+	"submission_avg",
+	"knockdown_avg",
+	"finish_rate",
+	"win_rate",
+	"height",
+	"reach",
+	"age",
+}
+
+// Differentials returns the red-minus-blue difference of every feature in FeatureNames.
+// Swapping the corners negates every value, which keeps the model symmetric.
+func Differentials(red, blue *model.Fighter) []float64 {
+	r := features(red)
+	b := features(blue)
+
+	diff := make([]float64, len(r))
+	for i := range r {
+		diff[i] = r[i] - b[i]
+	}
+
+	return diff
+}
+
+// features extracts the raw feature values of a single fighter.
+func features(f *model.Fighter) []float64 {
+	s := f.Stats
+
+	return []float64{
+		float64(s.StrAccuracy),
+		float64(s.SigStrLanded),
+		float64(s.SigStrAbs),
+		float64(s.SigStrDefense),
+		float64(s.TkdAccuracy),
+		float64(s.TakedownAvg),
+		float64(s.TakedownDefense),
+		float64(s.SubmissionAvg),
+		float64(s.KnockdownAvg),
+		ratio(s.WinByKO+s.WinBySub, f.Wins),
+		ratio(f.Wins, f.Wins+f.Loses+f.Draw),
+		float64(f.Height),
+		float64(f.Reach),
+		float64(f.Age),
+	}
+}
+
+// ratio returns part/total, or 0 when total is not positive.
+func ratio(part, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,98 @@
+package prediction
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+
+	"fightbettr.com/fighters/pkg/model"
+)
+
+// ModelVersion is the version of the serialized model format.
+const ModelVersion = 1
+
+// ErrModelNotLoaded is returned when a prediction is requested before a model was loaded.
+var ErrModelNotLoaded = errors.New("prediction model is not loaded")
+
+// Model is a logistic regression over stat differentials between the red and the blue corner.
+// It has no intercept, so swapping the corners swaps the probabilities.
+type Model struct {
+	Version   int       `json:"version"`
+	Features  []string  `json:"features"`
+	Weights   []float64 `json:"weights"`
+	Scales    []float64 `json:"scales"`
+	Samples   int       `json:"samples"`
+	TrainedAt int64     `json:"trained_at"`
+}
+
+// Predict returns the probabilities of the red and the blue corner to win the fight.
+func (m *Model) Predict(red, blue *model.Fighter) (float64, float64) {
+	diff := Differentials(red, blue)
+
+	var z float64
+	for i, d := range diff {
+		z += m.Weights[i] * d / m.Scales[i]
+	}
+
+	redWin := sigmoid(z)
+
+	return redWin, 1 - redWin
+}
+
+// Validate checks that the model matches the feature set of the current build.
+func (m *Model) Validate() error {
+	if m.Version != ModelVersion {
+		return fmt.Errorf("unsupported model version %d, expected %d", m.Version, ModelVersion)
+	}
+
+	if len(m.Features) != len(FeatureNames) || len(m.Weights) != len(FeatureNames) || len(m.Scales) != len(FeatureNames) {
+		return fmt.Errorf("model has %d features, expected %d", len(m.Weights), len(FeatureNames))
+	}
+
+	for i, name := range FeatureNames {
+		if m.Features[i] != name {
+			return fmt.Errorf("model feature %d is '%s', expected '%s'", i, m.Features[i], name)
+		}
+
+		if m.Scales[i] <= 0 {
+			return fmt.Errorf("model feature '%s' has non-positive scale", name)
+		}
+	}
+
+	return nil
+}
+
+// Save writes the model to the given path as indented JSON.
+func (m *Model) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// Load reads and validates a model previously written by Save.
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Model
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,134 @@
+package prediction
+
+import (
+	"path/filepath"
+	"testing"
+
+	"fightbettr.com/fighters/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFighter(wins, loses, strAccuracy int) *model.Fighter {
+	return &model.Fighter{
+		Wins:   wins,
+		Loses:  loses,
+		Height: 180,
+		Reach:  185,
+		Age:    30,
+		Stats: model.FighterStats{
+			StrAccuracy:  strAccuracy,
+			SigStrLanded: float32(strAccuracy) / 10,
+		},
+	}
+}
+
+func TestDifferentials(t *testing.T) {
+	red := testFighter(10, 2, 55)
+	blue := testFighter(5, 5, 40)
+
+	redBlue := Differentials(red, blue)
+	blueRed := Differentials(blue, red)
+
+	assert.Len(t, redBlue, len(FeatureNames))
+	for i := range redBlue {
+		assert.Equal(t, redBlue[i], -blueRed[i])
+	}
+
+	assert.Equal(t, float64(15), redBlue[0])
+}
+
+func TestTrainAndPredict(t *testing.T) {
+	strong := testFighter(20, 1, 60)
+	weak := testFighter(3, 10, 30)
+
+	samples := []Sample{
+		{Red: strong, Blue: weak, RedWon: true},
+		{Red: weak, Blue: strong, RedWon: false},
+		{Red: strong, Blue: weak, RedWon: true},
+	}
+
+	m, err := Train(samples, DefaultTrainOptions())
+	assert.NoError(t, err)
+	assert.NoError(t, m.Validate())
+	assert.Equal(t, len(samples), m.Samples)
+
+	redWin, blueWin := m.Predict(strong, weak)
+	assert.Greater(t, redWin, 0.5)
+	assert.InDelta(t, 1, redWin+blueWin, 1e-9)
+
+	swappedRed, swappedBlue := m.Predict(weak, strong)
+	assert.InDelta(t, redWin, swappedBlue, 1e-9)
+	assert.InDelta(t, blueWin, swappedRed, 1e-9)
+
+	_, err = Train(nil, DefaultTrainOptions())
+	assert.Equal(t, ErrNoSamples, err)
+}
+
+func TestSaveLoad(t *testing.T) {
+	m, err := Train([]Sample{{Red: testFighter(10, 0, 50), Blue: testFighter(0, 10, 20), RedWon: true}}, DefaultTrainOptions())
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	assert.NoError(t, m.Save(path))
+
+	loaded, err := Load(path)
+	assert.NoError(t, err)
+	assert.Equal(t, m, loaded)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(m *Model)
+		wantErr bool
+	}{
+		{
+			name:    "Valid",
+			modify:  func(m *Model) {},
+			wantErr: false,
+		},
+		{
+			name:    "Wrong version",
+			modify:  func(m *Model) { m.Version = ModelVersion + 1 },
+			wantErr: true,
+		},
+		{
+			name:    "Missing weights",
+			modify:  func(m *Model) { m.Weights = m.Weights[1:] },
+			wantErr: true,
+		},
+		{
+			name:    "Unknown feature",
+			modify:  func(m *Model) { m.Features[0] = "unknown" },
+			wantErr: true,
+		},
+		{
+			name:    "Zero scale",
+			modify:  func(m *Model) { m.Scales[0] = 0 },
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			n := len(FeatureNames)
+			m := &Model{
+				Version:  ModelVersion,
+				Features: append([]string(nil), FeatureNames...),
+				Weights:  make([]float64, n),
+				Scales:   make([]float64, n),
+			}
+			for i := range m.Scales {
+				m.Scales[i] = 1
+			}
+
+			tc.modify(m)
+
+			err := m.Validate()
+			assert.Equal(t, tc.wantErr, err != nil)
+		})
+	}
+}
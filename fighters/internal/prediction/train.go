@@ -0,0 +1,127 @@
+package prediction
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"fightbettr.com/fighters/pkg/model"
+)
+
+// ErrNoSamples is returned when there are no finished fights to train on.
+var ErrNoSamples = errors.New("no samples to train the prediction model")
+
+// Sample is a single finished fight used for training.
+type Sample struct {
+	Red    *model.Fighter
+	Blue   *model.Fighter
+	RedWon bool
+}
+
+// TrainOptions defines the gradient descent parameters.
+type TrainOptions struct {
+	Iterations   int
+	LearningRate float64
+	L2           float64
+}
+
+// DefaultTrainOptions returns options that converge on a few thousand fights.
+func DefaultTrainOptions() TrainOptions {
+	return TrainOptions{
+		Iterations:   2000,
+		LearningRate: 0.1,
+		L2:           0.01,
+	}
+}
+
+// Train fits a model on the given samples with batch gradient descent.
+// Every fight is also added with swapped corners, so the model does not learn a corner bias.
+// Features are scaled by their standard deviation and the scales are stored with the weights.
+func Train(samples []Sample, opts TrainOptions) (*Model, error) {
+	if len(samples) == 0 {
+		return nil, ErrNoSamples
+	}
+
+	n := len(FeatureNames)
+	xs := make([][]float64, 0, len(samples)*2)
+	ys := make([]float64, 0, len(samples)*2)
+
+	for _, s := range samples {
+		diff := Differentials(s.Red, s.Blue)
+		mirrored := make([]float64, n)
+		for i, d := range diff {
+			mirrored[i] = -d
+		}
+
+		y := 0.0
+		if s.RedWon {
+			y = 1
+		}
+
+		xs = append(xs, diff, mirrored)
+		ys = append(ys, y, 1-y)
+	}
+
+	scales := featureScales(xs, n)
+	for _, x := range xs {
+		for i := range x {
+			x[i] /= scales[i]
+		}
+	}
+
+	weights := make([]float64, n)
+	grad := make([]float64, n)
+	m := float64(len(xs))
+
+	for it := 0; it < opts.Iterations; it++ {
+		for i := range grad {
+			grad[i] = opts.L2 * weights[i]
+		}
+
+		for j, x := range xs {
+			var z float64
+			for i, v := range x {
+				z += weights[i] * v
+			}
+
+			e := sigmoid(z) - ys[j]
+			for i, v := range x {
+				grad[i] += e * v / m
+			}
+		}
+
+		for i := range weights {
+			weights[i] -= opts.LearningRate * grad[i]
+		}
+	}
+
+	return &Model{
+		Version:   ModelVersion,
+		Features:  append([]string(nil), FeatureNames...),
+		Weights:   weights,
+		Scales:    scales,
+		Samples:   len(samples),
+		TrainedAt: time.Now().Unix(),
+	}, nil
+}
+
+// featureScales returns the standard deviation of every feature around zero.
+// Mirrored samples make the mean zero, and a zero deviation falls back to 1.
+func featureScales(xs [][]float64, n int) []float64 {
+	scales := make([]float64, n)
+
+	for _, x := range xs {
+		for i, v := range x {
+			scales[i] += v * v
+		}
+	}
+
+	for i := range scales {
+		scales[i] = math.Sqrt(scales[i] / float64(len(xs)))
+		if scales[i] == 0 {
+			scales[i] = 1
+		}
+	}
+
+	return scales
+}
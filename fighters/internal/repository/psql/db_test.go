@@ -33,14 +33,15 @@ func TestNew(t *testing.T) {
 
 func TestPerformFightersQuery(t *testing.T) {
 	tests := []struct {
-		name     string
-		req      *model.FightersRequest
-		expected []string
+		name         string
+		req          *model.FightersRequest
+		expected     []string
+		expectedArgs []any
 	}{
 		{
 			name:     "nil request",
 			req:      nil,
-			expected: []string{},
+			expected: []string{`f.deleted_at IS NULL`},
 		},
 		{
 			name: "status only",
@@ -48,8 +49,34 @@ func TestPerformFightersQuery(t *testing.T) {
 				Status: "active",
 			},
 			expected: []string{
-				`f.status = 'active'`,
+				`f.deleted_at IS NULL`,
+				`f.status = $1`,
 			},
+			expectedArgs: []any{"active"},
+		},
+		{
+			name: "state only",
+			req: &model.FightersRequest{
+				State: "retired",
+			},
+			expected: []string{
+				`f.deleted_at IS NULL`,
+				`f.state = $1`,
+			},
+			expectedArgs: []any{"retired"},
+		},
+		{
+			name: "state and status",
+			req: &model.FightersRequest{
+				State:  "active",
+				Status: "Active",
+			},
+			expected: []string{
+				`f.deleted_at IS NULL`,
+				`f.state = $1`,
+				`f.status = $2`,
+			},
+			expectedArgs: []any{"active", "Active"},
 		},
 		{
 			name: "fighters IDs only",
@@ -67,9 +94,32 @@ func TestPerformFightersQuery(t *testing.T) {
 				FightersIds: []int32{4, 5},
 			},
 			expected: []string{
-				`f.status = 'inactive'`,
+				`f.status = $1`,
 				`f.fighter_id IN (4, 5)`,
 			},
+			expectedArgs: []any{"inactive"},
+		},
+		{
+			name: "fighter urls",
+			req: &model.FightersRequest{
+				FighterUrls: []string{"https://www.ufc.com/athlete/o'malley", "https://www.ufc.com/athlete/jon-jones?100%"},
+			},
+			expected: []string{
+				`f.fighter_url = ANY($1)`,
+			},
+			expectedArgs: []any{[]string{"https://www.ufc.com/athlete/o'malley", "https://www.ufc.com/athlete/jon-jones?100%"}},
+		},
+		{
+			name: "state and fighter urls",
+			req: &model.FightersRequest{
+				State:       "active' OR '1'='1",
+				FighterUrls: []string{"https://www.ufc.com/athlete/jon-jones"},
+			},
+			expected: []string{
+				`f.state = $1`,
+				`f.fighter_url = ANY($2)`,
+			},
+			expectedArgs: []any{"active' OR '1'='1", []string{"https://www.ufc.com/athlete/jon-jones"}},
 		},
 		{
 			name: "empty status and empty fighters IDs",
@@ -77,7 +127,7 @@ func TestPerformFightersQuery(t *testing.T) {
 				Status:      "",
 				FightersIds: nil,
 			},
-			expected: []string{},
+			expected: []string{`f.deleted_at IS NULL`},
 		},
 	}
 
@@ -85,8 +135,9 @@ func TestPerformFightersQuery(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			result := repo.performFightersQuery(tc.req)
-			assert.ElementsMatch(t, tc.expected, result)
+			conds, args := repo.performFightersQuery(tc.req)
+			assert.Equal(t, tc.expected, conds)
+			assert.Equal(t, tc.expectedArgs, args)
 		})
 	}
 }
@@ -321,6 +372,50 @@ func TestUpdateFighterStats(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestUpsertFighters(t *testing.T) {
+	initTestConfig()
+	defer viper.Reset()
+
+	ctx := context.Background()
+	config := cfg.ViperTestPostgres()
+
+	repo, err := New(ctx, config)
+	assert.NoError(t, err)
+	defer repo.GracefulShutdown()
+
+	fighter := model.Fighter{
+		Name:           "Upsert Fighter",
+		Division:       1,
+		Status:         "Active",
+		DebutTimestamp: 1715817600,
+		FighterUrl:     "https://www.ufc.com/athlete/upsert-fighter",
+		Stats:          model.FighterStats{StrAccuracy: 40},
+	}
+
+	affected, err := repo.UpsertFighters(ctx, nil, []model.Fighter{fighter})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	affected, err = repo.UpsertFighters(ctx, nil, []model.Fighter{fighter})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), affected)
+
+	fighter.Wins = 1
+	fighter.Stats.StrAccuracy = 45
+
+	tx, err := repo.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+	assert.NoError(t, err)
+
+	affected, err = repo.UpsertFighters(ctx, tx, []model.Fighter{fighter})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), affected)
+
+	err = tx.Commit(ctx)
+	assert.NoError(t, err)
+}
+
 func initTestConfig() {
 	viper.SetConfigName("config")
 	viper.AddConfigPath("../../../configs")
@@ -2,6 +2,7 @@ package psql
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -15,14 +16,14 @@ import (
 func (r *Repository) SearchFightersCount(ctx context.Context, req *model.FightersRequest) (int32, error) {
 	q := `SELECT count(*) FROM public.fb_fighters AS f`
 
-	args := r.performFightersQuery(req)
-	if len(args) > 0 {
+	conds, args := r.performFightersQuery(req)
+	if len(conds) > 0 {
 		q += ` WHERE `
-		q += strings.Join(args, ` AND `)
+		q += strings.Join(conds, ` AND `)
 	}
 
 	var count int32
-	if err := r.GetPool().QueryRow(ctx, q).Scan(&count); err != nil {
+	if err := r.GetPool().QueryRow(ctx, q, args...).Scan(&count); err != nil {
 		return 0, r.DebugLogSqlErr(q, err)
 	}
 
@@ -42,17 +43,17 @@ func (r *Repository) SearchFighters(ctx context.Context, req *model.FightersRequ
 		fs.total_sig_str_landed, fs.total_sig_str_attempted, fs.str_accuracy, fs.total_tkd_landed, fs.total_tkd_attempted,
 		fs.tkd_accuracy, fs.sig_str_landed, fs.sig_str_absorbed, fs.sig_str_defense, fs.takedown_defense,
 		fs.takedown_avg, fs.submission_avg, fs.knockdown_avg, fs.avg_fight_time, fs.win_by_ko,
-		fs.win_by_sub, fs.win_by_dec
+		fs.win_by_sub, fs.win_by_dec, f.state, COALESCE(f.deleted_at, 0)
 		FROM public.fb_fighters AS f
 		LEFT JOIN public.fb_fighter_stats AS fs ON f.fighter_id = fs.fighter_id`
 
-	args := r.performFightersQuery(req)
-	if len(args) > 0 {
+	conds, args := r.performFightersQuery(req)
+	if len(conds) > 0 {
 		q += ` WHERE `
-		q += strings.Join(args, ` AND `)
+		q += strings.Join(conds, ` AND `)
 	}
 
-	rows, err := r.GetPool().Query(ctx, q)
+	rows, err := r.GetPool().Query(ctx, q, args...)
 	if err != nil {
 		return nil, r.DebugLogSqlErr(q, err)
 	}
@@ -72,6 +73,7 @@ func (r *Repository) SearchFighters(ctx context.Context, req *model.FightersRequ
 			&fs.TotalSigStrLanded, &fs.TotalSigStrAttempted, &fs.StrAccuracy, &fs.TotalTkdLanded, &fs.TotalTkdAttempted,
 			&fs.TkdAccuracy, &fs.SigStrLanded, &fs.SigStrAbs, &fs.SigStrDefense, &fs.TakedownDefense,
 			&fs.TakedownAvg, &fs.SubmissionAvg, &fs.KnockdownAvg, &fs.AvgFightTime, &fs.WinByKO, &fs.WinBySub, &fs.WinByDec,
+			&f.State, &f.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -85,16 +87,30 @@ func (r *Repository) SearchFighters(ctx context.Context, req *model.FightersRequ
 }
 
 // performFightersQuery constructs the conditions for filtering fighter search based on the provided FightersRequest.
-// It returns a slice of string conditions that can be used in the WHERE clause of the SQL query.
-// If the provided FightersRequest is nil, an empty slice is returned.
-func (r *Repository) performFightersQuery(req *model.FightersRequest) []string {
-	var args []string
+// It returns a slice of string conditions that can be used in the WHERE clause of the SQL query together with
+// the arguments bound to their placeholders, so the values of the request never become part of the query.
+// Soft-deleted fighters are excluded unless they are requested by their ids or urls, so finished fights
+// still resolve their participants. If the provided FightersRequest is nil, only the soft-delete
+// condition is returned.
+func (r *Repository) performFightersQuery(req *model.FightersRequest) ([]string, []any) {
+	var conds []string
+	var args []any
 	if req == nil {
-		return args
+		return append(conds, `f.deleted_at IS NULL`), args
+	}
+
+	if len(req.FightersIds) == 0 && len(req.FighterUrls) == 0 {
+		conds = append(conds, `f.deleted_at IS NULL`)
+	}
+
+	if req.State != "" {
+		args = append(args, req.State)
+		conds = append(conds, fmt.Sprintf(`f.state = $%d`, len(args)))
 	}
 
 	if req.Status != "" {
-		args = append(args, fmt.Sprintf(`f.status = '%s'`, req.Status))
+		args = append(args, req.Status)
+		conds = append(conds, fmt.Sprintf(`f.status = $%d`, len(args)))
 	}
 
 	if req.FightersIds != nil && len(req.FightersIds) > 0 {
@@ -102,8 +118,74 @@ func (r *Repository) performFightersQuery(req *model.FightersRequest) []string {
 		for i, id := range req.FightersIds {
 			stringedIds[i] = fmt.Sprintf("%d", id)
 		}
-		args = append(args, fmt.Sprintf(`f.fighter_id IN (%s)`, strings.Join(stringedIds, ", ")))
+		conds = append(conds, fmt.Sprintf(`f.fighter_id IN (%s)`, strings.Join(stringedIds, ", ")))
+	}
+
+	if len(req.FighterUrls) > 0 {
+		args = append(args, req.FighterUrls)
+		conds = append(conds, fmt.Sprintf(`f.fighter_url = ANY($%d)`, len(args)))
+	}
+
+	return conds, args
+}
+
+// ErrFightsNotShared is returned when the fb_fights table of the events service is not found in the
+// fighters database, i.e. the services do not share one database.
+var ErrFightsNotShared = errors.New("the fb_fights table of the events service is not in the fighters database")
+
+// SearchFightResults retrieves all finished fights that have a winner from the fb_fights table of the events
+// service, which must share the database with the fighters service; ErrFightsNotShared is returned otherwise.
+// Fights that were declared no contest or whose result does not match one of the corners are skipped.
+// Every fight comes with the latest snapshots of both fighters taken before the fight date, so the stats
+// do not include the outcome of the fight; fights without such snapshots of both fighters are skipped.
+// The results are used as training samples for the prediction model.
+func (r *Repository) SearchFightResults(ctx context.Context) ([]model.FightResult, error) {
+	var shared bool
+	qShared := `SELECT to_regclass('public.fb_fights') IS NOT NULL`
+
+	if err := r.GetPool().QueryRow(ctx, qShared).Scan(&shared); err != nil {
+		return nil, r.DebugLogSqlErr(qShared, err)
+	}
+
+	if !shared {
+		return nil, ErrFightsNotShared
+	}
+
+	q := `SELECT ft.fight_id, ft.fighter_red_id, ft.fighter_blue_id, ft.result, ft.fight_date, red.fighter, blue.fighter
+		FROM public.fb_fights AS ft
+		JOIN LATERAL (
+			SELECT p.fighter FROM public.fb_fighter_snapshots AS p
+			WHERE p.fighter_id = ft.fighter_red_id AND p.taken_at < ft.fight_date
+			ORDER BY p.taken_at DESC, p.snapshot_id DESC LIMIT 1
+		) AS red ON true
+		JOIN LATERAL (
+			SELECT p.fighter FROM public.fb_fighter_snapshots AS p
+			WHERE p.fighter_id = ft.fighter_blue_id AND p.taken_at < ft.fight_date
+			ORDER BY p.taken_at DESC, p.snapshot_id DESC LIMIT 1
+		) AS blue ON true
+		WHERE ft.is_done = true AND ft.not_contest = false AND ft.result IN (ft.fighter_red_id, ft.fighter_blue_id)`
+
+	rows, err := r.GetPool().Query(ctx, q)
+	if err != nil {
+		return nil, r.DebugLogSqlErr(q, err)
+	}
+	defer rows.Close()
+
+	var results []model.FightResult
+
+	for rows.Next() {
+		var fr model.FightResult
+		var red, blue model.Fighter
+
+		if err := rows.Scan(&fr.FightId, &fr.FighterRedId, &fr.FighterBlueId, &fr.WinnerId, &fr.FightDate, &red, &blue); err != nil {
+			return nil, r.DebugLogSqlErr(q, err)
+		}
+
+		red.FighterId, blue.FighterId = fr.FighterRedId, fr.FighterBlueId
+		fr.Red, fr.Blue = &red, &blue
+
+		results = append(results, fr)
 	}
 
-	return args
+	return results, rows.Err()
 }
@@ -0,0 +1,25 @@
+package psql
+
+import (
+	"context"
+
+	"fightbettr.com/fighters/pkg/importer"
+	"fightbettr.com/fighters/pkg/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// UpsertFighters writes the provided fighters and their statistics to the database in bulk with the
+// queries of importer.Store, shared with the clients that import fighters into the database directly.
+// If no transaction (tx) is provided, it begins and commits its own. The method returns the number
+// of created or changed fighter rows.
+func (r *Repository) UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error) {
+	return importer.NewStore(r.FbRepo).UpsertFighters(ctx, tx, fighters)
+}
+
+// ReleaseMissingFighters marks active fighters whose fighter_url is not in the provided list as released
+// with the query of importer.Store. It returns the number of released fighters, or importer.ErrEmptyRoster
+// if the list is empty.
+func (r *Repository) ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error) {
+	return importer.NewStore(r.FbRepo).ReleaseMissingFighters(ctx, tx, urls)
+}
@@ -0,0 +1,98 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"fightbettr.com/fighters/pkg/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SetFighterState changes the lifecycle state of a fighter in the 'public.fb_fighters' table.
+// It takes a context, a database transaction (tx), the fighter ID and the new state.
+// If a transaction (tx) is provided, the update is performed within that transaction;
+// otherwise, it is executed as a standalone query. The method returns pgx.ErrNoRows
+// if the fighter does not exist or has been soft-deleted.
+func (r *Repository) SetFighterState(ctx context.Context, tx pgx.Tx, fighterId int32, state model.FighterState) error {
+	q := `UPDATE public.fb_fighters
+		SET state = $2, state_updated_at = $3
+		WHERE fighter_id = $1 AND deleted_at IS NULL`
+
+	tag, err := r.exec(ctx, tx, q, fighterId, string(state), time.Now().Unix())
+	if err != nil {
+		return r.DebugLogSqlErr(q, err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+
+	return nil
+}
+
+// SoftDeleteFighters marks all fighters that are not deleted yet as deleted.
+// Soft-deleted fighters are hidden from searches, but still resolve by their ids.
+// The method returns the number of deleted fighters.
+func (r *Repository) SoftDeleteFighters(ctx context.Context, tx pgx.Tx) (int64, error) {
+	q := `UPDATE public.fb_fighters SET deleted_at = $1 WHERE deleted_at IS NULL`
+
+	tag, err := r.exec(ctx, tx, q, time.Now().Unix())
+	if err != nil {
+		return 0, r.DebugLogSqlErr(q, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// DeleteUnreferencedFighters permanently deletes fighters and their statistics
+// that are not referenced by any row of the 'public.fb_fights' table.
+// Referenced fighters are left in place; a trigger on 'public.fb_fighters' rejects
+// their deletion as well. The method returns the number of deleted fighters.
+func (r *Repository) DeleteUnreferencedFighters(ctx context.Context, tx pgx.Tx) (int64, error) {
+	var hasFights bool
+	qFights := `SELECT to_regclass('public.fb_fights') IS NOT NULL`
+
+	if tx != nil {
+		if err := tx.QueryRow(ctx, qFights).Scan(&hasFights); err != nil {
+			return 0, r.DebugLogSqlErr(qFights, err)
+		}
+	} else {
+		if err := r.GetPool().QueryRow(ctx, qFights).Scan(&hasFights); err != nil {
+			return 0, r.DebugLogSqlErr(qFights, err)
+		}
+	}
+
+	cond := `true`
+	if hasFights {
+		cond = `NOT EXISTS (SELECT 1 FROM public.fb_fights AS ft
+			WHERE ft.fighter_red_id = f.fighter_id OR ft.fighter_blue_id = f.fighter_id)`
+	}
+
+	qStats := `DELETE FROM public.fb_fighter_stats AS fs
+		USING public.fb_fighters AS f
+		WHERE fs.fighter_id = f.fighter_id AND ` + cond
+
+	if _, err := r.exec(ctx, tx, qStats); err != nil {
+		return 0, r.DebugLogSqlErr(qStats, err)
+	}
+
+	qData := `DELETE FROM public.fb_fighters AS f WHERE ` + cond
+
+	tag, err := r.exec(ctx, tx, qData)
+	if err != nil {
+		return 0, r.DebugLogSqlErr(qData, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// exec executes the query within the transaction if it is provided, or on the pool otherwise.
+func (r *Repository) exec(ctx context.Context, tx pgx.Tx, q string, args ...any) (pgconn.CommandTag, error) {
+	if tx != nil {
+		return tx.Exec(ctx, q, args...)
+	}
+
+	return r.GetPool().Exec(ctx, q, args...)
+}
@@ -0,0 +1,214 @@
+package roster
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"fightbettr.com/fighters/pkg/model"
+)
+
+// Stdin is the source name that makes Read consume the standard input.
+const Stdin = "-"
+
+// shardExtensions lists the file extensions that are read from a directory source.
+var shardExtensions = map[string]struct{}{
+	".json":   {},
+	".ndjson": {},
+	".jsonl":  {},
+}
+
+// httpClient is used to download sources given as an HTTP(S) URL.
+var httpClient = &http.Client{Timeout: 60 * time.Second}
+
+// envelope is the collection format written by the scraper.
+// Version is optional and defaults to SchemaVersion.
+type envelope struct {
+	Version  *int              `json:"Version"`
+	Fighters []json.RawMessage `json:"Fighters"`
+}
+
+// Read reads and validates fighters from the given source.
+// The source is either Stdin, an HTTP(S) URL, a directory of collection shards or a path to a file.
+// Shards of a directory are read in lexical order and concatenated.
+func Read(ctx context.Context, source string) ([]model.Fighter, error) {
+	switch {
+	case source == Stdin:
+		return Decode(os.Stdin)
+	case strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://"):
+		return readURL(ctx, source)
+	}
+
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return readDir(source)
+	}
+
+	return readFile(source)
+}
+
+// Decode reads fighters from r, which contains either the {"Fighters": [...]} envelope
+// or newline delimited JSON with one fighter per line. Every fighter is validated against
+// the fighter schema before it is decoded.
+func Decode(r io.Reader) ([]model.Fighter, error) {
+	d := json.NewDecoder(bufio.NewReader(r))
+
+	var first json.RawMessage
+	if err := d.Decode(&first); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if env, ok := asEnvelope(first); ok {
+		version := SchemaVersion
+		if env.Version != nil {
+			version = *env.Version
+		}
+
+		return decodeFighters(version, env.Fighters)
+	}
+
+	raws := []json.RawMessage{first}
+	for {
+		var raw json.RawMessage
+		if err := d.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("fighter #%d: %w", len(raws)+1, err)
+		}
+
+		raws = append(raws, raw)
+	}
+
+	return decodeFighters(SchemaVersion, raws)
+}
+
+// asEnvelope reports whether the raw value is an envelope with the Fighters key.
+func asEnvelope(raw json.RawMessage) (envelope, bool) {
+	var keys map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keys); err != nil {
+		return envelope{}, false
+	}
+
+	if _, ok := keys["Fighters"]; !ok {
+		return envelope{}, false
+	}
+
+	var env envelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return envelope{}, false
+	}
+
+	return env, true
+}
+
+// decodeFighters validates and decodes raw fighter records.
+// Errors are reported with the 1-based position of the record.
+func decodeFighters(version int, raws []json.RawMessage) ([]model.Fighter, error) {
+	fighters := make([]model.Fighter, 0, len(raws))
+
+	for i, raw := range raws {
+		if err := Validate(version, raw); err != nil {
+			return nil, fmt.Errorf("fighter #%d: %w", i+1, err)
+		}
+
+		var f model.Fighter
+		if err := json.Unmarshal(raw, &f); err != nil {
+			return nil, fmt.Errorf("fighter #%d: %w", i+1, err)
+		}
+
+		fighters = append(fighters, f)
+	}
+
+	return fighters, nil
+}
+
+// readFile reads fighters from a single file.
+func readFile(path string) ([]model.Fighter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	fighters, err := Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return fighters, nil
+}
+
+// readDir reads fighters from every shard in the directory.
+func readDir(dir string) ([]model.Fighter, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		if _, ok := shardExtensions[strings.ToLower(filepath.Ext(e.Name()))]; ok {
+			names = append(names, e.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	var fighters []model.Fighter
+	for _, name := range names {
+		shard, err := readFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+
+		fighters = append(fighters, shard...)
+	}
+
+	return fighters, nil
+}
+
+// readURL downloads fighters from an HTTP(S) URL.
+func readURL(ctx context.Context, url string) ([]model.Fighter, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status %s", url, resp.Status)
+	}
+
+	fighters, err := Decode(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+
+	return fighters, nil
+}
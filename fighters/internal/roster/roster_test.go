@@ -0,0 +1,158 @@
+package roster
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fightbettr.com/fighters/pkg/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	fighterOne = `{"name":"John Doe","division":3,"status":"Active","fighterUrl":"https://www.ufc.com/athlete/john-doe","wins":10,"stats":{"strAccuracy":55,"winByKO":4}}`
+	fighterTwo = `{"name":"Jane Roe","division":8,"status":"Not Fighting","fighterUrl":"https://www.ufc.com/athlete/jane-roe","loses":2}`
+)
+
+var expectedFighters = []model.Fighter{
+	{
+		Name:       "John Doe",
+		Division:   model.Lightweight,
+		Status:     "Active",
+		FighterUrl: "https://www.ufc.com/athlete/john-doe",
+		Wins:       10,
+		Stats:      model.FighterStats{StrAccuracy: 55, WinByKO: 4},
+	},
+	{
+		Name:       "Jane Roe",
+		Division:   model.WomensStrawweight,
+		Status:     "Not Fighting",
+		FighterUrl: "https://www.ufc.com/athlete/jane-roe",
+		Loses:      2,
+	},
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []model.Fighter
+		expectedErr string
+	}{
+		{
+			name:     "Envelope",
+			input:    `{"Fighters":[` + fighterOne + `,` + fighterTwo + `]}`,
+			expected: expectedFighters,
+		},
+		{
+			name:     "Envelope with version",
+			input:    `{"Version":1,"Fighters":[` + fighterOne + `,` + fighterTwo + `]}`,
+			expected: expectedFighters,
+		},
+		{
+			name:     "Merged record with provenance",
+			input:    `{"Version":2,"Fighters":[` + strings.Replace(fighterOne, `"wins":10,`, `"wins":10,"provenance":{"wins":"ufc"},"sourceUrls":{"ufc":"https://www.ufc.com/athlete/john-doe"},`, 1) + `]}`,
+			expected: expectedFighters[:1],
+		},
+		{
+			name:        "Provenance in version 1",
+			input:       `{"Version":1,"Fighters":[` + strings.Replace(fighterOne, `"wins":10,`, `"wins":10,"provenance":{"wins":"ufc"},`, 1) + `]}`,
+			expectedErr: "fighter #1",
+		},
+		{
+			name:     "NDJSON",
+			input:    fighterOne + "\n" + fighterTwo + "\n",
+			expected: expectedFighters,
+		},
+		{
+			name:     "Empty input",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:        "Unsupported version",
+			input:       `{"Version":99,"Fighters":[` + fighterOne + `]}`,
+			expectedErr: "unsupported schema version 99",
+		},
+		{
+			name:        "Missing required field",
+			input:       fighterOne + "\n" + `{"name":"No Url","division":1,"status":"Active"}`,
+			expectedErr: "fighter #2",
+		},
+		{
+			name:        "Unknown field",
+			input:       `{"name":"Typo","division":1,"status":"Active","fighterUrl":"x","winz":1}`,
+			expectedErr: "fighter #1",
+		},
+		{
+			name:        "Division out of range",
+			input:       `{"name":"Big","division":12,"status":"Active","fighterUrl":"x"}`,
+			expectedErr: "fighter #1",
+		},
+		{
+			name:        "Malformed NDJSON",
+			input:       fighterOne + "\n{",
+			expectedErr: "fighter #2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fighters, err := Decode(strings.NewReader(tc.input))
+
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, fighters)
+		})
+	}
+}
+
+func TestReadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fighters.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"Fighters":[`+fighterOne+`,`+fighterTwo+`]}`), 0644))
+
+	fighters, err := Read(context.Background(), path)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFighters, fighters)
+
+	_, err = Read(context.Background(), filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestReadDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "01.json"), []byte(`{"Fighters":[`+fighterOne+`]}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "02.ndjson"), []byte(fighterTwo+"\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a shard"), 0644))
+
+	fighters, err := Read(context.Background(), dir)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFighters, fighters)
+}
+
+func TestReadURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/fighters.ndjson" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(fighterOne + "\n" + fighterTwo + "\n"))
+	}))
+	defer srv.Close()
+
+	fighters, err := Read(context.Background(), srv.URL+"/fighters.ndjson")
+	assert.NoError(t, err)
+	assert.Equal(t, expectedFighters, fighters)
+
+	_, err = Read(context.Background(), srv.URL+"/missing.json")
+	assert.ErrorContains(t, err, "unexpected status 404")
+}
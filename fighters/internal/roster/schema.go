@@ -0,0 +1,74 @@
+package roster
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// SchemaVersion is the version of the fighter schema that is used when the input does not declare one.
+const SchemaVersion = 2
+
+//go:embed schema/*.json
+var schemaFS embed.FS
+
+var (
+	schemasMu sync.Mutex
+	schemas   = map[int]*jsonschema.Schema{}
+)
+
+// Validate checks a single raw fighter record against the fighter schema of the given version.
+func Validate(version int, raw []byte) error {
+	s, err := schemaFor(version)
+	if err != nil {
+		return err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return err
+	}
+
+	return s.Validate(v)
+}
+
+// schemaFor compiles the embedded schema of the given version once and caches it.
+func schemaFor(version int) (*jsonschema.Schema, error) {
+	schemasMu.Lock()
+	defer schemasMu.Unlock()
+
+	if s, ok := schemas[version]; ok {
+		return s, nil
+	}
+
+	name := fmt.Sprintf("fighter.v%d.json", version)
+
+	data, err := schemaFS.ReadFile("schema/" + name)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported schema version %d", version)
+	}
+
+	url := "https://fightbettr.com/schema/" + name
+
+	c := jsonschema.NewCompiler()
+	c.Draft = jsonschema.Draft2020
+	if err := c.AddResource(url, bytes.NewReader(data)); err != nil {
+		return nil, err
+	}
+
+	s, err := c.Compile(url)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas[version] = s
+
+	return s, nil
+}
@@ -6,12 +6,19 @@ import (
 	"strings"
 
 	grpchandler "fightbettr.com/fighters/internal/handler/grpc"
+	"fightbettr.com/pkg/correlation"
+	"fightbettr.com/pkg/grpcauth"
+	"fightbettr.com/pkg/health"
 	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/metrics"
+	"fightbettr.com/pkg/tlsx"
 	"fightbettr.com/fighters/pkg/version"
 	"fightbettr.com/gen"
 	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -19,14 +26,36 @@ type ApiService struct {
 	ServiceName string
 	Handler     *grpchandler.Handler
 	Server      *grpc.Server
+	Health      *grpchealth.Server
 }
 
-func New() ApiService {
-	srv := grpc.NewServer(grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()))
+// policy returns the permissions of the FightersService methods.
+func policy() grpcauth.Policy {
+	return grpcauth.MustPolicy(&gen.FightersService_ServiceDesc, map[string]grpcauth.Permission{
+		gen.FightersService_SearchFightersCount_FullMethodName:    grpcauth.Public,
+		gen.FightersService_SearchFighters_FullMethodName:         grpcauth.Public,
+		gen.FightersService_PredictFights_FullMethodName:          grpcauth.Public,
+		gen.FightersService_ImportFighters_FullMethodName:         grpcauth.ServicePermission(tlsx.ViperConfig()),
+		gen.FightersService_ReleaseMissingFighters_FullMethodName: grpcauth.ServicePermission(tlsx.ViperConfig()),
+	})
+}
+
+func New(opts ...grpc.ServerOption) ApiService {
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(
+		otelgrpc.UnaryServerInterceptor(),
+		correlation.UnaryServerInterceptor(),
+		metrics.UnaryServerInterceptor(),
+		grpcauth.UnaryServerInterceptor(policy(), grpcauth.JWTVerifier()),
+	)}, opts...)
+	srv := grpc.NewServer(opts...)
+
+	hs := health.NewGRPCServer(gen.FightersService_ServiceDesc.ServiceName)
+	healthpb.RegisterHealthServer(srv, hs)
 
 	return ApiService{
 		ServiceName: version.Name,
 		Server:      srv,
+		Health:      hs,
 	}
 }
 
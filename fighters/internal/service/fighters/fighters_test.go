@@ -7,6 +7,8 @@ import (
 
 	grpchandler "fightbettr.com/fighters/internal/handler/grpc"
 	"fightbettr.com/fighters/pkg/version"
+	"fightbettr.com/gen"
+	"fightbettr.com/pkg/grpcauth"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -57,3 +59,31 @@ func TestRun(t *testing.T) {
 
 	apiService.Server.Stop()
 }
+
+func TestPolicy(t *testing.T) {
+	tests := []struct {
+		name       string
+		enabled    bool
+		clientAuth bool
+		want       grpcauth.Permission
+	}{
+		{name: "no tls", want: grpcauth.Admin},
+		{name: "tls without client certificates", enabled: true, want: grpcauth.Admin},
+		{name: "client certificates", enabled: true, clientAuth: true, want: grpcauth.Service},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer viper.Reset()
+
+			viper.Set("grpc.tls.enabled", tt.enabled)
+			viper.Set("grpc.tls.client_auth", tt.clientAuth)
+
+			p := policy()
+
+			assert.Equal(t, tt.want, p[gen.FightersService_ImportFighters_FullMethodName])
+			assert.Equal(t, tt.want, p[gen.FightersService_ReleaseMissingFighters_FullMethodName])
+			assert.Equal(t, grpcauth.Public, p[gen.FightersService_SearchFighters_FullMethodName])
+		})
+	}
+}
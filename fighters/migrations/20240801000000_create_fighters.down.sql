@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS public.fb_fighter_stats;
+
+DROP TABLE IF EXISTS public.fb_fighters;
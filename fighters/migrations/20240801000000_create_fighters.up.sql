@@ -0,0 +1,50 @@
+CREATE TABLE IF NOT EXISTS public.fb_fighters (
+    fighter_id serial NOT NULL,
+    name character varying(255) NOT NULL,
+    nickname character varying(255) DEFAULT ''::character varying,
+    division integer NOT NULL,
+    status character varying(50) NOT NULL,
+    hometown character varying(100) DEFAULT ''::character varying,
+    trains_at character varying(100) DEFAULT ''::character varying,
+    fighting_style character varying(100) DEFAULT ''::character varying,
+    age integer NOT NULL,
+    height double precision,
+    weight double precision,
+    octagon_debut character varying(50) DEFAULT ''::character varying,
+    debut_timestamp bigint NOT NULL,
+    reach integer,
+    leg_reach integer,
+    fighter_url character varying(255) NOT NULL,
+    image_url text,
+    wins integer DEFAULT 0 NOT NULL,
+    loses integer DEFAULT 0 NOT NULL,
+    draw integer DEFAULT 0 NOT NULL,
+    CONSTRAINT fb_fighters_pk PRIMARY KEY (fighter_id),
+    CONSTRAINT fb_fighters_name_debut_timestamp_key UNIQUE (name, debut_timestamp, fighter_url)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS fb_fighters_fighter_url_uindex ON public.fb_fighters USING btree (fighter_url);
+
+CREATE TABLE IF NOT EXISTS public.fb_fighter_stats (
+    stat_id serial NOT NULL,
+    fighter_id integer,
+    total_sig_str_landed integer,
+    total_sig_str_attempted integer,
+    str_accuracy integer,
+    total_tkd_landed integer,
+    total_tkd_attempted integer,
+    tkd_accuracy integer,
+    sig_str_landed double precision,
+    sig_str_absorbed double precision,
+    sig_str_defense integer,
+    takedown_defense integer,
+    takedown_avg double precision,
+    submission_avg double precision,
+    knockdown_avg double precision,
+    avg_fight_time character varying(50),
+    win_by_ko integer,
+    win_by_sub integer,
+    win_by_dec integer,
+    CONSTRAINT fb_fighter_stats_pkey PRIMARY KEY (stat_id),
+    CONSTRAINT fb_fighter_stats_fighter_id_fkey FOREIGN KEY (fighter_id) REFERENCES public.fb_fighters(fighter_id)
+);
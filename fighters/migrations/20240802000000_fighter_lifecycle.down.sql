@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS fb_fighters_prevent_referenced_delete ON public.fb_fighters;
+
+DROP FUNCTION IF EXISTS public.fb_fighters_prevent_referenced_delete();
+
+DROP INDEX IF EXISTS public.fb_fighters_state_index;
+
+ALTER TABLE public.fb_fighters
+    DROP CONSTRAINT IF EXISTS fb_fighters_state_check,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS state_updated_at,
+    DROP COLUMN IF EXISTS state;
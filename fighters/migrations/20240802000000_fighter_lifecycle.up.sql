@@ -0,0 +1,35 @@
+ALTER TABLE public.fb_fighters
+    ADD COLUMN IF NOT EXISTS state character varying(20) DEFAULT 'active' NOT NULL,
+    ADD COLUMN IF NOT EXISTS state_updated_at bigint,
+    ADD COLUMN IF NOT EXISTS deleted_at bigint;
+
+ALTER TABLE public.fb_fighters
+    ADD CONSTRAINT fb_fighters_state_check CHECK (state IN ('active', 'retired', 'released', 'deceased'));
+
+CREATE INDEX IF NOT EXISTS fb_fighters_state_index ON public.fb_fighters USING btree (state) WHERE deleted_at IS NULL;
+
+-- fb_fights belongs to the events service, so the reference is checked by a trigger instead of a foreign key.
+CREATE OR REPLACE FUNCTION public.fb_fighters_prevent_referenced_delete() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    referenced boolean;
+BEGIN
+    IF to_regclass('public.fb_fights') IS NOT NULL THEN
+        EXECUTE 'SELECT EXISTS (SELECT 1 FROM public.fb_fights WHERE fighter_red_id = $1 OR fighter_blue_id = $1)'
+            INTO referenced
+            USING OLD.fighter_id;
+
+        IF referenced THEN
+            RAISE EXCEPTION 'fighter % is referenced by fb_fights and can not be deleted', OLD.fighter_id
+                USING ERRCODE = 'foreign_key_violation';
+        END IF;
+    END IF;
+
+    RETURN OLD;
+END;
+$$;
+
+CREATE TRIGGER fb_fighters_prevent_referenced_delete
+    BEFORE DELETE ON public.fb_fighters
+    FOR EACH ROW EXECUTE FUNCTION public.fb_fighters_prevent_referenced_delete();
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS public.fb_fighter_snapshots;
@@ -0,0 +1,12 @@
+-- Snapshots of the imported fighters with their statistics, so the prediction model is trained on the stats
+-- the fighters had before a fight instead of their current stats, which already include its outcome.
+CREATE TABLE IF NOT EXISTS public.fb_fighter_snapshots (
+    snapshot_id serial NOT NULL,
+    fighter_id integer NOT NULL,
+    taken_at bigint NOT NULL,
+    fighter jsonb NOT NULL,
+    CONSTRAINT fb_fighter_snapshots_pkey PRIMARY KEY (snapshot_id),
+    CONSTRAINT fb_fighter_snapshots_fighter_id_fkey FOREIGN KEY (fighter_id) REFERENCES public.fb_fighters(fighter_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS fb_fighter_snapshots_fighter_id_taken_at_index ON public.fb_fighter_snapshots USING btree (fighter_id, taken_at);
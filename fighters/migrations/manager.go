@@ -1,22 +1,21 @@
 package migrations
 
 import (
+	"embed"
+
+	"fightbettr.com/pkg/migrate"
 	"fightbettr.com/pkg/pgxs"
-	"go.uber.org/zap"
 )
 
-const migrationTableCreate = `CREATE table(
-	
-	)`
+// Service is the name under which the fighters migrations are tracked in the schema_migrations table.
+const Service = "fighters"
 
-type DBMigration struct {
-	*pgxs.Repo
-	logger *zap.SugaredLogger
-	runs   bool
-}
+// FS contains the versioned up and down migrations of the fighters service.
+//
+//go:embed *.sql
+var FS embed.FS
 
-func (dbm *DBMigration) Shutdown() {
-	if dbm.Repo != nil {
-		dbm.Repo.GracefulShutdown()
-	}
+// New creates a migrator for the fighters schema that uses the connection pool of the given repository.
+func New(repo pgxs.FbRepo) (*migrate.Migrator, error) {
+	return migrate.New(repo, Service, FS)
 }
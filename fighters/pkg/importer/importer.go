@@ -0,0 +1,161 @@
+package importer
+
+import (
+	"context"
+	"errors"
+
+	"fightbettr.com/fighters/pkg/model"
+	"fightbettr.com/pkg/pgxs"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrEmptyRoster is returned when the fighters absent from a roster without any fighter url
+// would be released, which would release every active fighter.
+var ErrEmptyRoster = errors.New("empty roster")
+
+// Repository is the storage of the fighters the importer writes to. It is implemented by Store
+// and by the repository of the fighters service.
+type Repository interface {
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error)
+	ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error)
+}
+
+// Importer imports rosters of fighters, keyed by their fighter url, into the fighters database.
+// It is the import of the fighters service, used by its ImportFighters and ReleaseMissingFighters
+// gRPC methods and by the repo update command, and by the clients that write to the database
+// directly, such as the db sink of the scraper.
+type Importer struct {
+	repo  Repository
+	close func()
+}
+
+// New returns an Importer that writes to the repository.
+func New(repo Repository) *Importer {
+	return &Importer{repo: repo}
+}
+
+// Connect connects to the fighters database with the provided configuration and returns
+// an Importer that writes to it. The connection is closed by Close.
+func Connect(ctx context.Context, cfg *pgxs.Config) (*Importer, error) {
+	db, err := pgxs.NewPool(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Importer{repo: NewStore(db), close: db.GetPool().Close}, nil
+}
+
+// Unique removes the fighters without a fighter url and keeps the last entry of every
+// duplicated fighter url, as a batch upsert can not affect the same row twice.
+// It returns the remaining fighters and the number of fighters without a fighter url.
+func Unique(fighters []model.Fighter) ([]model.Fighter, int32) {
+	index := make(map[string]int, len(fighters))
+	unique := make([]model.Fighter, 0, len(fighters))
+
+	var skipped int32
+	for _, f := range fighters {
+		if f.FighterUrl == "" {
+			skipped++
+			continue
+		}
+
+		if i, ok := index[f.FighterUrl]; ok {
+			unique[i] = f
+			continue
+		}
+
+		index[f.FighterUrl] = len(unique)
+		unique = append(unique, f)
+	}
+
+	return unique, skipped
+}
+
+// Import upserts the fighters by their fighter url in a single transaction.
+// Fighters without a fighter url are skipped, and for a duplicated fighter url the last entry is used.
+// It returns the number of created or changed fighters together with the number of skipped ones.
+func (i *Importer) Import(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error) {
+	unique, skipped := Unique(fighters)
+	res := model.ImportResult{Skipped: skipped}
+
+	if len(unique) == 0 {
+		return res, nil
+	}
+
+	affected, err := i.repo.UpsertFighters(ctx, nil, unique)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	res.Affected = affected
+
+	return res, nil
+}
+
+// ImportRoster imports the fighters like Import and treats them as the complete roster: the active
+// fighters absent from it are marked as released in the same transaction, so the roster is either
+// applied as a whole or not at all. It returns ErrEmptyRoster if no fighter has a fighter url.
+func (i *Importer) ImportRoster(ctx context.Context, fighters []model.Fighter) (model.ImportResult, error) {
+	unique, skipped := Unique(fighters)
+	if len(unique) == 0 {
+		return model.ImportResult{}, ErrEmptyRoster
+	}
+
+	tx, err := i.repo.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	affected, err := i.repo.UpsertFighters(ctx, tx, unique)
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	released, err := i.repo.ReleaseMissingFighters(ctx, tx, urls(unique))
+	if err != nil {
+		return model.ImportResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.ImportResult{}, err
+	}
+
+	return model.ImportResult{Affected: affected, Skipped: skipped, Released: released}, nil
+}
+
+// Release marks the active fighters whose fighter url is not in the urls of a complete roster
+// as released. Empty urls are ignored, and ErrEmptyRoster is returned if no url is left.
+// It returns the number of released fighters.
+func (i *Importer) Release(ctx context.Context, urls []string) (int64, error) {
+	roster := make([]string, 0, len(urls))
+	for _, u := range urls {
+		if u != "" {
+			roster = append(roster, u)
+		}
+	}
+
+	if len(roster) == 0 {
+		return 0, ErrEmptyRoster
+	}
+
+	return i.repo.ReleaseMissingFighters(ctx, nil, roster)
+}
+
+// Close closes the database connection of an Importer created by Connect.
+func (i *Importer) Close() {
+	if i.close != nil {
+		i.close()
+	}
+}
+
+// urls returns the fighter urls of the fighters.
+func urls(fighters []model.Fighter) []string {
+	res := make([]string, len(fighters))
+	for i, f := range fighters {
+		res[i] = f.FighterUrl
+	}
+
+	return res
+}
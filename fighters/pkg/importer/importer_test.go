@@ -0,0 +1,146 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"fightbettr.com/fighters/pkg/model"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records whether the transaction is committed or rolled back.
+type fakeTx struct {
+	pgx.Tx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	if !tx.committed {
+		tx.rolledBack = true
+	}
+	return nil
+}
+
+// fakeRepo records the upserted fighters and the released rosters.
+type fakeRepo struct {
+	tx       *fakeTx
+	upserted [][]model.Fighter
+	released [][]string
+	txs      []pgx.Tx
+	err      error
+}
+
+func (r *fakeRepo) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	r.tx = &fakeTx{}
+	return r.tx, nil
+}
+
+func (r *fakeRepo) UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error) {
+	r.upserted = append(r.upserted, fighters)
+	r.txs = append(r.txs, tx)
+	return int64(len(fighters)), nil
+}
+
+func (r *fakeRepo) ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+
+	r.released = append(r.released, urls)
+	r.txs = append(r.txs, tx)
+	return 2, nil
+}
+
+var testFighters = []model.Fighter{
+	{Name: "Jon Jones", FighterUrl: "url-1"},
+	{Name: "No Url"},
+	{Name: "Amanda Nunes", FighterUrl: "url-2"},
+	{Name: "Jon 'Bones' Jones", FighterUrl: "url-1"},
+}
+
+func TestUnique(t *testing.T) {
+	unique, skipped := Unique(testFighters)
+
+	assert.Equal(t, []model.Fighter{testFighters[3], testFighters[2]}, unique)
+	assert.Equal(t, int32(1), skipped)
+
+	unique, skipped = Unique(nil)
+	assert.Empty(t, unique)
+	assert.Zero(t, skipped)
+}
+
+func TestImport(t *testing.T) {
+	repo := &fakeRepo{}
+
+	res, err := New(repo).Import(context.Background(), testFighters)
+	require.NoError(t, err)
+
+	assert.Equal(t, model.ImportResult{Affected: 2, Skipped: 1}, res)
+	assert.Equal(t, [][]model.Fighter{{testFighters[3], testFighters[2]}}, repo.upserted)
+	assert.Empty(t, repo.released)
+
+	res, err = New(repo).Import(context.Background(), testFighters[1:2])
+	require.NoError(t, err)
+	assert.Equal(t, model.ImportResult{Skipped: 1}, res)
+	assert.Len(t, repo.upserted, 1)
+}
+
+func TestImportRoster(t *testing.T) {
+	t.Run("Roster", func(t *testing.T) {
+		repo := &fakeRepo{}
+
+		res, err := New(repo).ImportRoster(context.Background(), testFighters)
+		require.NoError(t, err)
+
+		assert.Equal(t, model.ImportResult{Affected: 2, Skipped: 1, Released: 2}, res)
+		assert.Equal(t, [][]string{{"url-1", "url-2"}}, repo.released)
+		assert.Equal(t, []pgx.Tx{repo.tx, repo.tx}, repo.txs)
+		assert.True(t, repo.tx.committed)
+	})
+
+	t.Run("Empty roster", func(t *testing.T) {
+		repo := &fakeRepo{}
+
+		_, err := New(repo).ImportRoster(context.Background(), testFighters[1:2])
+		assert.ErrorIs(t, err, ErrEmptyRoster)
+		assert.Nil(t, repo.tx)
+		assert.Empty(t, repo.upserted)
+	})
+
+	t.Run("Release error", func(t *testing.T) {
+		repo := &fakeRepo{err: errors.New("database error")}
+
+		_, err := New(repo).ImportRoster(context.Background(), testFighters)
+		assert.EqualError(t, err, "database error")
+		assert.False(t, repo.tx.committed)
+		assert.True(t, repo.tx.rolledBack)
+	})
+}
+
+func TestRelease(t *testing.T) {
+	repo := &fakeRepo{}
+
+	released, err := New(repo).Release(context.Background(), []string{"url-1", "", "url-2"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), released)
+	assert.Equal(t, [][]string{{"url-1", "url-2"}}, repo.released)
+	assert.Equal(t, []pgx.Tx{nil}, repo.txs)
+
+	_, err = New(repo).Release(context.Background(), []string{""})
+	assert.ErrorIs(t, err, ErrEmptyRoster)
+	assert.Len(t, repo.released, 1)
+}
+
+func TestStoreReleaseEmptyRoster(t *testing.T) {
+	_, err := NewStore(nil).ReleaseMissingFighters(context.Background(), nil, nil)
+	assert.ErrorIs(t, err, ErrEmptyRoster)
+}
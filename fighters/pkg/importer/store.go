@@ -0,0 +1,268 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"fightbettr.com/fighters/pkg/model"
+	"fightbettr.com/pkg/pgxs"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Store is the Repository of the fighters database. It holds the queries of the import, so the fighters
+// service and the clients that write to the database directly, such as the db sink of the scraper, share them.
+type Store struct {
+	pgxs.FbRepo
+}
+
+// NewStore returns a Store that runs the queries of the import on the database.
+func NewStore(db pgxs.FbRepo) *Store {
+	return &Store{FbRepo: db}
+}
+
+// importColumns lists the columns of the staging table in the order they are copied.
+var importColumns = []string{
+	"name", "nickname", "division", "status", "hometown",
+	"trains_at", "fighting_style", "age", "height", "weight",
+	"octagon_debut", "debut_timestamp", "reach", "leg_reach", "wins",
+	"loses", "draw", "fighter_url", "image_url",
+	"total_sig_str_landed", "total_sig_str_attempted", "str_accuracy", "total_tkd_landed", "total_tkd_attempted",
+	"tkd_accuracy", "sig_str_landed", "sig_str_absorbed", "sig_str_defense", "takedown_defense",
+	"takedown_avg", "submission_avg", "knockdown_avg", "avg_fight_time", "win_by_ko",
+	"win_by_sub", "win_by_dec",
+}
+
+// UpsertFighters writes the provided fighters and their statistics to the database in bulk.
+// It copies all fighters into a temporary staging table and then merges them into the
+// 'public.fb_fighters' table with INSERT ... ON CONFLICT (fighter_url), so running the same
+// import twice leaves the table unchanged. Rows that are equal to the stored ones are not rewritten.
+// A fighter present in the import is restored if it was soft-deleted, and becomes active again if it
+// was released; retired and deceased fighters keep their state.
+// Statistics are updated for existing fighters and inserted for the new ones, and the fighters
+// are snapshotted with their statistics for the training of the prediction model.
+// The staging table is dropped on commit, so the method must run within a transaction; if no
+// transaction (tx) is provided, it begins and commits its own. The method returns the number
+// of created or changed fighter rows and an error if any step fails.
+func (s *Store) UpsertFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) (int64, error) {
+	if tx == nil {
+		ownTx, err := s.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.ReadCommitted})
+		if err != nil {
+			return 0, err
+		}
+		defer ownTx.Rollback(ctx)
+
+		affected, err := s.UpsertFighters(ctx, ownTx, fighters)
+		if err != nil {
+			return 0, err
+		}
+
+		return affected, ownTx.Commit(ctx)
+	}
+
+	qStaging := `CREATE TEMP TABLE fb_fighters_import (
+		name character varying(255) NOT NULL,
+		nickname character varying(255),
+		division integer NOT NULL,
+		status character varying(50) NOT NULL,
+		hometown character varying(100),
+		trains_at character varying(100),
+		fighting_style character varying(100),
+		age integer NOT NULL,
+		height double precision,
+		weight double precision,
+		octagon_debut character varying(50),
+		debut_timestamp bigint NOT NULL,
+		reach integer,
+		leg_reach integer,
+		wins integer NOT NULL,
+		loses integer NOT NULL,
+		draw integer NOT NULL,
+		fighter_url character varying(255) NOT NULL,
+		image_url text,
+		total_sig_str_landed integer,
+		total_sig_str_attempted integer,
+		str_accuracy integer,
+		total_tkd_landed integer,
+		total_tkd_attempted integer,
+		tkd_accuracy integer,
+		sig_str_landed double precision,
+		sig_str_absorbed double precision,
+		sig_str_defense integer,
+		takedown_defense integer,
+		takedown_avg double precision,
+		submission_avg double precision,
+		knockdown_avg double precision,
+		avg_fight_time character varying(50),
+		win_by_ko integer,
+		win_by_sub integer,
+		win_by_dec integer
+	) ON COMMIT DROP`
+
+	if _, err := tx.Exec(ctx, qStaging); err != nil {
+		return 0, s.DebugLogSqlErr(qStaging, err)
+	}
+
+	rows := pgx.CopyFromSlice(len(fighters), func(i int) ([]any, error) {
+		f := fighters[i]
+		s := f.Stats
+
+		return []any{
+			f.Name, f.NickName, int(f.Division), string(f.Status), f.Hometown,
+			f.TrainsAt, f.FightingStyle, int(f.Age), f.Height, f.Weight,
+			f.OctagonDebut, f.DebutTimestamp, int(f.Reach), int(f.LegReach), f.Wins,
+			f.Loses, f.Draw, f.FighterUrl, f.ImageUrl,
+			s.TotalSigStrLanded, s.TotalSigStrAttempted, s.StrAccuracy, s.TotalTkdLanded, s.TotalTkdAttempted,
+			s.TkdAccuracy, s.SigStrLanded, s.SigStrAbs, int(s.SigStrDefense), int(s.TakedownDefense),
+			s.TakedownAvg, s.SubmissionAvg, s.KnockdownAvg, s.AvgFightTime, s.WinByKO,
+			s.WinBySub, s.WinByDec,
+		}, nil
+	})
+
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{"fb_fighters_import"}, importColumns, rows); err != nil {
+		return 0, err
+	}
+
+	qData := `INSERT INTO public.fb_fighters (
+		name, nickname, division, status, hometown,
+		trains_at, fighting_style, age, height, weight,
+		octagon_debut, debut_timestamp, reach, leg_reach, wins,
+		loses, draw, fighter_url, image_url
+	)
+	SELECT name, nickname, division, status, hometown,
+		trains_at, fighting_style, age, height, weight,
+		octagon_debut, debut_timestamp, reach, leg_reach, wins,
+		loses, draw, fighter_url, image_url
+	FROM fb_fighters_import
+	ON CONFLICT (fighter_url) DO UPDATE SET
+		name = EXCLUDED.name, nickname = EXCLUDED.nickname, division = EXCLUDED.division,
+		status = EXCLUDED.status, hometown = EXCLUDED.hometown, trains_at = EXCLUDED.trains_at,
+		fighting_style = EXCLUDED.fighting_style, age = EXCLUDED.age, height = EXCLUDED.height,
+		weight = EXCLUDED.weight, octagon_debut = EXCLUDED.octagon_debut, debut_timestamp = EXCLUDED.debut_timestamp,
+		reach = EXCLUDED.reach, leg_reach = EXCLUDED.leg_reach, wins = EXCLUDED.wins,
+		loses = EXCLUDED.loses, draw = EXCLUDED.draw, image_url = EXCLUDED.image_url,
+		state = CASE WHEN fb_fighters.state = 'released' THEN 'active' ELSE fb_fighters.state END,
+		state_updated_at = CASE WHEN fb_fighters.state = 'released' THEN EXTRACT(epoch FROM now())::bigint ELSE fb_fighters.state_updated_at END,
+		deleted_at = NULL
+	WHERE fb_fighters.state = 'released' OR fb_fighters.deleted_at IS NOT NULL OR (
+		fb_fighters.name, fb_fighters.nickname, fb_fighters.division, fb_fighters.status, fb_fighters.hometown,
+		fb_fighters.trains_at, fb_fighters.fighting_style, fb_fighters.age, fb_fighters.height, fb_fighters.weight,
+		fb_fighters.octagon_debut, fb_fighters.debut_timestamp, fb_fighters.reach, fb_fighters.leg_reach, fb_fighters.wins,
+		fb_fighters.loses, fb_fighters.draw, fb_fighters.image_url
+	) IS DISTINCT FROM (
+		EXCLUDED.name, EXCLUDED.nickname, EXCLUDED.division, EXCLUDED.status, EXCLUDED.hometown,
+		EXCLUDED.trains_at, EXCLUDED.fighting_style, EXCLUDED.age, EXCLUDED.height, EXCLUDED.weight,
+		EXCLUDED.octagon_debut, EXCLUDED.debut_timestamp, EXCLUDED.reach, EXCLUDED.leg_reach, EXCLUDED.wins,
+		EXCLUDED.loses, EXCLUDED.draw, EXCLUDED.image_url
+	)`
+
+	tag, err := tx.Exec(ctx, qData)
+	if err != nil {
+		return 0, s.DebugLogSqlErr(qData, err)
+	}
+
+	qUpdateStats := `UPDATE public.fb_fighter_stats AS fs SET
+		total_sig_str_landed = i.total_sig_str_landed, total_sig_str_attempted = i.total_sig_str_attempted,
+		str_accuracy = i.str_accuracy, total_tkd_landed = i.total_tkd_landed, total_tkd_attempted = i.total_tkd_attempted,
+		tkd_accuracy = i.tkd_accuracy, sig_str_landed = i.sig_str_landed, sig_str_absorbed = i.sig_str_absorbed,
+		sig_str_defense = i.sig_str_defense, takedown_defense = i.takedown_defense, takedown_avg = i.takedown_avg,
+		submission_avg = i.submission_avg, knockdown_avg = i.knockdown_avg, avg_fight_time = i.avg_fight_time,
+		win_by_ko = i.win_by_ko, win_by_sub = i.win_by_sub, win_by_dec = i.win_by_dec
+		FROM fb_fighters_import AS i
+		JOIN public.fb_fighters AS f ON f.fighter_url = i.fighter_url
+		WHERE fs.fighter_id = f.fighter_id`
+
+	if _, err := tx.Exec(ctx, qUpdateStats); err != nil {
+		return 0, s.DebugLogSqlErr(qUpdateStats, err)
+	}
+
+	qInsertStats := `INSERT INTO public.fb_fighter_stats (
+		fighter_id, total_sig_str_landed, total_sig_str_attempted, str_accuracy, total_tkd_landed,
+		total_tkd_attempted, tkd_accuracy, sig_str_landed, sig_str_absorbed, sig_str_defense,
+		takedown_defense, takedown_avg, submission_avg, knockdown_avg, avg_fight_time,
+		win_by_ko, win_by_sub, win_by_dec
+	)
+	SELECT f.fighter_id, i.total_sig_str_landed, i.total_sig_str_attempted, i.str_accuracy, i.total_tkd_landed,
+		i.total_tkd_attempted, i.tkd_accuracy, i.sig_str_landed, i.sig_str_absorbed, i.sig_str_defense,
+		i.takedown_defense, i.takedown_avg, i.submission_avg, i.knockdown_avg, i.avg_fight_time,
+		i.win_by_ko, i.win_by_sub, i.win_by_dec
+	FROM fb_fighters_import AS i
+	JOIN public.fb_fighters AS f ON f.fighter_url = i.fighter_url
+	WHERE NOT EXISTS (SELECT 1 FROM public.fb_fighter_stats AS fs WHERE fs.fighter_id = f.fighter_id)`
+
+	if _, err := tx.Exec(ctx, qInsertStats); err != nil {
+		return 0, s.DebugLogSqlErr(qInsertStats, err)
+	}
+
+	if err := s.snapshotFighters(ctx, tx, fighters); err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// snapshotFighters stores the imported fighters with their statistics in the 'public.fb_fighter_snapshots'
+// table with the time of the import. A fighter equal to its latest snapshot is not stored again.
+// The snapshots let the prediction model be trained on the stats the fighters had before a fight.
+func (s *Store) snapshotFighters(ctx context.Context, tx pgx.Tx, fighters []model.Fighter) error {
+	urls := make([]string, len(fighters))
+	docs := make([]string, len(fighters))
+	for i, f := range fighters {
+		f.FighterId, f.Stats.StatId, f.Stats.FighterId = 0, 0, 0
+
+		doc, err := json.Marshal(f)
+		if err != nil {
+			return err
+		}
+
+		urls[i], docs[i] = f.FighterUrl, string(doc)
+	}
+
+	q := `INSERT INTO public.fb_fighter_snapshots (fighter_id, taken_at, fighter)
+		SELECT f.fighter_id, $1, i.fighter::jsonb
+		FROM unnest($2::text[], $3::text[]) AS i(fighter_url, fighter)
+		JOIN public.fb_fighters AS f ON f.fighter_url = i.fighter_url
+		WHERE i.fighter::jsonb IS DISTINCT FROM (
+			SELECT p.fighter FROM public.fb_fighter_snapshots AS p
+			WHERE p.fighter_id = f.fighter_id
+			ORDER BY p.taken_at DESC, p.snapshot_id DESC LIMIT 1
+		)`
+
+	if _, err := tx.Exec(ctx, q, time.Now().Unix(), urls, docs); err != nil {
+		return s.DebugLogSqlErr(q, err)
+	}
+
+	return nil
+}
+
+// ReleaseMissingFighters marks active fighters whose fighter_url is not in the provided list as released.
+// It is used after a full scrape, where a fighter absent from the roster is no longer under contract.
+// Retired and deceased fighters keep their state. The method returns the number of released fighters,
+// or ErrEmptyRoster if the list is empty.
+func (s *Store) ReleaseMissingFighters(ctx context.Context, tx pgx.Tx, urls []string) (int64, error) {
+	if len(urls) == 0 {
+		return 0, ErrEmptyRoster
+	}
+
+	q := `UPDATE public.fb_fighters
+		SET state = 'released', state_updated_at = $2
+		WHERE state = 'active' AND deleted_at IS NULL AND NOT (fighter_url = ANY($1))`
+
+	tag, err := s.exec(ctx, tx, q, urls, time.Now().Unix())
+	if err != nil {
+		return 0, s.DebugLogSqlErr(q, err)
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// exec executes the query within the transaction if it is provided, or on the pool otherwise.
+func (s *Store) exec(ctx context.Context, tx pgx.Tx, q string, args ...any) (pgconn.CommandTag, error) {
+	if tx != nil {
+		return tx.Exec(ctx, q, args...)
+	}
+
+	return s.GetPool().Exec(ctx, q, args...)
+}
@@ -6,6 +6,26 @@ type Division int
 // FighterStatus defines a fighter status. Uses to find active fighters
 type FighterStatus string
 
+// FighterState defines the lifecycle state of a fighter in the roster
+type FighterState string
+
+const (
+	StateActive   FighterState = "active"
+	StateRetired  FighterState = "retired"
+	StateReleased FighterState = "released"
+	StateDeceased FighterState = "deceased"
+)
+
+// IsValid reports whether the state is one of the known lifecycle states.
+func (s FighterState) IsValid() bool {
+	switch s {
+	case StateActive, StateRetired, StateReleased, StateDeceased:
+		return true
+	default:
+		return false
+	}
+}
+
 // String returns the string representation of a Division.
 func (d Division) String() string {
 	switch d {
@@ -99,10 +119,49 @@ type Fighter struct {
 	FighterUrl     string        `json:"fighterUrl"`
 	ImageUrl       string        `json:"imageUrl"`
 	Stats          FighterStats  `json:"stats"`
+	State          FighterState  `json:"state,omitempty"`
+	DeletedAt      int64         `json:"deletedAt,omitempty"`
 }
 
 // FightersRequest represents a request for fighters
 type FightersRequest struct {
-	Status      string  `json:"status"`
-	FightersIds []int32 `json:"fighter_ids"`
+	Status      string   `json:"status"`
+	FightersIds []int32  `json:"fighter_ids"`
+	State       string   `json:"state"`
+	FighterUrls []string `json:"fighter_urls"`
+}
+
+// ImportResult represents the outcome of a fighters import
+type ImportResult struct {
+	Affected int64 `json:"affected"`
+	Skipped  int32 `json:"skipped"`
+	Released int64 `json:"released,omitempty"`
+}
+
+// FightResult represents a finished fight that is used to train the prediction model.
+// Red and Blue are the snapshots of the fighters as they were imported before the fight date.
+type FightResult struct {
+	FightId       int32    `json:"fight_id"`
+	FighterRedId  int32    `json:"fighter_red_id"`
+	FighterBlueId int32    `json:"fighter_blue_id"`
+	WinnerId      int32    `json:"winner_id"`
+	FightDate     int64    `json:"fight_date"`
+	Red           *Fighter `json:"red,omitempty"`
+	Blue          *Fighter `json:"blue,omitempty"`
+}
+
+// FightPair represents the two corners of a fight to predict
+type FightPair struct {
+	FightId       int32 `json:"fight_id"`
+	FighterRedId  int32 `json:"fighter_red_id"`
+	FighterBlueId int32 `json:"fighter_blue_id"`
+}
+
+// FightPrediction represents the win probability of each corner of a fight
+type FightPrediction struct {
+	FightId            int32   `json:"fight_id"`
+	FighterRedId       int32   `json:"fighter_red_id"`
+	FighterBlueId      int32   `json:"fighter_blue_id"`
+	RedWinProbability  float32 `json:"redWinProbability"`
+	BlueWinProbability float32 `json:"blueWinProbability"`
 }
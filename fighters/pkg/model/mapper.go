@@ -26,6 +26,7 @@ func FighterToProto(f *Fighter) *gen.Fighter {
 		FighterUrl:     f.FighterUrl,
 		ImageUrl:       f.ImageUrl,
 		Stats:          FighterStatsrToProto(&f.Stats),
+		State:          string(f.State),
 	}
 }
 
@@ -52,6 +53,8 @@ func FighterFromProto(f *gen.Fighter) *Fighter {
 		TrainsAt:       f.TrainsAt,
 		FightingStyle:  f.FightingStyle,
 		Age:            int8(f.Age),
+		Height:         f.Height,
+		Weight:         f.Weight,
 		OctagonDebut:   f.OctagonDebut,
 		DebutTimestamp: int(f.DebutTimestamp),
 		Reach:          f.Reach,
@@ -62,6 +65,7 @@ func FighterFromProto(f *gen.Fighter) *Fighter {
 		FighterUrl:     f.FighterUrl,
 		ImageUrl:       f.ImageUrl,
 		Stats:          *FighterStatsFromProto(f.Stats),
+		State:          FighterState(f.State),
 	}
 }
 
@@ -102,7 +106,12 @@ func FighterStatsrToProto(f *FighterStats) *gen.FighterStats {
 }
 
 // FighterStatsFromProto converts a generated proto counterpart into a single Fighter stats struct..
+// A nil proto counterpart results in empty stats.
 func FighterStatsFromProto(f *gen.FighterStats) *FighterStats {
+	if f == nil {
+		return &FighterStats{}
+	}
+
 	return &FighterStats{
 		StatId:               f.StatId,
 		FighterId:            f.FighterId,
@@ -129,11 +138,80 @@ func FighterStatsFromProto(f *gen.FighterStats) *FighterStats {
 func FightersReqToProto(freq FightersRequest) *gen.FightersRequest {
 	req := &gen.FightersRequest{
 		Status: freq.Status,
+		State:  freq.State,
 	}
 
 	if freq.FightersIds != nil && len(freq.FightersIds) > 0 {
 		req.FightersIds = freq.FightersIds
 	}
 
+	if len(freq.FighterUrls) > 0 {
+		req.FighterUrls = freq.FighterUrls
+	}
+
 	return req
 }
+
+// FightPairsToProto converts a slice of FightPair structs into a slice of generated proto counterparts.
+func FightPairsToProto(pairs []FightPair) []*gen.FightPair {
+	protoPairs := make([]*gen.FightPair, len(pairs))
+
+	for i, p := range pairs {
+		protoPairs[i] = &gen.FightPair{
+			FightId:       p.FightId,
+			FighterRedId:  p.FighterRedId,
+			FighterBlueId: p.FighterBlueId,
+		}
+	}
+
+	return protoPairs
+}
+
+// FightPairsFromProto converts a slice of generated proto counterparts into a slice of FightPair structs.
+func FightPairsFromProto(ps []*gen.FightPair) []FightPair {
+	pairs := make([]FightPair, len(ps))
+
+	for i, p := range ps {
+		pairs[i] = FightPair{
+			FightId:       p.FightId,
+			FighterRedId:  p.FighterRedId,
+			FighterBlueId: p.FighterBlueId,
+		}
+	}
+
+	return pairs
+}
+
+// FightPredictionsToProto converts a slice of FightPrediction structs into a slice of generated proto counterparts.
+func FightPredictionsToProto(predictions []*FightPrediction) []*gen.FightPrediction {
+	protoPredictions := make([]*gen.FightPrediction, len(predictions))
+
+	for i, p := range predictions {
+		protoPredictions[i] = &gen.FightPrediction{
+			FightId:            p.FightId,
+			FighterRedId:       p.FighterRedId,
+			FighterBlueId:      p.FighterBlueId,
+			RedWinProbability:  p.RedWinProbability,
+			BlueWinProbability: p.BlueWinProbability,
+		}
+	}
+
+	return protoPredictions
+}
+
+// FightPredictionsFromProto converts a slice of generated proto counterparts into a slice of FightPrediction structs.
+func FightPredictionsFromProto(ps []*gen.FightPrediction) []*FightPrediction {
+	predictions := make([]*FightPrediction, len(ps))
+
+	for i, p := range ps {
+		predictions[i] = &FightPrediction{
+			FightId:            p.FightId,
+			FighterRedId:       p.FighterRedId,
+			FighterBlueId:      p.FighterBlueId,
+			RedWinProbability:  p.RedWinProbability,
+			BlueWinProbability: p.BlueWinProbability,
+		}
+	}
+
+	return predictions
+}
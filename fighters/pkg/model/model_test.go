@@ -609,6 +609,23 @@ func TestFighterFromProto(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Fighter with measurements and without stats",
+			input: &gen.Fighter{
+				FighterId:  3,
+				Name:       "Jon Jones",
+				Height:     76.0,
+				Weight:     248.0,
+				FighterUrl: "http://example.com/fighter3",
+			},
+			expected: &Fighter{
+				FighterId:  3,
+				Name:       "Jon Jones",
+				Height:     76.0,
+				Weight:     248.0,
+				FighterUrl: "http://example.com/fighter3",
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -823,6 +840,15 @@ func TestFightersReqToProto(t *testing.T) {
 				FightersIds: []int32{1, 2, 3},
 			},
 		},
+		{
+			name: "Case with FighterUrls",
+			input: FightersRequest{
+				FighterUrls: []string{"https://www.ufc.com/athlete/jon-jones"},
+			},
+			expected: &gen.FightersRequest{
+				FighterUrls: []string{"https://www.ufc.com/athlete/jon-jones"},
+			},
+		},
 		{
 			name: "Case with Empty FightersIds",
 			input: FightersRequest{
@@ -863,3 +889,53 @@ func TestFightersReqToProto(t *testing.T) {
 		})
 	}
 }
+
+func TestFightPairsToProto(t *testing.T) {
+	pairs := []FightPair{
+		{FightId: 1, FighterRedId: 10, FighterBlueId: 20},
+		{FightId: 2, FighterRedId: 30, FighterBlueId: 40},
+	}
+
+	expected := []*gen.FightPair{
+		{FightId: 1, FighterRedId: 10, FighterBlueId: 20},
+		{FightId: 2, FighterRedId: 30, FighterBlueId: 40},
+	}
+
+	actual := FightPairsToProto(pairs)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, pairs, FightPairsFromProto(actual))
+}
+
+func TestFightPredictionsToProto(t *testing.T) {
+	predictions := []*FightPrediction{
+		{FightId: 1, FighterRedId: 10, FighterBlueId: 20, RedWinProbability: 0.6, BlueWinProbability: 0.4},
+	}
+
+	expected := []*gen.FightPrediction{
+		{FightId: 1, FighterRedId: 10, FighterBlueId: 20, RedWinProbability: 0.6, BlueWinProbability: 0.4},
+	}
+
+	actual := FightPredictionsToProto(predictions)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, predictions, FightPredictionsFromProto(actual))
+}
+
+func TestFighterStateIsValid(t *testing.T) {
+	tests := []struct {
+		state    FighterState
+		expected bool
+	}{
+		{state: StateActive, expected: true},
+		{state: StateRetired, expected: true},
+		{state: StateReleased, expected: true},
+		{state: StateDeceased, expected: true},
+		{state: "", expected: false},
+		{state: "suspended", expected: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(string(tc.state), func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.state.IsValid())
+		})
+	}
+}
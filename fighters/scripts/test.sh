@@ -5,9 +5,12 @@ packages=(
     "./internal/service/fighters"
     "./internal/handler/grpc"
     "./internal/controller/fighters"
+    "./internal/prediction"
+    "./internal/roster"
     "./internal/repository/psql"
     "./pkg/cfg"
     "./pkg/errors"
+    "./pkg/importer"
     "./pkg/model"
 )
 
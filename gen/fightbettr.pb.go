@@ -1655,6 +1655,7 @@ type Fighter struct {
 	FighterUrl     string        `protobuf:"bytes,19,opt,name=fighterUrl,proto3" json:"fighterUrl,omitempty"`
 	ImageUrl       string        `protobuf:"bytes,20,opt,name=imageUrl,proto3" json:"imageUrl,omitempty"`
 	Stats          *FighterStats `protobuf:"bytes,21,opt,name=stats,proto3" json:"stats,omitempty"`
+	State          string        `protobuf:"bytes,22,opt,name=state,proto3" json:"state,omitempty"`
 }
 
 func (x *Fighter) Reset() {
@@ -1836,6 +1837,13 @@ func (x *Fighter) GetStats() *FighterStats {
 	return nil
 }
 
+func (x *Fighter) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 type FighterStats struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2032,8 +2040,10 @@ type FightersRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status      string  `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	FightersIds []int32 `protobuf:"varint,2,rep,packed,name=fightersIds,proto3" json:"fightersIds,omitempty"`
+	Status      string   `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	FightersIds []int32  `protobuf:"varint,2,rep,packed,name=fightersIds,proto3" json:"fightersIds,omitempty"`
+	State       string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	FighterUrls []string `protobuf:"bytes,4,rep,name=fighterUrls,proto3" json:"fighterUrls,omitempty"`
 }
 
 func (x *FightersRequest) Reset() {
@@ -2082,6 +2092,20 @@ func (x *FightersRequest) GetFightersIds() []int32 {
 	return nil
 }
 
+func (x *FightersRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *FightersRequest) GetFighterUrls() []string {
+	if x != nil {
+		return x.FighterUrls
+	}
+	return nil
+}
+
 type FightersResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2176,6 +2200,438 @@ func (x *FightersCountResponse) GetCount() int32 {
 	return 0
 }
 
+type FightPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FightId       int32 `protobuf:"varint,1,opt,name=fightId,proto3" json:"fightId,omitempty"`
+	FighterRedId  int32 `protobuf:"varint,2,opt,name=fighterRedId,proto3" json:"fighterRedId,omitempty"`
+	FighterBlueId int32 `protobuf:"varint,3,opt,name=fighterBlueId,proto3" json:"fighterBlueId,omitempty"`
+}
+
+func (x *FightPair) Reset() {
+	*x = FightPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FightPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FightPair) ProtoMessage() {}
+
+func (x *FightPair) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FightPair.ProtoReflect.Descriptor instead.
+func (*FightPair) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FightPair) GetFightId() int32 {
+	if x != nil {
+		return x.FightId
+	}
+	return 0
+}
+
+func (x *FightPair) GetFighterRedId() int32 {
+	if x != nil {
+		return x.FighterRedId
+	}
+	return 0
+}
+
+func (x *FightPair) GetFighterBlueId() int32 {
+	if x != nil {
+		return x.FighterBlueId
+	}
+	return 0
+}
+
+type FightPrediction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FightId            int32   `protobuf:"varint,1,opt,name=fightId,proto3" json:"fightId,omitempty"`
+	FighterRedId       int32   `protobuf:"varint,2,opt,name=fighterRedId,proto3" json:"fighterRedId,omitempty"`
+	FighterBlueId      int32   `protobuf:"varint,3,opt,name=fighterBlueId,proto3" json:"fighterBlueId,omitempty"`
+	RedWinProbability  float32 `protobuf:"fixed32,4,opt,name=redWinProbability,proto3" json:"redWinProbability,omitempty"`
+	BlueWinProbability float32 `protobuf:"fixed32,5,opt,name=blueWinProbability,proto3" json:"blueWinProbability,omitempty"`
+}
+
+func (x *FightPrediction) Reset() {
+	*x = FightPrediction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FightPrediction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FightPrediction) ProtoMessage() {}
+
+func (x *FightPrediction) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FightPrediction.ProtoReflect.Descriptor instead.
+func (*FightPrediction) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *FightPrediction) GetFightId() int32 {
+	if x != nil {
+		return x.FightId
+	}
+	return 0
+}
+
+func (x *FightPrediction) GetFighterRedId() int32 {
+	if x != nil {
+		return x.FighterRedId
+	}
+	return 0
+}
+
+func (x *FightPrediction) GetFighterBlueId() int32 {
+	if x != nil {
+		return x.FighterBlueId
+	}
+	return 0
+}
+
+func (x *FightPrediction) GetRedWinProbability() float32 {
+	if x != nil {
+		return x.RedWinProbability
+	}
+	return 0
+}
+
+func (x *FightPrediction) GetBlueWinProbability() float32 {
+	if x != nil {
+		return x.BlueWinProbability
+	}
+	return 0
+}
+
+type PredictFightsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fights []*FightPair `protobuf:"bytes,1,rep,name=fights,proto3" json:"fights,omitempty"`
+}
+
+func (x *PredictFightsRequest) Reset() {
+	*x = PredictFightsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PredictFightsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictFightsRequest) ProtoMessage() {}
+
+func (x *PredictFightsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictFightsRequest.ProtoReflect.Descriptor instead.
+func (*PredictFightsRequest) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *PredictFightsRequest) GetFights() []*FightPair {
+	if x != nil {
+		return x.Fights
+	}
+	return nil
+}
+
+type PredictFightsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Predictions []*FightPrediction `protobuf:"bytes,1,rep,name=predictions,proto3" json:"predictions,omitempty"`
+}
+
+func (x *PredictFightsResponse) Reset() {
+	*x = PredictFightsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PredictFightsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredictFightsResponse) ProtoMessage() {}
+
+func (x *PredictFightsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredictFightsResponse.ProtoReflect.Descriptor instead.
+func (*PredictFightsResponse) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *PredictFightsResponse) GetPredictions() []*FightPrediction {
+	if x != nil {
+		return x.Predictions
+	}
+	return nil
+}
+
+type ImportFightersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fighters []*Fighter `protobuf:"bytes,1,rep,name=fighters,proto3" json:"fighters,omitempty"`
+}
+
+func (x *ImportFightersRequest) Reset() {
+	*x = ImportFightersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportFightersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFightersRequest) ProtoMessage() {}
+
+func (x *ImportFightersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFightersRequest.ProtoReflect.Descriptor instead.
+func (*ImportFightersRequest) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ImportFightersRequest) GetFighters() []*Fighter {
+	if x != nil {
+		return x.Fighters
+	}
+	return nil
+}
+
+type ImportFightersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Affected int64 `protobuf:"varint,1,opt,name=affected,proto3" json:"affected,omitempty"`
+	Skipped  int32 `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"`
+}
+
+func (x *ImportFightersResponse) Reset() {
+	*x = ImportFightersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImportFightersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportFightersResponse) ProtoMessage() {}
+
+func (x *ImportFightersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportFightersResponse.ProtoReflect.Descriptor instead.
+func (*ImportFightersResponse) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ImportFightersResponse) GetAffected() int64 {
+	if x != nil {
+		return x.Affected
+	}
+	return 0
+}
+
+func (x *ImportFightersResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
+type ReleaseMissingFightersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FighterUrls []string `protobuf:"bytes,1,rep,name=fighterUrls,proto3" json:"fighterUrls,omitempty"`
+}
+
+func (x *ReleaseMissingFightersRequest) Reset() {
+	*x = ReleaseMissingFightersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseMissingFightersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseMissingFightersRequest) ProtoMessage() {}
+
+func (x *ReleaseMissingFightersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseMissingFightersRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseMissingFightersRequest) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ReleaseMissingFightersRequest) GetFighterUrls() []string {
+	if x != nil {
+		return x.FighterUrls
+	}
+	return nil
+}
+
+type ReleaseMissingFightersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Released int64 `protobuf:"varint,1,opt,name=released,proto3" json:"released,omitempty"`
+}
+
+func (x *ReleaseMissingFightersResponse) Reset() {
+	*x = ReleaseMissingFightersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_fightbettr_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReleaseMissingFightersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseMissingFightersResponse) ProtoMessage() {}
+
+func (x *ReleaseMissingFightersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_fightbettr_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseMissingFightersResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseMissingFightersResponse) Descriptor() ([]byte, []int) {
+	return file_fightbettr_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ReleaseMissingFightersResponse) GetReleased() int64 {
+	if x != nil {
+		return x.Released
+	}
+	return 0
+}
+
 var File_fightbettr_proto protoreflect.FileDescriptor
 
 var file_fightbettr_proto_rawDesc = []byte{
@@ -2346,7 +2802,7 @@ var file_fightbettr_proto_rawDesc = []byte{
 	0x68, 0x74, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09,
 	0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0xc8, 0x04, 0x0a, 0x07, 0x46,
+	0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49, 0x64, 0x22, 0xde, 0x04, 0x0a, 0x07, 0x46,
 	0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65,
 	0x72, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74,
 	0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
@@ -2383,112 +2839,177 @@ var file_fightbettr_proto_rawDesc = []byte{
 	0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x55, 0x72, 0x6c,
 	0x12, 0x23, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x0d, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x05,
-	0x73, 0x74, 0x61, 0x74, 0x73, 0x22, 0xb4, 0x05, 0x0a, 0x0c, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65,
-	0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x49, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x49, 0x64, 0x12, 0x1c,
-	0x0a, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11,
-	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69,
-	0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x32, 0x0a, 0x14, 0x74, 0x6f,
-	0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74,
-	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53,
-	0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x12, 0x20,
-	0x0a, 0x0b, 0x73, 0x74, 0x72, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79,
-	0x12, 0x26, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x6b, 0x64, 0x4c, 0x61, 0x6e, 0x64,
-	0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54,
-	0x6b, 0x64, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61,
-	0x6c, 0x54, 0x6b, 0x64, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x6b, 0x64, 0x41, 0x74, 0x74,
-	0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x6b, 0x64, 0x41, 0x63, 0x63,
-	0x75, 0x72, 0x61, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x74, 0x6b, 0x64,
-	0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x69, 0x67, 0x53,
-	0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c,
-	0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09,
-	0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x62, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x09, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x62, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x69,
-	0x67, 0x53, 0x74, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65,
-	0x12, 0x28, 0x0a, 0x0f, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x44, 0x65, 0x66, 0x65,
-	0x6e, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x74, 0x61, 0x6b, 0x65, 0x64,
-	0x6f, 0x77, 0x6e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61,
-	0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x02, 0x52,
-	0x0b, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x12, 0x24, 0x0a, 0x0d,
-	0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x76, 0x67, 0x18, 0x0e, 0x20,
-	0x01, 0x28, 0x02, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41,
-	0x76, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x41,
-	0x76, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x64,
-	0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x61, 0x76, 0x67, 0x46, 0x69, 0x67,
-	0x68, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x76,
-	0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x69,
-	0x6e, 0x42, 0x79, 0x4b, 0x4f, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x77, 0x69, 0x6e,
-	0x42, 0x79, 0x4b, 0x4f, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x53, 0x75, 0x62,
-	0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x53, 0x75, 0x62,
-	0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x44, 0x65, 0x63, 0x18, 0x13, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x44, 0x65, 0x63, 0x22, 0x4b, 0x0a, 0x0f,
-	0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74,
-	0x65, 0x72, 0x73, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x69,
-	0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x49, 0x64, 0x73, 0x22, 0x38, 0x0a, 0x10, 0x46, 0x69, 0x67,
-	0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
-	0x08, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x08, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52, 0x08, 0x66, 0x69, 0x67, 0x68, 0x74,
-	0x65, 0x72, 0x73, 0x22, 0x2d, 0x0a, 0x15, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75,
-	0x6e, 0x74, 0x32, 0xf0, 0x02, 0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x12, 0x2f, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x10,
-	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x11, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x17, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x18, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
-	0x6d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x4c, 0x6f, 0x67,
-	0x69, 0x6e, 0x12, 0x14, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65,
-	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x3e, 0x0a, 0x0d, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74,
-	0x12, 0x15, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f,
-	0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x46, 0x0a, 0x0f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76,
-	0x65, 0x72, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63,
-	0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x50,
-	0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x12, 0x0f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x90, 0x02, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x13, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x76,
-	0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x72, 0x65,
-	0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x32, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x11, 0x2e,
-	0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x12, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65,
-	0x74, 0x12, 0x11, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42,
-	0x65, 0x74, 0x73, 0x12, 0x0c, 0x2e, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0d, 0x2e, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x36, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x13, 0x2e,
-	0x46, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x14, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x89, 0x01, 0x0a, 0x0f, 0x46, 0x69, 0x67,
-	0x68, 0x74, 0x65, 0x72, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x13,
-	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x43, 0x6f,
-	0x75, 0x6e, 0x74, 0x12, 0x10, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73,
-	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a,
-	0x0e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x12,
-	0x10, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x11, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x42, 0x06, 0x5a, 0x04, 0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x16,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x22, 0xb4, 0x05, 0x0a, 0x0c,
+	0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x49,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72,
+	0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74,
+	0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64,
+	0x12, 0x32, 0x0a, 0x14, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x14,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x74, 0x74, 0x65, 0x6d,
+	0x70, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x74, 0x72, 0x41, 0x63, 0x63, 0x75, 0x72,
+	0x61, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x73, 0x74, 0x72, 0x41, 0x63,
+	0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54,
+	0x6b, 0x64, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x6b, 0x64, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x2c,
+	0x0a, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x6b, 0x64, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70,
+	0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x11, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x54, 0x6b, 0x64, 0x41, 0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x74, 0x6b, 0x64, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x74, 0x6b, 0x64, 0x41, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x4c, 0x61, 0x6e, 0x64,
+	0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x62, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x02, 0x52, 0x09, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x41, 0x62, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x73, 0x69, 0x67, 0x53, 0x74, 0x72, 0x44,
+	0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f,
+	0x77, 0x6e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0f, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x44, 0x65, 0x66, 0x65, 0x6e, 0x73, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0b, 0x74, 0x61, 0x6b, 0x65, 0x64, 0x6f, 0x77, 0x6e, 0x41,
+	0x76, 0x67, 0x12, 0x24, 0x0a, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x41, 0x76, 0x67, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0d, 0x73, 0x75, 0x62, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x76, 0x67, 0x12, 0x22, 0x0a, 0x0c, 0x6b, 0x6e, 0x6f, 0x63,
+	0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x02, 0x52, 0x0c,
+	0x6b, 0x6e, 0x6f, 0x63, 0x6b, 0x64, 0x6f, 0x77, 0x6e, 0x41, 0x76, 0x67, 0x12, 0x22, 0x0a, 0x0c,
+	0x61, 0x76, 0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x61, 0x76, 0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x4b, 0x4f, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x4b, 0x4f, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69,
+	0x6e, 0x42, 0x79, 0x53, 0x75, 0x62, 0x18, 0x12, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77, 0x69,
+	0x6e, 0x42, 0x79, 0x53, 0x75, 0x62, 0x12, 0x1a, 0x0a, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x44,
+	0x65, 0x63, 0x18, 0x13, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x42, 0x79, 0x44,
+	0x65, 0x63, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x49, 0x64, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x49, 0x64, 0x73,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65,
+	0x72, 0x55, 0x72, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x67,
+	0x68, 0x74, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x73, 0x22, 0x38, 0x0a, 0x10, 0x46, 0x69, 0x67, 0x68,
+	0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x08,
+	0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08,
+	0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52, 0x08, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65,
+	0x72, 0x73, 0x22, 0x2d, 0x0a, 0x15, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x22, 0x6f, 0x0a, 0x09, 0x46, 0x69, 0x67, 0x68, 0x74, 0x50, 0x61, 0x69, 0x72, 0x12, 0x18,
+	0x0a, 0x07, 0x66, 0x69, 0x67, 0x68, 0x74, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x07, 0x66, 0x69, 0x67, 0x68, 0x74, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x67, 0x68,
+	0x74, 0x65, 0x72, 0x52, 0x65, 0x64, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c,
+	0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52, 0x65, 0x64, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x42, 0x6c, 0x75, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x42, 0x6c, 0x75, 0x65,
+	0x49, 0x64, 0x22, 0xd3, 0x01, 0x0a, 0x0f, 0x46, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69, 0x67, 0x68, 0x74, 0x49,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x66, 0x69, 0x67, 0x68, 0x74, 0x49, 0x64,
+	0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52, 0x65, 0x64, 0x49, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52,
+	0x65, 0x64, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x42,
+	0x6c, 0x75, 0x65, 0x49, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0d, 0x66, 0x69, 0x67,
+	0x68, 0x74, 0x65, 0x72, 0x42, 0x6c, 0x75, 0x65, 0x49, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x72, 0x65,
+	0x64, 0x57, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x02, 0x52, 0x11, 0x72, 0x65, 0x64, 0x57, 0x69, 0x6e, 0x50, 0x72, 0x6f,
+	0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x12, 0x62, 0x6c, 0x75, 0x65,
+	0x57, 0x69, 0x6e, 0x50, 0x72, 0x6f, 0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x02, 0x52, 0x12, 0x62, 0x6c, 0x75, 0x65, 0x57, 0x69, 0x6e, 0x50, 0x72, 0x6f,
+	0x62, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x22, 0x3a, 0x0a, 0x14, 0x50, 0x72, 0x65, 0x64,
+	0x69, 0x63, 0x74, 0x46, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x22, 0x0a, 0x06, 0x66, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0a, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x50, 0x61, 0x69, 0x72, 0x52, 0x06, 0x66, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x22, 0x4b, 0x0a, 0x15, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x46,
+	0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a,
+	0x0b, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x3d, 0x0a, 0x15, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x67, 0x68, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x24, 0x0a, 0x08, 0x66, 0x69,
+	0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x08, 0x2e, 0x46,
+	0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x52, 0x08, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73,
+	0x22, 0x4e, 0x0a, 0x16, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65,
+	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x66,
+	0x66, 0x65, 0x63, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x6b, 0x69, 0x70, 0x70, 0x65, 0x64,
+	0x22, 0x41, 0x0a, 0x1d, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x69,
+	0x6e, 0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x55, 0x72, 0x6c, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x55,
+	0x72, 0x6c, 0x73, 0x22, 0x3c, 0x0a, 0x1e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69,
+	0x73, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x32, 0xf0, 0x02, 0x0a, 0x0b, 0x41, 0x75, 0x74, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x2f, 0x0a, 0x08, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x10, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x11, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x44, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x12, 0x17, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x05, 0x4c, 0x6f, 0x67, 0x69,
+	0x6e, 0x12, 0x14, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e,
+	0x0a, 0x0d, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x12,
+	0x15, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72,
+	0x64, 0x52, 0x65, 0x73, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x46,
+	0x0a, 0x0f, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65,
+	0x72, 0x12, 0x18, 0x2e, 0x50, 0x61, 0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63, 0x6f,
+	0x76, 0x65, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x50, 0x61,
+	0x73, 0x73, 0x77, 0x6f, 0x72, 0x64, 0x52, 0x65, 0x63, 0x6f, 0x76, 0x65, 0x72, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x12, 0x0f, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x10, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x32, 0x90, 0x02, 0x0a, 0x0c, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x13, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x32, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x11, 0x2e, 0x47,
+	0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65, 0x74,
+	0x12, 0x11, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x65, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x42, 0x65,
+	0x74, 0x73, 0x12, 0x0c, 0x2e, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x0d, 0x2e, 0x42, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x36, 0x0a, 0x09, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x13, 0x2e, 0x46,
+	0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x14, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xe7, 0x02, 0x0a, 0x0f, 0x46, 0x69, 0x67, 0x68,
+	0x74, 0x65, 0x72, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3f, 0x0a, 0x13, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x10, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x12, 0x10,
+	0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0d, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x46, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x50, 0x72, 0x65, 0x64, 0x69, 0x63, 0x74, 0x46, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x50, 0x72,
+	0x65, 0x64, 0x69, 0x63, 0x74, 0x46, 0x69, 0x67, 0x68, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x0e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x67,
+	0x68, 0x74, 0x65, 0x72, 0x73, 0x12, 0x16, 0x2e, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69,
+	0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x59, 0x0a, 0x16, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73,
+	0x65, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73,
+	0x12, 0x1e, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6e,
+	0x67, 0x46, 0x69, 0x67, 0x68, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x06, 0x5a, 0x04, 0x2f, 0x67, 0x65, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -2503,86 +3024,103 @@ func file_fightbettr_proto_rawDescGZIP() []byte {
 	return file_fightbettr_proto_rawDescData
 }
 
-var file_fightbettr_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_fightbettr_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
 var file_fightbettr_proto_goTypes = []interface{}{
-	(*RegisterRequest)(nil),          // 0: RegisterRequest
-	(*RegisterResponse)(nil),         // 1: RegisterResponse
-	(*RegisterConfirmRequest)(nil),   // 2: RegisterConfirmRequest
-	(*RegisterConfirmResponse)(nil),  // 3: RegisterConfirmResponse
-	(*AuthenticateRequest)(nil),      // 4: AuthenticateRequest
-	(*AuthenticateResponse)(nil),     // 5: AuthenticateResponse
-	(*PasswordResetRequest)(nil),     // 6: PasswordResetRequest
-	(*PasswordResetResponse)(nil),    // 7: PasswordResetResponse
-	(*PasswordRecoveryRequest)(nil),  // 8: PasswordRecoveryRequest
-	(*PasswordRecoveryResponse)(nil), // 9: PasswordRecoveryResponse
-	(*ProfileRequest)(nil),           // 10: ProfileRequest
-	(*ProfileResponse)(nil),          // 11: ProfileResponse
-	(*User)(nil),                     // 12: User
-	(*CreateEventRequest)(nil),       // 13: CreateEventRequest
-	(*CreateEventResponse)(nil),      // 14: CreateEventResponse
-	(*GetEventsRequest)(nil),         // 15: GetEventsRequest
-	(*GetEventsResponse)(nil),        // 16: GetEventsResponse
-	(*CreateBetRequest)(nil),         // 17: CreateBetRequest
-	(*CreateBetResponse)(nil),        // 18: CreateBetResponse
-	(*BetsRequest)(nil),              // 19: BetsRequest
-	(*BetsResponse)(nil),             // 20: BetsResponse
-	(*FightResultRequest)(nil),       // 21: FightResultRequest
-	(*FightResultResponse)(nil),      // 22: FightResultResponse
-	(*Fight)(nil),                    // 23: Fight
-	(*Event)(nil),                    // 24: Event
-	(*Bet)(nil),                      // 25: Bet
-	(*Fighter)(nil),                  // 26: Fighter
-	(*FighterStats)(nil),             // 27: FighterStats
-	(*FightersRequest)(nil),          // 28: FightersRequest
-	(*FightersResponse)(nil),         // 29: FightersResponse
-	(*FightersCountResponse)(nil),    // 30: FightersCountResponse
-	(*empty.Empty)(nil),              // 31: google.protobuf.Empty
-	(*timestamp.Timestamp)(nil),      // 32: google.protobuf.Timestamp
+	(*RegisterRequest)(nil),                // 0: RegisterRequest
+	(*RegisterResponse)(nil),               // 1: RegisterResponse
+	(*RegisterConfirmRequest)(nil),         // 2: RegisterConfirmRequest
+	(*RegisterConfirmResponse)(nil),        // 3: RegisterConfirmResponse
+	(*AuthenticateRequest)(nil),            // 4: AuthenticateRequest
+	(*AuthenticateResponse)(nil),           // 5: AuthenticateResponse
+	(*PasswordResetRequest)(nil),           // 6: PasswordResetRequest
+	(*PasswordResetResponse)(nil),          // 7: PasswordResetResponse
+	(*PasswordRecoveryRequest)(nil),        // 8: PasswordRecoveryRequest
+	(*PasswordRecoveryResponse)(nil),       // 9: PasswordRecoveryResponse
+	(*ProfileRequest)(nil),                 // 10: ProfileRequest
+	(*ProfileResponse)(nil),                // 11: ProfileResponse
+	(*User)(nil),                           // 12: User
+	(*CreateEventRequest)(nil),             // 13: CreateEventRequest
+	(*CreateEventResponse)(nil),            // 14: CreateEventResponse
+	(*GetEventsRequest)(nil),               // 15: GetEventsRequest
+	(*GetEventsResponse)(nil),              // 16: GetEventsResponse
+	(*CreateBetRequest)(nil),               // 17: CreateBetRequest
+	(*CreateBetResponse)(nil),              // 18: CreateBetResponse
+	(*BetsRequest)(nil),                    // 19: BetsRequest
+	(*BetsResponse)(nil),                   // 20: BetsResponse
+	(*FightResultRequest)(nil),             // 21: FightResultRequest
+	(*FightResultResponse)(nil),            // 22: FightResultResponse
+	(*Fight)(nil),                          // 23: Fight
+	(*Event)(nil),                          // 24: Event
+	(*Bet)(nil),                            // 25: Bet
+	(*Fighter)(nil),                        // 26: Fighter
+	(*FighterStats)(nil),                   // 27: FighterStats
+	(*FightersRequest)(nil),                // 28: FightersRequest
+	(*FightersResponse)(nil),               // 29: FightersResponse
+	(*FightersCountResponse)(nil),          // 30: FightersCountResponse
+	(*FightPair)(nil),                      // 31: FightPair
+	(*FightPrediction)(nil),                // 32: FightPrediction
+	(*PredictFightsRequest)(nil),           // 33: PredictFightsRequest
+	(*PredictFightsResponse)(nil),          // 34: PredictFightsResponse
+	(*ImportFightersRequest)(nil),          // 35: ImportFightersRequest
+	(*ImportFightersResponse)(nil),         // 36: ImportFightersResponse
+	(*ReleaseMissingFightersRequest)(nil),  // 37: ReleaseMissingFightersRequest
+	(*ReleaseMissingFightersResponse)(nil), // 38: ReleaseMissingFightersResponse
+	(*empty.Empty)(nil),                    // 39: google.protobuf.Empty
+	(*timestamp.Timestamp)(nil),            // 40: google.protobuf.Timestamp
 }
 var file_fightbettr_proto_depIdxs = []int32{
-	31, // 0: RegisterConfirmResponse.response:type_name -> google.protobuf.Empty
-	32, // 1: AuthenticateResponse.ExpirationTime:type_name -> google.protobuf.Timestamp
-	31, // 2: PasswordResetResponse.response:type_name -> google.protobuf.Empty
-	31, // 3: PasswordRecoveryResponse.response:type_name -> google.protobuf.Empty
+	39, // 0: RegisterConfirmResponse.response:type_name -> google.protobuf.Empty
+	40, // 1: AuthenticateResponse.ExpirationTime:type_name -> google.protobuf.Timestamp
+	39, // 2: PasswordResetResponse.response:type_name -> google.protobuf.Empty
+	39, // 3: PasswordRecoveryResponse.response:type_name -> google.protobuf.Empty
 	12, // 4: ProfileResponse.user:type_name -> User
 	23, // 5: CreateEventRequest.fights:type_name -> Fight
-	31, // 6: GetEventsRequest.response:type_name -> google.protobuf.Empty
+	39, // 6: GetEventsRequest.response:type_name -> google.protobuf.Empty
 	24, // 7: GetEventsResponse.events:type_name -> Event
 	25, // 8: BetsResponse.bets:type_name -> Bet
 	23, // 9: Event.fights:type_name -> Fight
 	27, // 10: Fighter.stats:type_name -> FighterStats
 	26, // 11: FightersResponse.fighters:type_name -> Fighter
-	0,  // 12: AuthService.Register:input_type -> RegisterRequest
-	2,  // 13: AuthService.RegisterConfirm:input_type -> RegisterConfirmRequest
-	4,  // 14: AuthService.Login:input_type -> AuthenticateRequest
-	6,  // 15: AuthService.PasswordReset:input_type -> PasswordResetRequest
-	8,  // 16: AuthService.PasswordRecover:input_type -> PasswordRecoveryRequest
-	10, // 17: AuthService.Profile:input_type -> ProfileRequest
-	13, // 18: EventService.CreateEvent:input_type -> CreateEventRequest
-	15, // 19: EventService.GetEvents:input_type -> GetEventsRequest
-	17, // 20: EventService.CreateBet:input_type -> CreateBetRequest
-	19, // 21: EventService.GetBets:input_type -> BetsRequest
-	21, // 22: EventService.SetResult:input_type -> FightResultRequest
-	28, // 23: FightersService.SearchFightersCount:input_type -> FightersRequest
-	28, // 24: FightersService.SearchFighters:input_type -> FightersRequest
-	1,  // 25: AuthService.Register:output_type -> RegisterResponse
-	3,  // 26: AuthService.RegisterConfirm:output_type -> RegisterConfirmResponse
-	5,  // 27: AuthService.Login:output_type -> AuthenticateResponse
-	7,  // 28: AuthService.PasswordReset:output_type -> PasswordResetResponse
-	9,  // 29: AuthService.PasswordRecover:output_type -> PasswordRecoveryResponse
-	11, // 30: AuthService.Profile:output_type -> ProfileResponse
-	14, // 31: EventService.CreateEvent:output_type -> CreateEventResponse
-	16, // 32: EventService.GetEvents:output_type -> GetEventsResponse
-	18, // 33: EventService.CreateBet:output_type -> CreateBetResponse
-	20, // 34: EventService.GetBets:output_type -> BetsResponse
-	22, // 35: EventService.SetResult:output_type -> FightResultResponse
-	30, // 36: FightersService.SearchFightersCount:output_type -> FightersCountResponse
-	29, // 37: FightersService.SearchFighters:output_type -> FightersResponse
-	25, // [25:38] is the sub-list for method output_type
-	12, // [12:25] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	31, // 12: PredictFightsRequest.fights:type_name -> FightPair
+	32, // 13: PredictFightsResponse.predictions:type_name -> FightPrediction
+	26, // 14: ImportFightersRequest.fighters:type_name -> Fighter
+	0,  // 15: AuthService.Register:input_type -> RegisterRequest
+	2,  // 16: AuthService.RegisterConfirm:input_type -> RegisterConfirmRequest
+	4,  // 17: AuthService.Login:input_type -> AuthenticateRequest
+	6,  // 18: AuthService.PasswordReset:input_type -> PasswordResetRequest
+	8,  // 19: AuthService.PasswordRecover:input_type -> PasswordRecoveryRequest
+	10, // 20: AuthService.Profile:input_type -> ProfileRequest
+	13, // 21: EventService.CreateEvent:input_type -> CreateEventRequest
+	15, // 22: EventService.GetEvents:input_type -> GetEventsRequest
+	17, // 23: EventService.CreateBet:input_type -> CreateBetRequest
+	19, // 24: EventService.GetBets:input_type -> BetsRequest
+	21, // 25: EventService.SetResult:input_type -> FightResultRequest
+	28, // 26: FightersService.SearchFightersCount:input_type -> FightersRequest
+	28, // 27: FightersService.SearchFighters:input_type -> FightersRequest
+	33, // 28: FightersService.PredictFights:input_type -> PredictFightsRequest
+	35, // 29: FightersService.ImportFighters:input_type -> ImportFightersRequest
+	37, // 30: FightersService.ReleaseMissingFighters:input_type -> ReleaseMissingFightersRequest
+	1,  // 31: AuthService.Register:output_type -> RegisterResponse
+	3,  // 32: AuthService.RegisterConfirm:output_type -> RegisterConfirmResponse
+	5,  // 33: AuthService.Login:output_type -> AuthenticateResponse
+	7,  // 34: AuthService.PasswordReset:output_type -> PasswordResetResponse
+	9,  // 35: AuthService.PasswordRecover:output_type -> PasswordRecoveryResponse
+	11, // 36: AuthService.Profile:output_type -> ProfileResponse
+	14, // 37: EventService.CreateEvent:output_type -> CreateEventResponse
+	16, // 38: EventService.GetEvents:output_type -> GetEventsResponse
+	18, // 39: EventService.CreateBet:output_type -> CreateBetResponse
+	20, // 40: EventService.GetBets:output_type -> BetsResponse
+	22, // 41: EventService.SetResult:output_type -> FightResultResponse
+	30, // 42: FightersService.SearchFightersCount:output_type -> FightersCountResponse
+	29, // 43: FightersService.SearchFighters:output_type -> FightersResponse
+	34, // 44: FightersService.PredictFights:output_type -> PredictFightsResponse
+	36, // 45: FightersService.ImportFighters:output_type -> ImportFightersResponse
+	38, // 46: FightersService.ReleaseMissingFighters:output_type -> ReleaseMissingFightersResponse
+	31, // [31:47] is the sub-list for method output_type
+	15, // [15:31] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_fightbettr_proto_init() }
@@ -2963,6 +3501,102 @@ func file_fightbettr_proto_init() {
 				return nil
 			}
 		}
+		file_fightbettr_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FightPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FightPrediction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PredictFightsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PredictFightsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportFightersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImportFightersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseMissingFightersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_fightbettr_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReleaseMissingFightersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -2970,7 +3604,7 @@ func file_fightbettr_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_fightbettr_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   3,
 		},
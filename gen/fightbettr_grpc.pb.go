@@ -532,8 +532,11 @@ var EventService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	FightersService_SearchFightersCount_FullMethodName = "/FightersService/SearchFightersCount"
-	FightersService_SearchFighters_FullMethodName      = "/FightersService/SearchFighters"
+	FightersService_SearchFightersCount_FullMethodName    = "/FightersService/SearchFightersCount"
+	FightersService_SearchFighters_FullMethodName         = "/FightersService/SearchFighters"
+	FightersService_PredictFights_FullMethodName          = "/FightersService/PredictFights"
+	FightersService_ImportFighters_FullMethodName         = "/FightersService/ImportFighters"
+	FightersService_ReleaseMissingFighters_FullMethodName = "/FightersService/ReleaseMissingFighters"
 )
 
 // FightersServiceClient is the client API for FightersService service.
@@ -542,6 +545,9 @@ const (
 type FightersServiceClient interface {
 	SearchFightersCount(ctx context.Context, in *FightersRequest, opts ...grpc.CallOption) (*FightersCountResponse, error)
 	SearchFighters(ctx context.Context, in *FightersRequest, opts ...grpc.CallOption) (*FightersResponse, error)
+	PredictFights(ctx context.Context, in *PredictFightsRequest, opts ...grpc.CallOption) (*PredictFightsResponse, error)
+	ImportFighters(ctx context.Context, in *ImportFightersRequest, opts ...grpc.CallOption) (*ImportFightersResponse, error)
+	ReleaseMissingFighters(ctx context.Context, in *ReleaseMissingFightersRequest, opts ...grpc.CallOption) (*ReleaseMissingFightersResponse, error)
 }
 
 type fightersServiceClient struct {
@@ -570,12 +576,42 @@ func (c *fightersServiceClient) SearchFighters(ctx context.Context, in *Fighters
 	return out, nil
 }
 
+func (c *fightersServiceClient) PredictFights(ctx context.Context, in *PredictFightsRequest, opts ...grpc.CallOption) (*PredictFightsResponse, error) {
+	out := new(PredictFightsResponse)
+	err := c.cc.Invoke(ctx, FightersService_PredictFights_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fightersServiceClient) ImportFighters(ctx context.Context, in *ImportFightersRequest, opts ...grpc.CallOption) (*ImportFightersResponse, error) {
+	out := new(ImportFightersResponse)
+	err := c.cc.Invoke(ctx, FightersService_ImportFighters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fightersServiceClient) ReleaseMissingFighters(ctx context.Context, in *ReleaseMissingFightersRequest, opts ...grpc.CallOption) (*ReleaseMissingFightersResponse, error) {
+	out := new(ReleaseMissingFightersResponse)
+	err := c.cc.Invoke(ctx, FightersService_ReleaseMissingFighters_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FightersServiceServer is the server API for FightersService service.
 // All implementations must embed UnimplementedFightersServiceServer
 // for forward compatibility
 type FightersServiceServer interface {
 	SearchFightersCount(context.Context, *FightersRequest) (*FightersCountResponse, error)
 	SearchFighters(context.Context, *FightersRequest) (*FightersResponse, error)
+	PredictFights(context.Context, *PredictFightsRequest) (*PredictFightsResponse, error)
+	ImportFighters(context.Context, *ImportFightersRequest) (*ImportFightersResponse, error)
+	ReleaseMissingFighters(context.Context, *ReleaseMissingFightersRequest) (*ReleaseMissingFightersResponse, error)
 	mustEmbedUnimplementedFightersServiceServer()
 }
 
@@ -589,6 +625,15 @@ func (UnimplementedFightersServiceServer) SearchFightersCount(context.Context, *
 func (UnimplementedFightersServiceServer) SearchFighters(context.Context, *FightersRequest) (*FightersResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchFighters not implemented")
 }
+func (UnimplementedFightersServiceServer) PredictFights(context.Context, *PredictFightsRequest) (*PredictFightsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PredictFights not implemented")
+}
+func (UnimplementedFightersServiceServer) ImportFighters(context.Context, *ImportFightersRequest) (*ImportFightersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportFighters not implemented")
+}
+func (UnimplementedFightersServiceServer) ReleaseMissingFighters(context.Context, *ReleaseMissingFightersRequest) (*ReleaseMissingFightersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseMissingFighters not implemented")
+}
 func (UnimplementedFightersServiceServer) mustEmbedUnimplementedFightersServiceServer() {}
 
 // UnsafeFightersServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -638,6 +683,60 @@ func _FightersService_SearchFighters_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FightersService_PredictFights_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictFightsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FightersServiceServer).PredictFights(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FightersService_PredictFights_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FightersServiceServer).PredictFights(ctx, req.(*PredictFightsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FightersService_ImportFighters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportFightersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FightersServiceServer).ImportFighters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FightersService_ImportFighters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FightersServiceServer).ImportFighters(ctx, req.(*ImportFightersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FightersService_ReleaseMissingFighters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseMissingFightersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FightersServiceServer).ReleaseMissingFighters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FightersService_ReleaseMissingFighters_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FightersServiceServer).ReleaseMissingFighters(ctx, req.(*ReleaseMissingFightersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FightersService_ServiceDesc is the grpc.ServiceDesc for FightersService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -653,6 +752,18 @@ var FightersService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchFighters",
 			Handler:    _FightersService_SearchFighters_Handler,
 		},
+		{
+			MethodName: "PredictFights",
+			Handler:    _FightersService_PredictFights_Handler,
+		},
+		{
+			MethodName: "ImportFighters",
+			Handler:    _FightersService_ImportFighters_Handler,
+		},
+		{
+			MethodName: "ReleaseMissingFighters",
+			Handler:    _FightersService_ReleaseMissingFighters_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "fightbettr.proto",
@@ -2,16 +2,31 @@ package grpcutil
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/rand"
+	"sync"
+	"time"
 
+	"fightbettr.com/pkg/correlation"
 	"fightbettr.com/pkg/discovery"
+	"fightbettr.com/pkg/grpcauth"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
+// roundRobinConfig is the service config that balances the calls over every address of a service.
+const roundRobinConfig = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+
+// ErrClosed is returned by ConnManager.Conn after the manager is closed.
+var ErrClosed = errors.New("grpcutil: connection manager is closed")
+
 // ServiceConnection attempts to select a random service instance and returns a gRPC connection to it.
-func ServiceConnection(ctx context.Context, serviceName string, registry discovery.Registry) (*grpc.ClientConn, error) {
+// It suits one-shot commands; long-running services share the connections of a ConnManager instead.
+// The connection is insecure unless the options set the transport credentials, e.g. those of tlsx.DialOptions.
+func ServiceConnection(ctx context.Context, serviceName string, registry discovery.Registry, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 	addrs, err := registry.ServiceAddresses(ctx, serviceName)
 	if err != nil {
 		return nil, err
@@ -19,7 +34,116 @@ func ServiceConnection(ctx context.Context, serviceName string, registry discove
 
 	return grpc.Dial(
 		addrs[rand.Intn(len(addrs))],
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+		dialOptions(serviceName, opts)...,
 	)
 }
+
+// dialOptions returns the options of the connections to the service: the default insecure credentials,
+// the tracing, correlation and user token interceptors and the given options, which override the defaults.
+// The TLS server name is the service name, whatever instance address the connection is made to.
+func dialOptions(serviceName string, opts []grpc.DialOption) []grpc.DialOption {
+	return append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithAuthority(serviceName),
+		grpc.WithChainUnaryInterceptor(
+			otelgrpc.UnaryClientInterceptor(),
+			correlation.UnaryClientInterceptor(),
+			grpcauth.UnaryClientInterceptor(),
+		),
+	}, opts...)
+}
+
+// ConnManager keeps a long-lived gRPC connection per service. The addresses of every service are
+// resolved through the registry and refreshed in the background, and the calls are balanced
+// round-robin over the instances of the service, so a call pays neither a registry lookup nor a handshake.
+// The connections are safe for concurrent use and must not be closed by the callers.
+type ConnManager struct {
+	builder *resolverBuilder
+	opts    []grpc.DialOption
+
+	mu     sync.Mutex
+	conns  map[string]*grpc.ClientConn
+	closed bool
+}
+
+// NewConnManager creates a connection manager that refreshes the addresses of the services
+// from the registry every refreshInterval, or every DefaultRefreshInterval if it is not positive.
+// The connections are made with the given options, e.g. the TLS credentials of tlsx.DialOptions.
+func NewConnManager(registry discovery.Registry, refreshInterval time.Duration, opts ...grpc.DialOption) *ConnManager {
+	return &ConnManager{
+		builder: newResolverBuilder(registry, refreshInterval),
+		opts:    opts,
+		conns:   make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Conn returns the connection to the service with the given name, creating it on the first call.
+// The connection is established lazily, so Conn does not fail when no instance is registered yet;
+// calls made while the service has no instances fail with the Unavailable code.
+func (m *ConnManager) Conn(serviceName string) (*grpc.ClientConn, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	if conn, ok := m.conns[serviceName]; ok {
+		return conn, nil
+	}
+
+	opts := append([]grpc.DialOption{
+		grpc.WithResolvers(m.builder),
+		grpc.WithDefaultServiceConfig(roundRobinConfig),
+	}, dialOptions(serviceName, m.opts)...)
+
+	conn, err := grpc.Dial(Scheme+":///"+serviceName, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	m.conns[serviceName] = conn
+
+	return conn, nil
+}
+
+// HealthCheck returns a check of the service with the given name. The check asks an instance of the service
+// for its overall status with the grpc.health.v1 protocol over the shared connection, and fails unless
+// the instance is serving.
+func (m *ConnManager) HealthCheck(serviceName string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		conn, err := m.Conn(serviceName)
+		if err != nil {
+			return err
+		}
+
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		if err != nil {
+			return err
+		}
+
+		if resp.Status != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", serviceName, resp.Status)
+		}
+
+		return nil
+	}
+}
+
+// Close closes every connection of the manager.
+func (m *ConnManager) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+
+	var errs []error
+	for name, conn := range m.conns {
+		if err := conn.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(m.conns, name)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,250 @@
+package grpcutil
+
+import (
+	"context"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fightbettr.com/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testService = "test-service"
+
+// fakeRegistry is a registry whose addresses are set by the test.
+type fakeRegistry struct {
+	mu      sync.Mutex
+	addrs   []string
+	queries atomic.Int64
+}
+
+func (r *fakeRegistry) Register(context.Context, string, string, string) error { return nil }
+
+func (r *fakeRegistry) Deregister(context.Context, string, string) error { return nil }
+
+func (r *fakeRegistry) ReportHealthyState(string, string) error { return nil }
+
+func (r *fakeRegistry) ServiceAddresses(ctx context.Context, serviceName string) ([]string, error) {
+	r.queries.Add(1)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if serviceName != testService || len(r.addrs) == 0 {
+		return nil, discovery.ErrNotFound
+	}
+
+	return append([]string(nil), r.addrs...), nil
+}
+
+func (r *fakeRegistry) set(addrs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.addrs = addrs
+}
+
+// testServer is a gRPC server that answers every method and counts the calls.
+type testServer struct {
+	addr  string
+	calls atomic.Int64
+}
+
+func startServer(t *testing.T) *testServer {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &testServer{addr: lis.Addr().String()}
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		s.calls.Add(1)
+
+		var req emptypb.Empty
+		if err := stream.RecvMsg(&req); err != nil {
+			return err
+		}
+
+		return stream.SendMsg(&emptypb.Empty{})
+	}))
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return s
+}
+
+func ping(conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	return conn.Invoke(ctx, "/test.Test/Ping", &emptypb.Empty{}, &emptypb.Empty{})
+}
+
+func TestConnManagerRoundRobin(t *testing.T) {
+	a, b := startServer(t), startServer(t)
+
+	registry := &fakeRegistry{}
+	registry.set(a.addr, b.addr)
+
+	m := NewConnManager(registry, 10*time.Millisecond)
+	defer m.Close()
+
+	conn, err := m.Conn(testService)
+	require.NoError(t, err)
+
+	again, err := m.Conn(testService)
+	require.NoError(t, err)
+	assert.Same(t, conn, again, "connection is reused")
+
+	// Round-robin starts once both instances are connected.
+	require.Eventually(t, func() bool {
+		require.NoError(t, ping(conn))
+		return a.calls.Load() > 0 && b.calls.Load() > 0
+	}, 5*time.Second, time.Millisecond)
+
+	a.calls.Store(0)
+	b.calls.Store(0)
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, ping(conn))
+	}
+
+	assert.Equal(t, int64(5), a.calls.Load())
+	assert.Equal(t, int64(5), b.calls.Load())
+}
+
+func TestConnManagerWatch(t *testing.T) {
+	a, b := startServer(t), startServer(t)
+
+	registry := &fakeRegistry{}
+	registry.set(a.addr)
+
+	m := NewConnManager(registry, 10*time.Millisecond)
+	defer m.Close()
+
+	conn, err := m.Conn(testService)
+	require.NoError(t, err)
+	require.NoError(t, ping(conn))
+
+	// A new instance gets calls once it is registered.
+	registry.set(a.addr, b.addr)
+	require.Eventually(t, func() bool {
+		require.NoError(t, ping(conn))
+		return b.calls.Load() > 0
+	}, 5*time.Second, time.Millisecond)
+
+	// A deregistered instance gets no more calls.
+	registry.set(b.addr)
+	require.Eventually(t, func() bool {
+		before := a.calls.Load()
+		for i := 0; i < 4; i++ {
+			require.NoError(t, ping(conn))
+		}
+		return a.calls.Load() == before
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Calls fail fast while the service has no instances.
+	registry.set()
+	require.Eventually(t, func() bool {
+		return status.Code(ping(conn)) == codes.Unavailable
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConnManagerNoInstances(t *testing.T) {
+	registry := &fakeRegistry{}
+
+	m := NewConnManager(registry, 10*time.Millisecond)
+	defer m.Close()
+
+	conn, err := m.Conn(testService)
+	require.NoError(t, err)
+
+	// Calls fail fast instead of waiting for the deadline.
+	start := time.Now()
+	assert.Equal(t, codes.Unavailable, status.Code(ping(conn)))
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	// The registry is not queried in a loop.
+	time.Sleep(100 * time.Millisecond)
+	assert.Less(t, registry.queries.Load(), int64(30))
+
+	a := startServer(t)
+	registry.set(a.addr)
+	require.Eventually(t, func() bool { return ping(conn) == nil }, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestConnManagerClose(t *testing.T) {
+	registry := &fakeRegistry{}
+	m := NewConnManager(registry, time.Hour)
+
+	conn, err := m.Conn(testService)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Close())
+
+	_, err = m.Conn(testService)
+	assert.ErrorIs(t, err, ErrClosed)
+
+	assert.Error(t, ping(conn))
+}
+
+func TestConnManagerHealthCheck(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	hs := health.NewServer()
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	registry := &fakeRegistry{}
+	registry.set(lis.Addr().String())
+
+	m := NewConnManager(registry, 10*time.Millisecond)
+	defer m.Close()
+
+	check := m.HealthCheck(testService)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	assert.NoError(t, check(ctx))
+
+	hs.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.EqualError(t, check(ctx), "test-service is NOT_SERVING")
+
+	registry.set()
+	require.Eventually(t, func() bool {
+		return status.Code(check(ctx)) == codes.Unavailable
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestEqualAddrs(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []string
+		want bool
+	}{
+		{name: "equal", a: []string{"a:1", "b:1"}, b: []string{"a:1", "b:1"}, want: true},
+		{name: "both empty", want: true},
+		{name: "different length", a: []string{"a:1"}, b: []string{"a:1", "b:1"}},
+		{name: "different address", a: []string{"a:1", "b:1"}, b: []string{"a:1", "c:1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, equalAddrs(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package grpcutil
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"fightbettr.com/pkg/discovery"
+	"google.golang.org/grpc/resolver"
+)
+
+// Scheme is the scheme of the gRPC targets resolved through the service registry, e.g. discovery:///auth-service.
+const Scheme = "discovery"
+
+// DefaultRefreshInterval is the interval at which the addresses of a service are refreshed from the registry.
+const DefaultRefreshInterval = 5 * time.Second
+
+// resolveTimeout limits a single query to the registry.
+const resolveTimeout = 5 * time.Second
+
+// resolverBuilder builds resolvers that watch the addresses of a service in the registry.
+type resolverBuilder struct {
+	registry discovery.Registry
+	interval time.Duration
+}
+
+// newResolverBuilder creates a builder of resolvers that query the registry every interval.
+func newResolverBuilder(registry discovery.Registry, interval time.Duration) *resolverBuilder {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+
+	return &resolverBuilder{registry: registry, interval: interval}
+}
+
+// Build starts watching the service named by the endpoint of the target.
+func (b *resolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, _ resolver.BuildOptions) (resolver.Resolver, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	r := &discoveryResolver{
+		registry:    b.registry,
+		serviceName: target.Endpoint(),
+		interval:    b.interval,
+		cc:          cc,
+		resolveNow:  make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	r.wg.Add(1)
+	go r.watch()
+
+	return r, nil
+}
+
+// Scheme returns the scheme of the targets handled by the builder.
+func (b *resolverBuilder) Scheme() string {
+	return Scheme
+}
+
+// discoveryResolver polls the registry for the addresses of a service and updates the connection
+// whenever instances come and go. When the service has no instances the connection goes into the
+// transient failure state, so calls fail fast instead of waiting for an instance. Other errors of the
+// registry are reported to the connection, which keeps using the last known addresses.
+type discoveryResolver struct {
+	registry    discovery.Registry
+	serviceName string
+	interval    time.Duration
+	cc          resolver.ClientConn
+
+	resolveNow chan struct{}
+	// addrs is the last address set passed to the connection, resolved reports whether there is one.
+	addrs    []string
+	resolved bool
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// watch resolves the addresses right away and then every interval or when the connection asks for it.
+func (r *discoveryResolver) watch() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.resolve()
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.resolveNow:
+		}
+	}
+}
+
+// resolve queries the registry and passes the addresses to the connection if they have changed.
+func (r *discoveryResolver) resolve() {
+	ctx, cancel := context.WithTimeout(r.ctx, resolveTimeout)
+	defer cancel()
+
+	addrs, err := r.registry.ServiceAddresses(ctx, r.serviceName)
+	if errors.Is(err, discovery.ErrNotFound) {
+		addrs = nil
+	} else if err != nil {
+		if r.ctx.Err() == nil {
+			r.cc.ReportError(err)
+		}
+		return
+	}
+
+	addrs = append([]string(nil), addrs...)
+	sort.Strings(addrs)
+
+	if r.resolved && equalAddrs(addrs, r.addrs) {
+		return
+	}
+
+	state := resolver.State{Addresses: make([]resolver.Address, len(addrs))}
+	for i, addr := range addrs {
+		state.Addresses[i] = resolver.Address{Addr: addr}
+	}
+
+	// The balancer rejects an empty set with an error and fails the calls, which is what is wanted
+	// for a service without instances, so the error is not retried.
+	_ = r.cc.UpdateState(state)
+
+	r.addrs = addrs
+	r.resolved = true
+}
+
+// ResolveNow asks for an immediate refresh, e.g. after a connection to an instance is lost.
+func (r *discoveryResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.resolveNow <- struct{}{}:
+	default:
+	}
+}
+
+// Close stops watching the registry.
+func (r *discoveryResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+// equalAddrs reports whether the sorted address sets are equal.
+func equalAddrs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
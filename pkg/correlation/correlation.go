@@ -0,0 +1,143 @@
+package correlation
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	logs "fightbettr.com/pkg/logger"
+	"github.com/gofrs/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Header is the HTTP header of the request ID, accepted from the clients and returned in the responses.
+const Header = "X-Request-ID"
+
+// Keys of the gRPC metadata the request and user IDs are propagated in.
+const (
+	RequestIDKey = "x-request-id"
+	UserIDKey    = "x-user-id"
+)
+
+// maxRequestIDLength limits the length of the request IDs accepted from the clients.
+const maxRequestIDLength = 128
+
+// contextKey is the type of the context keys of this package.
+type contextKey int
+
+const (
+	requestIDContextKey contextKey = iota
+	userIDContextKey
+)
+
+// NewRequestID returns a new random request ID.
+func NewRequestID() string {
+	return uuid.Must(uuid.NewV4()).String()
+}
+
+// ValidRequestID reports whether the request ID received from a client may be used as is:
+// it must be non-empty, at most 128 characters long and consist of letters, digits and -_.: only,
+// so it is safe to log and forward.
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithRequestID returns a copy of the context with the request ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// RequestID returns the request ID of the context, or an empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey).(string)
+	return id
+}
+
+// WithUserID returns a copy of the context with the ID of the user making the request.
+func WithUserID(ctx context.Context, id int32) context.Context {
+	return context.WithValue(ctx, userIDContextKey, id)
+}
+
+// UserID returns the ID of the user making the request of the context, if there is one.
+func UserID(ctx context.Context) (int32, bool) {
+	id, ok := ctx.Value(userIDContextKey).(int32)
+	return id, ok
+}
+
+// UnaryClientInterceptor propagates the request and user IDs of the context in the outgoing metadata of the calls.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := RequestID(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, RequestIDKey, id)
+		}
+
+		if id, ok := UserID(ctx); ok {
+			ctx = metadata.AppendToOutgoingContext(ctx, UserIDKey, strconv.FormatInt(int64(id), 10))
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor takes the request and user IDs of the calls from the incoming metadata, generating
+// a request ID if there is none, and adds them with the called method as the route to the log fields of the context.
+// Every call is written to the access log with its status code, latency and response size.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		requestID := first(md, RequestIDKey)
+		if !ValidRequestID(requestID) {
+			requestID = NewRequestID()
+		}
+
+		ctx = WithRequestID(ctx, requestID)
+		ctx = logs.With(ctx, "request_id", requestID, "route", info.FullMethod)
+
+		if uid, err := strconv.ParseInt(first(md, UserIDKey), 10, 32); err == nil {
+			ctx = WithUserID(ctx, int32(uid))
+			ctx = logs.With(ctx, "user_id", int32(uid))
+		}
+
+		resp, err := handler(ctx, req)
+
+		var size int
+		if msg, ok := resp.(proto.Message); ok && err == nil {
+			size = proto.Size(msg)
+		}
+
+		logs.Ctx(ctx).Infow("access",
+			"code", status.Code(err).String(),
+			"latency", time.Since(start).Seconds(),
+			"size", size,
+		)
+
+		return resp, err
+	}
+}
+
+// first returns the first value of the metadata key, or an empty string.
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
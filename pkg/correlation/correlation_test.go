@@ -0,0 +1,147 @@
+package correlation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{id: NewRequestID(), want: true},
+		{id: "req-1_a.b:c", want: true},
+		{id: "", want: false},
+		{id: strings.Repeat("a", 129), want: false},
+		{id: "id with spaces", want: false},
+		{id: "id\nwith\nnewlines", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidRequestID(tt.id))
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want metadata.MD
+	}{
+		{
+			name: "no ids",
+			ctx:  context.Background(),
+			want: nil,
+		},
+		{
+			name: "request id",
+			ctx:  WithRequestID(context.Background(), "req-1"),
+			want: metadata.Pairs(RequestIDKey, "req-1"),
+		},
+		{
+			name: "request and user ids",
+			ctx:  WithUserID(WithRequestID(context.Background(), "req-1"), 42),
+			want: metadata.Pairs(RequestIDKey, "req-1", UserIDKey, "42"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				got, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+
+			require.NoError(t, UnaryClientInterceptor()(tt.ctx, "/AuthService/Login", nil, nil, nil, invoker))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	core, observed := observer.New(zap.InfoLevel)
+	defer zap.ReplaceGlobals(zap.New(core))()
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/EventService/CreateBet"}
+
+	tests := []struct {
+		name          string
+		md            metadata.MD
+		wantRequestID string
+		wantUserID    int32
+		wantUser      bool
+	}{
+		{
+			name: "generated request id",
+		},
+		{
+			name:          "propagated ids",
+			md:            metadata.Pairs(RequestIDKey, "req-1", UserIDKey, "42"),
+			wantRequestID: "req-1",
+			wantUserID:    42,
+			wantUser:      true,
+		},
+		{
+			name: "invalid ids are ignored",
+			md:   metadata.Pairs(RequestIDKey, "bad id", UserIDKey, "admin"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observed.TakeAll()
+
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			var requestID string
+			var userID int32
+			var hasUser bool
+			handler := func(ctx context.Context, req any) (any, error) {
+				requestID = RequestID(ctx)
+				userID, hasUser = UserID(ctx)
+				return wrapperspb.Int32(7), nil
+			}
+
+			_, err := UnaryServerInterceptor()(ctx, nil, info, handler)
+			require.NoError(t, err)
+
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, requestID)
+			} else {
+				assert.True(t, ValidRequestID(requestID))
+			}
+			assert.Equal(t, tt.wantUserID, userID)
+			assert.Equal(t, tt.wantUser, hasUser)
+
+			entries := observed.FilterMessage("access").All()
+			require.Len(t, entries, 1)
+
+			fields := entries[0].ContextMap()
+			assert.Equal(t, requestID, fields["request_id"])
+			assert.Equal(t, info.FullMethod, fields["route"])
+			assert.Equal(t, "OK", fields["code"])
+			assert.EqualValues(t, 2, fields["size"])
+			if tt.wantUser {
+				assert.EqualValues(t, tt.wantUserID, fields["user_id"])
+			} else {
+				assert.NotContains(t, fields, "user_id")
+			}
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package cors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Header names of the CORS requests and responses.
+const (
+	HeaderOrigin           = "Origin"
+	HeaderRequestMethod    = "Access-Control-Request-Method"
+	HeaderRequestHeaders   = "Access-Control-Request-Headers"
+	HeaderAllowOrigin      = "Access-Control-Allow-Origin"
+	HeaderAllowCredentials = "Access-Control-Allow-Credentials"
+	HeaderAllowMethods     = "Access-Control-Allow-Methods"
+	HeaderAllowHeaders     = "Access-Control-Allow-Headers"
+	HeaderExposeHeaders    = "Access-Control-Expose-Headers"
+	HeaderMaxAge           = "Access-Control-Max-Age"
+	HeaderVary             = "Vary"
+)
+
+// AnyOrigin allows every origin. It cannot be combined with credentials, since it would let any website
+// make authenticated requests.
+const AnyOrigin = "*"
+
+// ErrAnyOriginWithCredentials is returned when every origin is allowed to send credentials.
+var ErrAnyOriginWithCredentials = errors.New("cors: any origin (*) cannot be allowed with credentials")
+
+// Rule is the methods and request headers allowed on the paths with a prefix.
+type Rule struct {
+	// PathPrefix is the prefix of the paths of the rule; the rule of the longest matching prefix applies.
+	PathPrefix string
+	Methods    []string
+	Headers    []string
+}
+
+// Config holds the CORS settings of a server.
+type Config struct {
+	// AllowedOrigins are the origins allowed to make cross-origin requests, as scheme://host[:port].
+	// The host may start with a *. wildcard that matches its subdomains, e.g. https://*.fightbettr.com.
+	AllowedOrigins []string
+	// AllowedMethods and AllowedHeaders are allowed on the paths without a rule.
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders are the response headers the clients may read.
+	ExposedHeaders []string
+	// AllowCredentials allows the requests with cookies.
+	AllowCredentials bool
+	// MaxAge is the time the clients may cache the result of a preflight request.
+	MaxAge time.Duration
+	// Rules are the methods and headers allowed on some paths.
+	Rules []Rule
+}
+
+// ViperConfig returns the CORS config based on the cors values from viper. The rules are read from
+// cors.routes.<name>.path_prefix, cors.routes.<name>.methods and cors.routes.<name>.headers.
+// Without cors.allowed_origins, the origin of the web client given by web.host and web.port is allowed.
+func ViperConfig() Config {
+	origins := viper.GetStringSlice("cors.allowed_origins")
+	if len(origins) == 0 && viper.GetString("web.host") != "" {
+		origins = []string{WebOrigin(viper.GetString("web.host"), viper.GetString("web.port"))}
+	}
+
+	cfg := Config{
+		AllowedOrigins:   origins,
+		AllowedMethods:   viper.GetStringSlice("cors.allowed_methods"),
+		AllowedHeaders:   viper.GetStringSlice("cors.allowed_headers"),
+		ExposedHeaders:   viper.GetStringSlice("cors.exposed_headers"),
+		AllowCredentials: viper.GetBool("cors.allow_credentials"),
+		MaxAge:           viper.GetDuration("cors.max_age"),
+	}
+
+	for name := range viper.GetStringMap("cors.routes") {
+		key := "cors.routes." + name
+		cfg.Rules = append(cfg.Rules, Rule{
+			PathPrefix: viper.GetString(key + ".path_prefix"),
+			Methods:    viper.GetStringSlice(key + ".methods"),
+			Headers:    viper.GetStringSlice(key + ".headers"),
+		})
+	}
+
+	return cfg
+}
+
+// WebOrigin returns the origin of the web client served at the host, given as scheme://host like the
+// web.host option of the services, and the port. The port is left out if it is empty.
+func WebOrigin(host, port string) string {
+	host = strings.TrimSuffix(strings.TrimSpace(host), "/")
+	if port == "" {
+		return host
+	}
+
+	return host + ":" + port
+}
+
+// origin is an allowed origin, whose host may match the subdomains of a domain.
+type origin struct {
+	scheme string
+	host   string
+	// wildcard makes host the parent domain of the allowed hosts.
+	wildcard bool
+}
+
+// parseOrigin parses an allowed origin of the config.
+func parseOrigin(s string) (origin, error) {
+	scheme, host, ok := strings.Cut(strings.ToLower(strings.TrimSpace(s)), "://")
+	if !ok || scheme == "" || host == "" || strings.ContainsAny(host, "/?#") {
+		return origin{}, fmt.Errorf("cors: invalid origin %q, want scheme://host[:port]", s)
+	}
+
+	if rest, ok := strings.CutPrefix(host, "*."); ok {
+		if rest == "" || strings.Contains(rest, "*") {
+			return origin{}, fmt.Errorf("cors: invalid wildcard origin %q", s)
+		}
+
+		return origin{scheme: scheme, host: rest, wildcard: true}, nil
+	}
+
+	if strings.Contains(host, "*") {
+		return origin{}, fmt.Errorf("cors: wildcard must be the first label of origin %q", s)
+	}
+
+	return origin{scheme: scheme, host: host}, nil
+}
+
+// matches reports whether the scheme and host, with the port, of a request origin are allowed.
+func (o origin) matches(scheme, host string) bool {
+	if scheme != o.scheme {
+		return false
+	}
+
+	if !o.wildcard {
+		return host == o.host
+	}
+
+	return strings.HasSuffix(host, "."+o.host)
+}
+
+// set is a set of methods or headers, with their list for the responses.
+type set struct {
+	names map[string]bool
+	list  string
+}
+
+// newSet creates a set of the methods, or the headers if canonical is set.
+func newSet(values []string, canonical bool) set {
+	s := set{names: make(map[string]bool, len(values))}
+
+	var list []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+
+		if canonical {
+			v = http.CanonicalHeaderKey(v)
+		} else {
+			v = strings.ToUpper(v)
+		}
+
+		if !s.names[v] {
+			s.names[v] = true
+			list = append(list, v)
+		}
+	}
+
+	s.list = strings.Join(list, ",")
+
+	return s
+}
+
+// rule is a compiled Rule.
+type rule struct {
+	prefix  string
+	methods set
+	headers set
+}
+
+// Policy is the compiled CORS config of a server.
+type Policy struct {
+	anyOrigin   bool
+	origins     []origin
+	credentials bool
+	exposed     string
+	maxAge      string
+	// rules are sorted by prefix length, the longest first; the last one has the empty prefix.
+	rules []rule
+}
+
+// New compiles the config into a policy.
+func New(cfg Config) (*Policy, error) {
+	p := &Policy{
+		credentials: cfg.AllowCredentials,
+		exposed:     newSet(cfg.ExposedHeaders, true).list,
+	}
+
+	for _, s := range cfg.AllowedOrigins {
+		if strings.TrimSpace(s) == AnyOrigin {
+			p.anyOrigin = true
+			continue
+		}
+
+		o, err := parseOrigin(s)
+		if err != nil {
+			return nil, err
+		}
+
+		p.origins = append(p.origins, o)
+	}
+
+	if p.anyOrigin && p.credentials {
+		return nil, ErrAnyOriginWithCredentials
+	}
+
+	if cfg.MaxAge > 0 {
+		p.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+
+	for _, r := range cfg.Rules {
+		if !strings.HasPrefix(r.PathPrefix, "/") {
+			return nil, fmt.Errorf("cors: invalid path prefix %q of a rule", r.PathPrefix)
+		}
+
+		p.rules = append(p.rules, rule{
+			prefix:  r.PathPrefix,
+			methods: newSet(r.Methods, false),
+			headers: newSet(r.Headers, true),
+		})
+	}
+
+	sort.SliceStable(p.rules, func(i, j int) bool { return len(p.rules[i].prefix) > len(p.rules[j].prefix) })
+
+	p.rules = append(p.rules, rule{
+		methods: newSet(cfg.AllowedMethods, false),
+		headers: newSet(cfg.AllowedHeaders, true),
+	})
+
+	return p, nil
+}
+
+// AllowsOrigin reports whether the origin is allowed to make cross-origin requests.
+func (p *Policy) AllowsOrigin(o string) bool {
+	if p.anyOrigin {
+		return true
+	}
+
+	u, err := url.Parse(strings.ToLower(o))
+	if err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" || u.User != nil {
+		return false
+	}
+
+	for _, allowed := range p.origins {
+		if allowed.matches(u.Scheme, u.Host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// rule returns the rule of the path.
+func (p *Policy) rule(path string) rule {
+	for _, r := range p.rules {
+		if strings.HasPrefix(path, r.prefix) {
+			return r
+		}
+	}
+
+	return p.rules[len(p.rules)-1]
+}
+
+// Handler returns the middleware that applies the policy to the requests of next.
+//
+// A preflight request, an OPTIONS request with an Origin and an Access-Control-Request-Method header,
+// is answered with 204 No Content and the allowed methods and headers of its path if its origin, method
+// and headers are allowed, and with 403 Forbidden otherwise. It is not passed to next.
+//
+// The other requests are passed to next. The requests of an allowed origin get the
+// Access-Control-Allow-Origin header, and the exposed headers; the requests of the other origins get
+// no CORS headers, so the browsers do not let the pages of those origins read the responses.
+func (p *Policy) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		o := r.Header.Get(HeaderOrigin)
+		if o == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Add(HeaderVary, HeaderOrigin)
+
+		if r.Method == http.MethodOptions && r.Header.Get(HeaderRequestMethod) != "" {
+			p.preflight(w, r, o)
+			return
+		}
+
+		if p.AllowsOrigin(o) {
+			p.setOrigin(h, o)
+			if p.exposed != "" {
+				h.Set(HeaderExposeHeaders, p.exposed)
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// preflight answers a preflight request.
+func (p *Policy) preflight(w http.ResponseWriter, r *http.Request, o string) {
+	h := w.Header()
+	h.Add(HeaderVary, HeaderRequestMethod)
+	h.Add(HeaderVary, HeaderRequestHeaders)
+
+	rule := p.rule(r.URL.Path)
+
+	if !p.AllowsOrigin(o) || !rule.methods.names[strings.ToUpper(r.Header.Get(HeaderRequestMethod))] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	for _, header := range strings.Split(r.Header.Get(HeaderRequestHeaders), ",") {
+		header = strings.TrimSpace(header)
+		if header != "" && !rule.headers.names[http.CanonicalHeaderKey(header)] {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+	}
+
+	p.setOrigin(h, o)
+	h.Set(HeaderAllowMethods, rule.methods.list)
+	if rule.headers.list != "" {
+		h.Set(HeaderAllowHeaders, rule.headers.list)
+	}
+	if p.maxAge != "" {
+		h.Set(HeaderMaxAge, p.maxAge)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setOrigin sets the allowed origin headers of the response to a request of the allowed origin.
+func (p *Policy) setOrigin(h http.Header, o string) {
+	if p.anyOrigin {
+		h.Set(HeaderAllowOrigin, AnyOrigin)
+		return
+	}
+
+	h.Set(HeaderAllowOrigin, o)
+	if p.credentials {
+		h.Set(HeaderAllowCredentials, "true")
+	}
+}
@@ -0,0 +1,295 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	AllowedOrigins:   []string{"https://fightbettr.com", "https://*.fightbettr.com", "http://localhost:3000"},
+	AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+	AllowedHeaders:   []string{"Content-Type", "X-Request-ID"},
+	ExposedHeaders:   []string{"X-Request-ID", "Retry-After"},
+	AllowCredentials: true,
+	MaxAge:           10 * time.Minute,
+	Rules: []Rule{
+		{PathPrefix: "/media", Methods: []string{http.MethodGet}},
+		{PathPrefix: "/media/upload", Methods: []string{http.MethodPut}, Headers: []string{"content-type"}},
+	},
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "config", cfg: testConfig},
+		{name: "any origin", cfg: Config{AllowedOrigins: []string{AnyOrigin}}},
+		{name: "any origin with credentials", cfg: Config{AllowedOrigins: []string{AnyOrigin}, AllowCredentials: true}, wantErr: true},
+		{name: "no scheme", cfg: Config{AllowedOrigins: []string{"fightbettr.com"}}, wantErr: true},
+		{name: "path", cfg: Config{AllowedOrigins: []string{"https://fightbettr.com/app"}}, wantErr: true},
+		{name: "inner wildcard", cfg: Config{AllowedOrigins: []string{"https://app.*.fightbettr.com"}}, wantErr: true},
+		{name: "bare wildcard", cfg: Config{AllowedOrigins: []string{"https://*."}}, wantErr: true},
+		{name: "relative rule", cfg: Config{Rules: []Rule{{PathPrefix: "media"}}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAllowsOrigin(t *testing.T) {
+	p, err := New(testConfig)
+	require.NoError(t, err)
+
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{origin: "https://fightbettr.com", want: true},
+		{origin: "https://FightBettr.com", want: true},
+		{origin: "https://app.fightbettr.com", want: true},
+		{origin: "https://a.b.fightbettr.com", want: true},
+		{origin: "http://localhost:3000", want: true},
+		{origin: "http://fightbettr.com", want: false},
+		{origin: "https://evilfightbettr.com", want: false},
+		{origin: "https://fightbettr.com.evil.com", want: false},
+		{origin: "https://app.fightbettr.com:8443", want: false},
+		{origin: "http://localhost", want: false},
+		{origin: "null", want: false},
+		{origin: "https://fightbettr.com/path", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.origin, func(t *testing.T) {
+			assert.Equal(t, tt.want, p.AllowsOrigin(tt.origin))
+		})
+	}
+}
+
+func TestHandler(t *testing.T) {
+	p, err := New(testConfig)
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	handler := p.Handler(next)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		headers    map[string]string
+		wantStatus int
+		want       map[string]string
+	}{
+		{
+			name:       "same origin",
+			method:     http.MethodGet,
+			path:       "/events",
+			wantStatus: http.StatusTeapot,
+			want:       map[string]string{HeaderAllowOrigin: ""},
+		},
+		{
+			name:       "allowed origin",
+			method:     http.MethodPost,
+			path:       "/login",
+			headers:    map[string]string{HeaderOrigin: "https://app.fightbettr.com"},
+			wantStatus: http.StatusTeapot,
+			want: map[string]string{
+				HeaderAllowOrigin:      "https://app.fightbettr.com",
+				HeaderAllowCredentials: "true",
+				HeaderExposeHeaders:    "X-Request-Id,Retry-After",
+				HeaderVary:             HeaderOrigin,
+			},
+		},
+		{
+			name:       "other origin",
+			method:     http.MethodPost,
+			path:       "/login",
+			headers:    map[string]string{HeaderOrigin: "https://evil.com"},
+			wantStatus: http.StatusTeapot,
+			want: map[string]string{
+				HeaderAllowOrigin:      "",
+				HeaderAllowCredentials: "",
+				HeaderVary:             HeaderOrigin,
+			},
+		},
+		{
+			name:   "preflight",
+			method: http.MethodOptions,
+			path:   "/login",
+			headers: map[string]string{
+				HeaderOrigin:         "https://fightbettr.com",
+				HeaderRequestMethod:  http.MethodPost,
+				HeaderRequestHeaders: "content-type, x-request-id",
+			},
+			wantStatus: http.StatusNoContent,
+			want: map[string]string{
+				HeaderAllowOrigin:      "https://fightbettr.com",
+				HeaderAllowCredentials: "true",
+				HeaderAllowMethods:     "GET,POST",
+				HeaderAllowHeaders:     "Content-Type,X-Request-Id",
+				HeaderMaxAge:           "600",
+			},
+		},
+		{
+			name:   "preflight of other origin",
+			method: http.MethodOptions,
+			path:   "/login",
+			headers: map[string]string{
+				HeaderOrigin:        "https://evil.com",
+				HeaderRequestMethod: http.MethodPost,
+			},
+			wantStatus: http.StatusForbidden,
+			want:       map[string]string{HeaderAllowOrigin: "", HeaderAllowMethods: ""},
+		},
+		{
+			name:   "preflight of method not allowed",
+			method: http.MethodOptions,
+			path:   "/login",
+			headers: map[string]string{
+				HeaderOrigin:        "https://fightbettr.com",
+				HeaderRequestMethod: http.MethodDelete,
+			},
+			wantStatus: http.StatusForbidden,
+			want:       map[string]string{HeaderAllowOrigin: ""},
+		},
+		{
+			name:   "preflight of header not allowed",
+			method: http.MethodOptions,
+			path:   "/login",
+			headers: map[string]string{
+				HeaderOrigin:         "https://fightbettr.com",
+				HeaderRequestMethod:  http.MethodPost,
+				HeaderRequestHeaders: "Content-Type, X-Debug",
+			},
+			wantStatus: http.StatusForbidden,
+			want:       map[string]string{HeaderAllowOrigin: ""},
+		},
+		{
+			name:   "preflight of route rule",
+			method: http.MethodOptions,
+			path:   "/media/fighters/1/small",
+			headers: map[string]string{
+				HeaderOrigin:        "https://fightbettr.com",
+				HeaderRequestMethod: http.MethodPost,
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:   "preflight of longest route rule",
+			method: http.MethodOptions,
+			path:   "/media/upload",
+			headers: map[string]string{
+				HeaderOrigin:         "https://fightbettr.com",
+				HeaderRequestMethod:  http.MethodPut,
+				HeaderRequestHeaders: "Content-Type",
+			},
+			wantStatus: http.StatusNoContent,
+			want: map[string]string{
+				HeaderAllowMethods: "PUT",
+				HeaderAllowHeaders: "Content-Type",
+			},
+		},
+		{
+			name:       "options without preflight",
+			method:     http.MethodOptions,
+			path:       "/login",
+			headers:    map[string]string{HeaderOrigin: "https://fightbettr.com"},
+			wantStatus: http.StatusTeapot,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			for k, v := range tt.want {
+				assert.Equal(t, v, w.Header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestHandlerAnyOrigin(t *testing.T) {
+	p, err := New(Config{AllowedOrigins: []string{AnyOrigin}, AllowedMethods: []string{http.MethodGet}})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/events", nil)
+	r.Header.Set(HeaderOrigin, "https://example.com")
+
+	w := httptest.NewRecorder()
+	p.Handler(http.NotFoundHandler()).ServeHTTP(w, r)
+
+	assert.Equal(t, AnyOrigin, w.Header().Get(HeaderAllowOrigin))
+	assert.Empty(t, w.Header().Get(HeaderAllowCredentials))
+}
+
+func TestViperConfig(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("cors.allowed_origins", []string{"https://fightbettr.com"})
+	viper.Set("cors.allowed_methods", []string{"GET"})
+	viper.Set("cors.allow_credentials", true)
+	viper.Set("cors.max_age", time.Hour)
+	viper.SetDefault("cors.routes.media.path_prefix", "/media")
+	viper.SetDefault("cors.routes.media.methods", []string{"GET"})
+
+	cfg := ViperConfig()
+
+	assert.Equal(t, []string{"https://fightbettr.com"}, cfg.AllowedOrigins)
+	assert.Equal(t, []string{"GET"}, cfg.AllowedMethods)
+	assert.True(t, cfg.AllowCredentials)
+	assert.Equal(t, time.Hour, cfg.MaxAge)
+	assert.Equal(t, []Rule{{PathPrefix: "/media", Methods: []string{"GET"}}}, cfg.Rules)
+}
+
+func TestViperConfigWebOrigin(t *testing.T) {
+	defer viper.Reset()
+
+	// the defaults of the gateway
+	viper.SetDefault("web.host", "http://localhost")
+	viper.SetDefault("web.port", "4200")
+	viper.SetDefault("cors.allow_credentials", true)
+
+	cfg := ViperConfig()
+	assert.Equal(t, []string{"http://localhost:4200"}, cfg.AllowedOrigins)
+
+	p, err := New(cfg)
+	require.NoError(t, err)
+	assert.True(t, p.AllowsOrigin("http://localhost:4200"))
+	assert.False(t, p.AllowsOrigin("http://localhost"))
+
+	viper.Set("cors.allowed_origins", []string{"https://fightbettr.com"})
+	assert.Equal(t, []string{"https://fightbettr.com"}, ViperConfig().AllowedOrigins)
+}
+
+func TestWebOrigin(t *testing.T) {
+	assert.Equal(t, "http://localhost:4200", WebOrigin("http://localhost", "4200"))
+	assert.Equal(t, "http://localhost:4200", WebOrigin("http://localhost/", "4200"))
+	assert.Equal(t, "https://fightbettr.com", WebOrigin("https://fightbettr.com", ""))
+}
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"fmt"
+	"sync"
+
+	"fightbettr.com/pkg/discovery"
+	"fightbettr.com/pkg/discovery/consul"
+	"fightbettr.com/pkg/discovery/dns"
+	"fightbettr.com/pkg/discovery/memory"
+	"fightbettr.com/pkg/discovery/static"
+	"github.com/spf13/viper"
+)
+
+// Names of the registry backends.
+const (
+	Consul = "consul"
+	Memory = "memory"
+	Static = "static"
+	DNS    = "dns"
+)
+
+// Config selects the registry backend and holds its settings.
+type Config struct {
+	// Backend is one of consul, memory, static and dns.
+	Backend string
+	// Addr is the address of the Consul agent.
+	Addr string
+	// StaticPath is the YAML file with the addresses of the services of the static backend.
+	StaticPath string
+	// StaticServices are the addresses of the services of the static backend, added to those from StaticPath.
+	StaticServices map[string][]string
+	// DNSDomain is the domain the service names are looked up in by the dns backend.
+	DNSDomain string
+	// DNSService is the service of the SRV records looked up by the dns backend, e.g. grpc.
+	DNSService string
+}
+
+var (
+	sharedMemory     *memory.Registry
+	sharedMemoryOnce sync.Once
+)
+
+// ViperConfig returns the registry config based on the registry values from viper.
+func ViperConfig() Config {
+	return Config{
+		Backend:        viper.GetString("registry.backend"),
+		Addr:           viper.GetString("registry.addr"),
+		StaticPath:     viper.GetString("registry.static.path"),
+		StaticServices: viper.GetStringMapStringSlice("registry.static.services"),
+		DNSDomain:      viper.GetString("registry.dns.domain"),
+		DNSService:     viper.GetString("registry.dns.service"),
+	}
+}
+
+// New creates the registry of the configured backend. An empty backend selects Consul.
+// Every memory registry created in a process is the same instance, so services running
+// in a single binary find each other.
+func New(cfg Config) (discovery.Registry, error) {
+	switch cfg.Backend {
+	case Consul, "":
+		return consul.NewRegistry(cfg.Addr)
+	case Memory:
+		sharedMemoryOnce.Do(func() {
+			sharedMemory = memory.NewRegistry()
+		})
+
+		return sharedMemory, nil
+	case Static:
+		services := make(map[string][]string)
+
+		if cfg.StaticPath != "" {
+			f, err := static.LoadFile(cfg.StaticPath)
+			if err != nil {
+				return nil, err
+			}
+
+			services = f.Services()
+		}
+
+		for name, addrs := range cfg.StaticServices {
+			services[name] = append(services[name], addrs...)
+		}
+
+		return static.NewRegistry(services), nil
+	case DNS:
+		return dns.NewRegistry(cfg.DNSDomain, cfg.DNSService), nil
+	default:
+		return nil, fmt.Errorf("unknown registry backend '%s', allowed backends are: %s, %s, %s, %s",
+			cfg.Backend, Consul, Memory, Static, DNS)
+	}
+}
+
+// FromViper creates the registry configured in viper.
+func FromViper() (discovery.Registry, error) {
+	return New(ViperConfig())
+}
@@ -0,0 +1,86 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fightbettr.com/pkg/discovery/consul"
+	"fightbettr.com/pkg/discovery/dns"
+	"fightbettr.com/pkg/discovery/memory"
+	"fightbettr.com/pkg/discovery/static"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		want    any
+		wantErr bool
+	}{
+		{name: "default", cfg: Config{Addr: "localhost:8500"}, want: &consul.Registry{}},
+		{name: "consul", cfg: Config{Backend: Consul, Addr: "localhost:8500"}, want: &consul.Registry{}},
+		{name: "memory", cfg: Config{Backend: Memory}, want: &memory.Registry{}},
+		{name: "static", cfg: Config{Backend: Static}, want: &static.Registry{}},
+		{name: "dns", cfg: Config{Backend: DNS, DNSDomain: "fb.local"}, want: &dns.Registry{}},
+		{name: "missing static file", cfg: Config{Backend: Static, StaticPath: "missing.yaml"}, wantErr: true},
+		{name: "unknown", cfg: Config{Backend: "etcd"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.want, r)
+		})
+	}
+}
+
+func TestNewMemoryIsShared(t *testing.T) {
+	ctx := context.Background()
+
+	a, err := New(Config{Backend: Memory})
+	require.NoError(t, err)
+	b, err := New(Config{Backend: Memory})
+	require.NoError(t, err)
+
+	require.NoError(t, a.Register(ctx, "auth-1", "auth-service", "localhost:9092"))
+	defer a.Deregister(ctx, "auth-1", "auth-service")
+
+	addrs, err := b.ServiceAddresses(ctx, "auth-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9092"}, addrs)
+}
+
+func TestFromViperStatic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("services:\n  auth-service:\n    - localhost:9092\n"), 0644))
+
+	viper.Set("registry.backend", Static)
+	viper.Set("registry.static.path", path)
+	viper.Set("registry.static.services", map[string]any{
+		"auth-service":  []string{"localhost:9192"},
+		"event-service": []string{"localhost:9094"},
+	})
+	defer viper.Reset()
+
+	r, err := FromViper()
+	require.NoError(t, err)
+
+	addrs, err := r.ServiceAddresses(context.Background(), "auth-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9092", "localhost:9192"}, addrs)
+
+	addrs, err = r.ServiceAddresses(context.Background(), "event-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9094"}, addrs)
+}
@@ -0,0 +1,81 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"fightbettr.com/pkg/discovery"
+)
+
+// Registry defines a service registry that resolves the instances of a service with DNS SRV records,
+// e.g. of a Kubernetes headless service. The records are managed outside of the services,
+// so registration of an instance has no effect.
+//
+// The addresses of a service are looked up at _<service>._tcp.<service name>.<domain>, e.g.
+// _grpc._tcp.auth-service.fightbettr.svc.cluster.local; without a service the name
+// <service name>.<domain> is looked up directly.
+type Registry struct {
+	domain  string
+	service string
+
+	lookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+// NewRegistry creates a new DNS SRV service registry for the domain and the SRV service, which may be empty.
+func NewRegistry(domain string, service string) *Registry {
+	return &Registry{
+		domain:    strings.Trim(domain, "."),
+		service:   service,
+		lookupSRV: net.DefaultResolver.LookupSRV,
+	}
+}
+
+// Register does nothing, the SRV records are managed outside of the services.
+func (r *Registry) Register(ctx context.Context, instanceID string, serviceName string, hostPort string) error {
+	return nil
+}
+
+// Deregister does nothing, the SRV records are managed outside of the services.
+func (r *Registry) Deregister(ctx context.Context, instanceID string, serviceName string) error {
+	return nil
+}
+
+// ServiceAddresses returns the addresses of the targets of the SRV records of the given service
+// in the order of their priority and weight, or discovery.ErrNotFound.
+func (r *Registry) ServiceAddresses(ctx context.Context, serviceName string) ([]string, error) {
+	name := serviceName
+	if r.domain != "" {
+		name += "." + r.domain
+	}
+
+	proto := ""
+	if r.service != "" {
+		proto = "tcp"
+	}
+
+	_, records, err := r.lookupSRV(ctx, r.service, proto, name)
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) && dnsErr.IsNotFound {
+		return nil, discovery.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	} else if len(records) == 0 {
+		return nil, discovery.ErrNotFound
+	}
+
+	res := make([]string, 0, len(records))
+	for _, rec := range records {
+		res = append(res, net.JoinHostPort(strings.TrimSuffix(rec.Target, "."), fmt.Sprint(rec.Port)))
+	}
+
+	return res, nil
+}
+
+// ReportHealthyState does nothing, the health of the instances is tracked by the DNS server.
+func (r *Registry) ReportHealthyState(instanceID string, serviceName string) error {
+	return nil
+}
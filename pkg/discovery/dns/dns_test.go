@@ -0,0 +1,99 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"fightbettr.com/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceAddresses(t *testing.T) {
+	records := []*net.SRV{
+		{Target: "auth-0.auth-service.fb.svc.cluster.local.", Port: 9092, Priority: 10, Weight: 50},
+		{Target: "10.0.0.7", Port: 9092, Priority: 10, Weight: 50},
+	}
+
+	tests := []struct {
+		name      string
+		domain    string
+		service   string
+		records   []*net.SRV
+		err       error
+		wantQuery [3]string
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "with srv service",
+			domain:    "fb.svc.cluster.local.",
+			service:   "grpc",
+			records:   records,
+			wantQuery: [3]string{"grpc", "tcp", "auth-service.fb.svc.cluster.local"},
+			want:      []string{"auth-0.auth-service.fb.svc.cluster.local:9092", "10.0.0.7:9092"},
+		},
+		{
+			name:      "without srv service",
+			domain:    "service.consul",
+			records:   records[1:],
+			wantQuery: [3]string{"", "", "auth-service.service.consul"},
+			want:      []string{"10.0.0.7:9092"},
+		},
+		{
+			name:      "without domain",
+			records:   records[1:],
+			wantQuery: [3]string{"", "", "auth-service"},
+			want:      []string{"10.0.0.7:9092"},
+		},
+		{
+			name:      "no such host",
+			domain:    "fb.local",
+			err:       &net.DNSError{Err: "no such host", Name: "auth-service.fb.local", IsNotFound: true},
+			wantQuery: [3]string{"", "", "auth-service.fb.local"},
+			wantErr:   discovery.ErrNotFound,
+		},
+		{
+			name:      "no records",
+			domain:    "fb.local",
+			wantQuery: [3]string{"", "", "auth-service.fb.local"},
+			wantErr:   discovery.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry(tt.domain, tt.service)
+
+			var query [3]string
+			r.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+				query = [3]string{service, proto, name}
+				return "", tt.records, tt.err
+			}
+
+			addrs, err := r.ServiceAddresses(context.Background(), "auth-service")
+			assert.Equal(t, tt.wantQuery, query)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, addrs)
+		})
+	}
+}
+
+func TestServiceAddressesError(t *testing.T) {
+	r := NewRegistry("fb.local", "")
+	r.lookupSRV = func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+		return "", nil, &net.DNSError{Err: "server misbehaving", IsTemporary: true}
+	}
+
+	_, err := r.ServiceAddresses(context.Background(), "auth-service")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, discovery.ErrNotFound))
+}
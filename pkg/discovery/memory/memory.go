@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"fightbettr.com/pkg/discovery"
+)
+
+// DefaultTTL is the time after the last reported healthy state after which an instance is considered inactive.
+// It matches the TTL of the health check the Consul registry creates for an instance.
+const DefaultTTL = 5 * time.Second
+
+// Registry defines an in-memory service registry. It is used in tests and when all services run
+// in a single process, where they have to share the same Registry.
+type Registry struct {
+	mu       sync.RWMutex
+	services map[string]map[string]*instance
+	ttl      time.Duration
+	now      func() time.Time
+}
+
+// instance is a registered service instance.
+type instance struct {
+	hostPort   string
+	lastActive time.Time
+}
+
+// NewRegistry creates a new in-memory service registry instance with the DefaultTTL.
+func NewRegistry() *Registry {
+	return &Registry{
+		services: make(map[string]map[string]*instance),
+		ttl:      DefaultTTL,
+		now:      time.Now,
+	}
+}
+
+// Register creates a service instance record in the registry. The instance is active right away.
+func (r *Registry) Register(ctx context.Context, instanceID string, serviceName string, hostPort string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.services[serviceName]; !ok {
+		r.services[serviceName] = make(map[string]*instance)
+	}
+
+	r.services[serviceName][instanceID] = &instance{hostPort: hostPort, lastActive: r.now()}
+
+	return nil
+}
+
+// Deregister removes a service instance record from the registry.
+func (r *Registry) Deregister(ctx context.Context, instanceID string, serviceName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.services[serviceName]; !ok {
+		return nil
+	}
+
+	delete(r.services[serviceName], instanceID)
+
+	if len(r.services[serviceName]) == 0 {
+		delete(r.services, serviceName)
+	}
+
+	return nil
+}
+
+// ReportHealthyState marks the service instance as active. It returns an error if the instance is not registered.
+func (r *Registry) ReportHealthyState(instanceID string, serviceName string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i, ok := r.services[serviceName][instanceID]
+	if !ok {
+		return errors.New("service instance is not registered yet")
+	}
+
+	i.lastActive = r.now()
+
+	return nil
+}
+
+// ServiceAddresses returns the list of addresses of the instances of the given service that reported
+// their healthy state within the TTL, or discovery.ErrNotFound.
+func (r *Registry) ServiceAddresses(ctx context.Context, serviceName string) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var res []string
+	for _, i := range r.services[serviceName] {
+		if r.now().Sub(i.lastActive) > r.ttl {
+			continue
+		}
+
+		res = append(res, i.hostPort)
+	}
+
+	if len(res) == 0 {
+		return nil, discovery.ErrNotFound
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
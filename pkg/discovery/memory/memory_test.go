@@ -0,0 +1,49 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fightbettr.com/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	r := NewRegistry()
+	r.now = func() time.Time { return now }
+
+	_, err := r.ServiceAddresses(ctx, "auth-service")
+	assert.ErrorIs(t, err, discovery.ErrNotFound)
+
+	require.NoError(t, r.Register(ctx, "auth-1", "auth-service", "localhost:9092"))
+	require.NoError(t, r.Register(ctx, "auth-2", "auth-service", "localhost:9192"))
+	require.NoError(t, r.Register(ctx, "event-1", "event-service", "localhost:9094"))
+
+	addrs, err := r.ServiceAddresses(ctx, "auth-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9092", "localhost:9192"}, addrs)
+
+	// An instance that stops reporting its healthy state is inactive after the TTL.
+	now = now.Add(DefaultTTL / 2)
+	require.NoError(t, r.ReportHealthyState("auth-2", "auth-service"))
+	now = now.Add(DefaultTTL)
+
+	addrs, err = r.ServiceAddresses(ctx, "auth-service")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"localhost:9192"}, addrs)
+
+	_, err = r.ServiceAddresses(ctx, "event-service")
+	assert.ErrorIs(t, err, discovery.ErrNotFound)
+
+	require.NoError(t, r.Deregister(ctx, "auth-2", "auth-service"))
+	_, err = r.ServiceAddresses(ctx, "auth-service")
+	assert.ErrorIs(t, err, discovery.ErrNotFound)
+
+	assert.Error(t, r.ReportHealthyState("auth-3", "auth-service"))
+	assert.NoError(t, r.Deregister(ctx, "auth-3", "fighters-service"))
+}
@@ -0,0 +1,89 @@
+package static
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"fightbettr.com/pkg/discovery"
+	"gopkg.in/yaml.v3"
+)
+
+// Registry defines a service registry with a fixed list of addresses per service. It is used to run
+// the services without a registry server: the addresses are taken from the configuration, and
+// registration of an instance has no effect.
+type Registry struct {
+	services map[string][]string
+}
+
+// File is the format of the YAML file of a static registry:
+//
+//	services:
+//	  auth-service:
+//	    - localhost:9092
+//	  fighters-service:
+//	    - 10.0.0.5:9093
+//	    - 10.0.0.6:9093
+type File struct {
+	Services map[string][]string `yaml:"services"`
+}
+
+// NewRegistry creates a new static service registry with the addresses of every service.
+func NewRegistry(services map[string][]string) *Registry {
+	r := &Registry{services: make(map[string][]string, len(services))}
+	for name, addrs := range services {
+		r.services[name] = append([]string(nil), addrs...)
+	}
+
+	return r
+}
+
+// LoadFile creates a new static service registry with the addresses from the YAML file at path.
+func LoadFile(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("static registry %s: %w", path, err)
+	}
+
+	return NewRegistry(f.Services), nil
+}
+
+// Services returns the addresses of every service of the registry.
+func (r *Registry) Services() map[string][]string {
+	res := make(map[string][]string, len(r.services))
+	for name, addrs := range r.services {
+		res[name] = append([]string(nil), addrs...)
+	}
+
+	return res
+}
+
+// Register does nothing, the addresses of a static registry are fixed.
+func (r *Registry) Register(ctx context.Context, instanceID string, serviceName string, hostPort string) error {
+	return nil
+}
+
+// Deregister does nothing, the addresses of a static registry are fixed.
+func (r *Registry) Deregister(ctx context.Context, instanceID string, serviceName string) error {
+	return nil
+}
+
+// ServiceAddresses returns the configured addresses of the given service, or discovery.ErrNotFound.
+func (r *Registry) ServiceAddresses(ctx context.Context, serviceName string) ([]string, error) {
+	addrs := r.services[serviceName]
+	if len(addrs) == 0 {
+		return nil, discovery.ErrNotFound
+	}
+
+	return append([]string(nil), addrs...), nil
+}
+
+// ReportHealthyState does nothing, the instances of a static registry are always considered healthy.
+func (r *Registry) ReportHealthyState(instanceID string, serviceName string) error {
+	return nil
+}
@@ -0,0 +1,81 @@
+package static
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fightbettr.com/pkg/discovery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		service string
+		want    []string
+		wantErr error
+	}{
+		{
+			name:    "single address",
+			content: "services:\n  auth-service:\n    - localhost:9092\n",
+			service: "auth-service",
+			want:    []string{"localhost:9092"},
+		},
+		{
+			name:    "several addresses",
+			content: "services:\n  fighters-service:\n    - 10.0.0.5:9093\n    - 10.0.0.6:9093\n",
+			service: "fighters-service",
+			want:    []string{"10.0.0.5:9093", "10.0.0.6:9093"},
+		},
+		{
+			name:    "unknown service",
+			content: "services:\n  auth-service:\n    - localhost:9092\n",
+			service: "event-service",
+			wantErr: discovery.ErrNotFound,
+		},
+		{
+			name:    "service without addresses",
+			content: "services:\n  auth-service: []\n",
+			service: "auth-service",
+			wantErr: discovery.ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "registry.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			r, err := LoadFile(path)
+			require.NoError(t, err)
+
+			// Registration does not change the configured addresses.
+			require.NoError(t, r.Register(context.Background(), "id", tt.service, "localhost:1"))
+			require.NoError(t, r.ReportHealthyState("id", tt.service))
+
+			addrs, err := r.ServiceAddresses(context.Background(), tt.service)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, addrs)
+		})
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	_, err := LoadFile(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "registry.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("services: [localhost:9092]"), 0644))
+
+	_, err = LoadFile(path)
+	assert.Error(t, err)
+}
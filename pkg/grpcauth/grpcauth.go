@@ -0,0 +1,276 @@
+package grpcauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/model"
+	"fightbettr.com/pkg/tlsx"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the key of the gRPC metadata the JWT of the user is forwarded in as a bearer token.
+const MetadataKey = "authorization"
+
+// bearerPrefix prefixes the token in the metadata value.
+const bearerPrefix = "Bearer "
+
+// Permission is the requirement a caller must meet to call a method.
+type Permission int
+
+const (
+	// Public methods may be called by anyone, e.g. Login.
+	Public Permission = iota
+	// User methods may be called with the token of a logged in user.
+	User
+	// Admin methods may be called with the token of an admin.
+	Admin
+	// Service methods may be called by the services with a verified client certificate and by the admins.
+	Service
+)
+
+// String returns the name of the permission.
+func (p Permission) String() string {
+	switch p {
+	case Public:
+		return "public"
+	case User:
+		return "user"
+	case Admin:
+		return "admin"
+	case Service:
+		return "service"
+	default:
+		return fmt.Sprintf("Permission(%d)", int(p))
+	}
+}
+
+// ErrNoToken is returned by a verifier when the call carries no token.
+var ErrNoToken = errors.New("grpcauth: no token")
+
+// Identity is the user a call is made for, as verified from the forwarded token.
+type Identity struct {
+	UserID int32
+	Admin  bool
+}
+
+// Verifier verifies a raw token and returns the identity of its user.
+type Verifier func(token string) (Identity, error)
+
+// Policy is the permission of every method of a service by the full method name.
+type Policy map[string]Permission
+
+// NewPolicy checks that the rules set the permission of every method of the service and of no other method,
+// so a method added to the service without a rule is never left open, and returns them as the policy.
+func NewPolicy(desc *grpc.ServiceDesc, rules map[string]Permission) (Policy, error) {
+	methods := make(map[string]bool, len(desc.Methods))
+	for _, m := range desc.Methods {
+		methods["/"+desc.ServiceName+"/"+m.MethodName] = true
+	}
+
+	for method := range methods {
+		if _, ok := rules[method]; !ok {
+			return nil, fmt.Errorf("grpcauth: no permission for method %s", method)
+		}
+	}
+
+	for method := range rules {
+		if !methods[method] {
+			return nil, fmt.Errorf("grpcauth: %s is not a method of %s", method, desc.ServiceName)
+		}
+	}
+
+	return Policy(rules), nil
+}
+
+// MustPolicy is like NewPolicy but panics if the rules do not match the methods of the service.
+func MustPolicy(desc *grpc.ServiceDesc, rules map[string]Permission) Policy {
+	p, err := NewPolicy(desc, rules)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// ServicePermission returns the permission of the methods the other services call: Service when the servers
+// of the config require client certificates, so the calling services can be identified, and Admin otherwise,
+// since a caller without a certificate cannot be told apart from any client that reaches the port.
+func ServicePermission(cfg tlsx.Config) Permission {
+	if cfg.Enabled && cfg.ClientAuth {
+		return Service
+	}
+
+	return Admin
+}
+
+// contextKey is the type of the context keys of this package.
+type contextKey int
+
+const (
+	tokenContextKey contextKey = iota
+	identityContextKey
+)
+
+// WithToken returns a copy of the context with the raw token of the user, forwarded to the services
+// by UnaryClientInterceptor.
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenContextKey, token)
+}
+
+// FromContext returns the verified identity of the call, if the call carries a valid token.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityContextKey).(Identity)
+	return id, ok
+}
+
+// UserID returns the ID of the user the call is made for. A call may name its user in the request,
+// e.g. to be compatible with the older gateways, but only admins may name a user other than their own.
+// It fails with the Unauthenticated code if the call carries no valid token and with the PermissionDenied
+// code if the requested user is not the user of the token.
+func UserID(ctx context.Context, requested int32) (int32, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "user token is required")
+	}
+
+	if requested == 0 || requested == id.UserID {
+		return id.UserID, nil
+	}
+
+	if id.Admin {
+		return requested, nil
+	}
+
+	return 0, status.Errorf(codes.PermissionDenied, "user %d may not act for user %d", id.UserID, requested)
+}
+
+// UnaryClientInterceptor forwards the token of the context to the called service in the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if token, ok := ctx.Value(tokenContextKey).(string); ok && token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataKey, bearerPrefix+token)
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor verifies the token of every call and allows the call if the caller has the permission
+// of the method by the policy. The identity of a valid token is added to the context of the call, also for
+// the public methods. The calls without the permission fail with the Unauthenticated code if they carry no valid
+// token and with the PermissionDenied code otherwise. The methods out of the policy, e.g. those of the health
+// service, are public.
+func UnaryServerInterceptor(policy Policy, verify Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		perm := policy[info.FullMethod]
+
+		id, err := verify(bearerToken(ctx))
+		if err == nil {
+			ctx = context.WithValue(ctx, identityContextKey, id)
+		} else if !errors.Is(err, ErrNoToken) {
+			logs.Ctx(ctx).Debugf("Invalid user token: %s", err)
+		}
+
+		if err := authorize(ctx, perm, id, err == nil); err != nil {
+			logs.Ctx(ctx).Warnw("Call is not authorized", "permission", perm.String())
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// authorize checks that the caller has the permission.
+func authorize(ctx context.Context, perm Permission, id Identity, verified bool) error {
+	switch perm {
+	case Public:
+		return nil
+	case User:
+		if verified {
+			return nil
+		}
+	case Admin:
+		if verified && id.Admin {
+			return nil
+		}
+	case Service:
+		if _, ok := tlsx.Identity(ctx); ok {
+			return nil
+		}
+
+		if verified && id.Admin {
+			return nil
+		}
+	}
+
+	if !verified {
+		return status.Errorf(codes.Unauthenticated, "%s token is required", perm)
+	}
+
+	return status.Errorf(codes.PermissionDenied, "%s permission is required", perm)
+}
+
+// bearerToken returns the bearer token of the incoming metadata, or an empty string.
+func bearerToken(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, v := range md.Get(MetadataKey) {
+		if token, ok := strings.CutPrefix(v, bearerPrefix); ok {
+			return token
+		}
+	}
+
+	return ""
+}
+
+// JWTVerifier verifies the RS256 signature and the expiration of the JWT of the users with the key
+// set by utils.LoadJwtCerts (auth.jwt.parse_key), and reads the user ID and the admin flag from the claims
+// the auth service issues.
+func JWTVerifier() Verifier {
+	return func(raw string) (Identity, error) {
+		if raw == "" {
+			return Identity{}, ErrNoToken
+		}
+
+		key := viper.Get("auth.jwt.parse_key")
+		if key == nil {
+			return Identity{}, errors.New("grpcauth: JWT key is not loaded")
+		}
+
+		token, err := jwt.Parse([]byte(raw), jwt.WithKey(jwa.RS256, key), jwt.WithValidate(true))
+		if err != nil {
+			return Identity{}, err
+		}
+
+		return identityFromClaims(token)
+	}
+}
+
+// identityFromClaims reads the identity from the claims of the token.
+func identityFromClaims(token jwt.Token) (Identity, error) {
+	v, ok := token.Get(string(model.ContextUserId))
+	uid, valid := v.(float64)
+	if !ok || !valid || uid <= 0 {
+		return Identity{}, errors.New("grpcauth: token has no user id")
+	}
+
+	id := Identity{UserID: int32(uid)}
+
+	if f, ok := token.Get(string(model.ContextFlags)); ok {
+		if flags, valid := f.(float64); valid && int(flags) == 1 {
+			id.Admin = true
+		}
+	}
+
+	return id, nil
+}
@@ -0,0 +1,256 @@
+package grpcauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"fightbettr.com/pkg/tlsx"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var testDesc = &grpc.ServiceDesc{
+	ServiceName: "EventService",
+	Methods: []grpc.MethodDesc{
+		{MethodName: "GetEvents"},
+		{MethodName: "GetBets"},
+		{MethodName: "SetResult"},
+		{MethodName: "Import"},
+	},
+}
+
+var testRules = map[string]Permission{
+	"/EventService/GetEvents": Public,
+	"/EventService/GetBets":   User,
+	"/EventService/SetResult": Admin,
+	"/EventService/Import":    Service,
+}
+
+// signToken signs a token with the claims the auth service issues.
+func signToken(t *testing.T, key *rsa.PrivateKey, userID float64, flags float64, exp time.Time) string {
+	token, err := jwt.NewBuilder().
+		Claim("user_id", userID).
+		Claim("flags", flags).
+		Expiration(exp).
+		Build()
+	require.NoError(t, err)
+
+	signed, err := jwt.Sign(token, jwt.WithKey(jwa.RS256, key))
+	require.NoError(t, err)
+
+	return string(signed)
+}
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]Permission
+		wantErr bool
+	}{
+		{name: "every method", rules: testRules},
+		{name: "missing method", rules: map[string]Permission{"/EventService/GetEvents": Public}, wantErr: true},
+		{
+			name: "unknown method",
+			rules: map[string]Permission{
+				"/EventService/GetEvents": Public,
+				"/EventService/GetBets":   User,
+				"/EventService/SetResult": Admin,
+				"/EventService/Import":    Service,
+				"/EventService/Unknown":   Public,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPolicy(testDesc, tt.rules)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestJWTVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	viper.Set("auth.jwt.parse_key", &key.PublicKey)
+	defer viper.Set("auth.jwt.parse_key", nil)
+
+	hour := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Identity
+		wantErr bool
+	}{
+		{name: "user", token: signToken(t, key, 42, 0, hour), want: Identity{UserID: 42}},
+		{name: "admin", token: signToken(t, key, 1, 1, hour), want: Identity{UserID: 1, Admin: true}},
+		{name: "no token", wantErr: true},
+		{name: "expired", token: signToken(t, key, 42, 0, time.Now().Add(-time.Hour)), wantErr: true},
+		{name: "signed by another key", token: signToken(t, other, 42, 1, hour), wantErr: true},
+		{name: "no user id", token: signToken(t, key, 0, 0, hour), wantErr: true},
+		{name: "malformed", token: "not a token", wantErr: true},
+	}
+
+	verify := JWTVerifier()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verify(tt.token)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want metadata.MD
+	}{
+		{name: "no token", ctx: context.Background()},
+		{name: "token", ctx: WithToken(context.Background(), "abc"), want: metadata.Pairs(MetadataKey, "Bearer abc")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				got, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+
+			require.NoError(t, UnaryClientInterceptor()(tt.ctx, "/EventService/GetBets", nil, nil, nil, invoker))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	// The tokens of the test name their identities.
+	identities := map[string]Identity{
+		"user":  {UserID: 42},
+		"admin": {UserID: 1, Admin: true},
+	}
+	verify := func(token string) (Identity, error) {
+		if token == "" {
+			return Identity{}, ErrNoToken
+		}
+
+		id, ok := identities[token]
+		if !ok {
+			return Identity{}, assert.AnError
+		}
+
+		return id, nil
+	}
+
+	interceptor := UnaryServerInterceptor(MustPolicy(testDesc, testRules), verify)
+
+	withToken := func(token string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(MetadataKey, "Bearer "+token))
+	}
+
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: "scrapper-service"}}
+	withCert := peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+		wantID   Identity
+	}{
+		{name: "public without token", ctx: context.Background(), method: "/EventService/GetEvents", wantCode: codes.OK},
+		{name: "public with token", ctx: withToken("user"), method: "/EventService/GetEvents", wantCode: codes.OK, wantID: identities["user"]},
+		{name: "user", ctx: withToken("user"), method: "/EventService/GetBets", wantCode: codes.OK, wantID: identities["user"]},
+		{name: "user without token", ctx: context.Background(), method: "/EventService/GetBets", wantCode: codes.Unauthenticated},
+		{name: "invalid token", ctx: withToken("forged"), method: "/EventService/GetBets", wantCode: codes.Unauthenticated},
+		{name: "admin", ctx: withToken("admin"), method: "/EventService/SetResult", wantCode: codes.OK, wantID: identities["admin"]},
+		{name: "user is not admin", ctx: withToken("user"), method: "/EventService/SetResult", wantCode: codes.PermissionDenied},
+		{name: "service certificate", ctx: withCert, method: "/EventService/Import", wantCode: codes.OK},
+		{name: "admin calls service method", ctx: withToken("admin"), method: "/EventService/Import", wantCode: codes.OK, wantID: identities["admin"]},
+		{name: "user calls service method", ctx: withToken("user"), method: "/EventService/Import", wantCode: codes.PermissionDenied},
+		{name: "method out of policy", ctx: context.Background(), method: "/grpc.health.v1.Health/Check", wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Identity
+			handler := func(ctx context.Context, req any) (any, error) {
+				got, _ = FromContext(ctx)
+				return "ok", nil
+			}
+
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantID, got)
+		})
+	}
+}
+
+func TestUserID(t *testing.T) {
+	withIdentity := func(id Identity) context.Context {
+		return context.WithValue(context.Background(), identityContextKey, id)
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		requested int32
+		want      int32
+		wantCode  codes.Code
+	}{
+		{name: "user of token", ctx: withIdentity(Identity{UserID: 42}), want: 42},
+		{name: "same user requested", ctx: withIdentity(Identity{UserID: 42}), requested: 42, want: 42},
+		{name: "other user requested", ctx: withIdentity(Identity{UserID: 42}), requested: 7, wantCode: codes.PermissionDenied},
+		{name: "admin requests other user", ctx: withIdentity(Identity{UserID: 1, Admin: true}), requested: 7, want: 7},
+		{name: "no token", ctx: context.Background(), requested: 42, wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserID(tt.ctx, tt.requested)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestServicePermission(t *testing.T) {
+	assert.Equal(t, Admin, ServicePermission(tlsx.Config{}))
+	assert.Equal(t, Admin, ServicePermission(tlsx.Config{Enabled: true}))
+	assert.Equal(t, Service, ServicePermission(tlsx.Config{Enabled: true, ClientAuth: true}))
+}
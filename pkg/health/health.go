@@ -0,0 +1,207 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Statuses of a check and of a report.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// DefaultTimeout limits a single check.
+const DefaultTimeout = 2 * time.Second
+
+// Check checks a dependency of a service, e.g. the database, and returns an error if it is not usable.
+type Check func(ctx context.Context) error
+
+// Result is the outcome of a single check.
+type Result struct {
+	Name     string `json:"name"`
+	Status   string `json:"status"`
+	Error    string `json:"error,omitempty"`
+	Duration string `json:"duration"`
+}
+
+// Report is the outcome of all checks of a Checker.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+// Ready reports whether every check has passed.
+func (r Report) Ready() bool {
+	return r.Status == StatusUp
+}
+
+// Err returns an error that lists the failed checks, or nil if the report is ready.
+func (r Report) Err() error {
+	if r.Ready() {
+		return nil
+	}
+
+	var failed []string
+	for _, res := range r.Checks {
+		if res.Status != StatusUp {
+			failed = append(failed, res.Name+": "+res.Error)
+		}
+	}
+
+	return fmt.Errorf("not ready: %v", failed)
+}
+
+// Checker runs the readiness checks of a service.
+type Checker struct {
+	mu      sync.RWMutex
+	checks  map[string]Check
+	timeout time.Duration
+}
+
+// New creates a checker that limits every check to timeout, or DefaultTimeout if it is not positive.
+func New(timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Checker{
+		checks:  make(map[string]Check),
+		timeout: timeout,
+	}
+}
+
+// Add adds the check with the given name, replacing the check with the same name.
+func (c *Checker) Add(name string, check Check) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.checks[name] = check
+}
+
+// Check runs every check concurrently and returns the report ordered by the check name.
+// A checker without checks is ready.
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	report := Report{Status: StatusUp, Checks: make([]Result, 0, len(c.checks))}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for name, check := range c.checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+
+			res := c.run(ctx, name, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if res.Status != StatusUp {
+				report.Status = StatusDown
+			}
+			report.Checks = append(report.Checks, res)
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	sort.Slice(report.Checks, func(i, j int) bool {
+		return report.Checks[i].Name < report.Checks[j].Name
+	})
+
+	return report
+}
+
+// run runs a single check within the timeout of the checker.
+func (c *Checker) run(ctx context.Context, name string, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// A check that ignores the context still fails at the timeout.
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	res := Result{Name: name, Status: StatusUp, Duration: time.Since(start).Round(time.Microsecond).String()}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+
+	return res
+}
+
+// Watch runs the checks right away and then every interval until the context is done,
+// and passes every report to the reporters, e.g. RegistryReporter and GRPCReporter.
+func Watch(ctx context.Context, c *Checker, interval time.Duration, reporters ...func(Report)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		report := c.Check(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		for _, fn := range reporters {
+			fn(report)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// LivenessHandler returns the handler of the liveness probe. It responds with 200 as long as
+// the process serves HTTP requests, regardless of its dependencies.
+func LivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": StatusUp})
+	}
+}
+
+// ReadinessHandler returns the handler of the readiness probe. It runs the checks and responds
+// with the report and 200 if every check has passed, or 503 otherwise.
+func ReadinessHandler(c *Checker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := c.Check(r.Context())
+
+		status := http.StatusOK
+		if !report.Ready() {
+			status = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, status, report)
+	}
+}
+
+// writeJSON writes the value as a JSON response with the status code. Probes must not be cached.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(v)
+}
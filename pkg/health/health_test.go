@@ -0,0 +1,201 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fightbettr.com/pkg/discovery/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestCheck(t *testing.T) {
+	ok := func(ctx context.Context) error { return nil }
+	fail := func(ctx context.Context) error { return errors.New("connection refused") }
+	hang := func(ctx context.Context) error { time.Sleep(time.Second); return nil }
+
+	tests := []struct {
+		name       string
+		checks     map[string]Check
+		wantStatus string
+		want       map[string]string
+	}{
+		{
+			name:       "no checks",
+			wantStatus: StatusUp,
+			want:       map[string]string{},
+		},
+		{
+			name:       "all pass",
+			checks:     map[string]Check{"postgres": ok, "auth-service": ok},
+			wantStatus: StatusUp,
+			want:       map[string]string{"auth-service": StatusUp, "postgres": StatusUp},
+		},
+		{
+			name:       "one fails",
+			checks:     map[string]Check{"postgres": fail, "auth-service": ok},
+			wantStatus: StatusDown,
+			want:       map[string]string{"auth-service": StatusUp, "postgres": StatusDown},
+		},
+		{
+			name:       "check ignoring the context times out",
+			checks:     map[string]Check{"postgres": hang},
+			wantStatus: StatusDown,
+			want:       map[string]string{"postgres": StatusDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(50 * time.Millisecond)
+			for name, check := range tt.checks {
+				c.Add(name, check)
+			}
+
+			start := time.Now()
+			report := c.Check(context.Background())
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+			assert.Equal(t, tt.wantStatus, report.Status)
+			assert.Equal(t, tt.wantStatus == StatusUp, report.Ready())
+			assert.Equal(t, report.Ready(), report.Err() == nil)
+
+			got := make(map[string]string)
+			var names []string
+			for _, res := range report.Checks {
+				got[res.Name] = res.Status
+				names = append(names, res.Name)
+				if res.Status == StatusDown {
+					assert.NotEmpty(t, res.Error)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+			assert.IsIncreasing(t, append([]string{""}, names...), "checks are ordered by name")
+		})
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	var err error
+	c := New(time.Second)
+	c.Add("postgres", func(ctx context.Context) error { return err })
+
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		err        error
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "liveness", handler: LivenessHandler(), err: errors.New("down"), wantStatus: http.StatusOK, wantBody: StatusUp},
+		{name: "ready", handler: ReadinessHandler(c), wantStatus: http.StatusOK, wantBody: StatusUp},
+		{name: "not ready", handler: ReadinessHandler(c), err: errors.New("down"), wantStatus: http.StatusServiceUnavailable, wantBody: StatusDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err = tt.err
+
+			rec := httptest.NewRecorder()
+			tt.handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+			var body struct {
+				Status string `json:"status"`
+			}
+			require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+			assert.Equal(t, tt.wantBody, body.Status)
+		})
+	}
+}
+
+// countingRegistry counts the reported healthy states.
+type countingRegistry struct {
+	memory.Registry
+	reports atomic.Int64
+}
+
+func (r *countingRegistry) ReportHealthyState(instanceID string, serviceName string) error {
+	r.reports.Add(1)
+	return nil
+}
+
+func TestRegistryReporter(t *testing.T) {
+	registry := &countingRegistry{}
+	report := RegistryReporter(registry, "auth-1", "auth-service")
+
+	ready := Report{Status: StatusUp}
+	notReady := Report{Status: StatusDown, Checks: []Result{{Name: "postgres", Status: StatusDown, Error: "down"}}}
+
+	for _, r := range []Report{notReady, ready, ready, notReady, notReady, ready} {
+		report(r)
+	}
+
+	assert.Equal(t, int64(3), registry.reports.Load(), "only ready reports reach the registry")
+}
+
+func TestGRPCReporter(t *testing.T) {
+	srv := NewGRPCServer("AuthService")
+
+	status := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := srv.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.Status
+	}
+
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status("AuthService"))
+
+	report := GRPCReporter(srv, "AuthService")
+
+	report(Report{Status: StatusUp})
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, status("AuthService"))
+
+	report(Report{Status: StatusDown})
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, status("AuthService"))
+}
+
+func TestWatch(t *testing.T) {
+	var healthy atomic.Bool
+	c := New(time.Second)
+	c.Add("postgres", func(ctx context.Context) error {
+		if !healthy.Load() {
+			return errors.New("down")
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	reports := make(chan Report)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(ctx, c, time.Millisecond, func(r Report) {
+			select {
+			case reports <- r:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	assert.False(t, (<-reports).Ready(), "checks run right away")
+
+	healthy.Store(true)
+	<-reports // the report that may have been taken before the change
+	assert.True(t, (<-reports).Ready())
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"sync/atomic"
+
+	"fightbettr.com/pkg/discovery"
+	logs "fightbettr.com/pkg/logger"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// RegistryReporter returns a reporter that reports the healthy state of the service instance to the registry
+// while the service is ready. Once a check fails the reports stop, so the registry, e.g. the TTL check
+// of Consul, takes the instance out of the service addresses until it is ready again.
+// Changes of the readiness are logged.
+func RegistryReporter(registry discovery.Registry, instanceID string, serviceName string) func(Report) {
+	// ready is 0 before the first report, then 1 while ready and 2 while not ready.
+	var ready atomic.Int32
+
+	return func(r Report) {
+		state := int32(2)
+		if r.Ready() {
+			state = 1
+		}
+
+		if prev := ready.Swap(state); prev != state {
+			if state == 1 {
+				logs.Infow("Service is ready", "service", serviceName, "instance", instanceID)
+			} else {
+				logs.Warnw("Service is not ready", "service", serviceName, "instance", instanceID, "error", r.Err())
+			}
+		}
+
+		if !r.Ready() {
+			return
+		}
+
+		if err := registry.ReportHealthyState(instanceID, serviceName); err != nil {
+			logs.Errorw("Failed to report healthy state", "service", serviceName, "error", err)
+		}
+	}
+}
+
+// GRPCReporter returns a reporter that sets the serving status of the overall server and of the given
+// services of the grpc.health.v1 health server: SERVING while the service is ready and NOT_SERVING otherwise.
+func GRPCReporter(srv *grpchealth.Server, services ...string) func(Report) {
+	return func(r Report) {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !r.Ready() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+
+		srv.SetServingStatus("", status)
+		for _, s := range services {
+			srv.SetServingStatus(s, status)
+		}
+	}
+}
+
+// NewGRPCServer creates a grpc.health.v1 health server that reports the overall server and
+// the given services as NOT_SERVING until the first report of GRPCReporter.
+func NewGRPCServer(services ...string) *grpchealth.Server {
+	srv := grpchealth.NewServer()
+
+	srv.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	for _, s := range services {
+		srv.SetServingStatus(s, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+
+	return srv
+}
@@ -0,0 +1,63 @@
+package httplib
+
+import "net/http"
+
+// ResponseRecorder wraps a http.ResponseWriter and records the status code and the size of the response,
+// e.g. for access logs and metrics.
+type ResponseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int
+}
+
+// NewResponseRecorder creates a recorder of the responses written to w.
+func NewResponseRecorder(w http.ResponseWriter) *ResponseRecorder {
+	return &ResponseRecorder{ResponseWriter: w}
+}
+
+// WriteHeader records the status code and writes it.
+func (r *ResponseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Write records the size of the written body and writes it.
+func (r *ResponseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	n, err := r.ResponseWriter.Write(b)
+	r.size += n
+
+	return n, err
+}
+
+// Flush flushes the response if the underlying writer supports it.
+func (r *ResponseRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying writer, so http.ResponseController reaches its methods.
+func (r *ResponseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// Status returns the written status code, or 200 if the handler has written nothing.
+func (r *ResponseRecorder) Status() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+
+	return r.status
+}
+
+// Size returns the number of the written bytes of the body.
+func (r *ResponseRecorder) Size() int {
+	return r.size
+}
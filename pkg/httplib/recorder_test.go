@@ -0,0 +1,64 @@
+package httplib
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseRecorder(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantStatus int
+		wantSize   int
+	}{
+		{
+			name:       "nothing written",
+			handler:    func(w http.ResponseWriter, r *http.Request) {},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "body without status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("hello"))
+				w.Write([]byte(" world"))
+			},
+			wantStatus: http.StatusOK,
+			wantSize:   11,
+		},
+		{
+			name: "status and body",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				ErrorResponseJSON(w, http.StatusNotFound, 404, errors.New("not found"))
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name: "status written twice",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantStatus: http.StatusCreated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			rec := NewResponseRecorder(w)
+
+			tt.handler(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Status())
+			assert.Equal(t, w.Body.Len(), rec.Size())
+			if tt.wantSize > 0 {
+				assert.Equal(t, tt.wantSize, rec.Size())
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package ipaddr
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ClientIP returns the IP address of the client of the request. Behind Cloudflare, with trustProxy,
+// it is the address of the CF-Connecting-IP header; otherwise it is the remote address of the connection.
+// The header is trusted only behind a proxy that sets it, since any client can send it.
+func ClientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if ip := net.ParseIP(strings.TrimSpace(r.Header.Get(CFConnectingIp))); ip != nil {
+			return ip.String()
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,37 @@
+package ipaddr
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteAddr string
+		cfIP       string
+		trustProxy bool
+		want       string
+	}{
+		{name: "remote address", remoteAddr: "10.0.0.1:5123", want: "10.0.0.1"},
+		{name: "IPv6 remote address", remoteAddr: "[::1]:5123", want: "::1"},
+		{name: "remote address without port", remoteAddr: "10.0.0.1", want: "10.0.0.1"},
+		{name: "CF-Connecting-IP", remoteAddr: "10.0.0.1:5123", cfIP: "203.0.113.7", trustProxy: true, want: "203.0.113.7"},
+		{name: "untrusted CF-Connecting-IP", remoteAddr: "10.0.0.1:5123", cfIP: "203.0.113.7", want: "10.0.0.1"},
+		{name: "invalid CF-Connecting-IP", remoteAddr: "10.0.0.1:5123", cfIP: "not an ip", trustProxy: true, want: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			r.RemoteAddr = tt.remoteAddr
+			if tt.cfIP != "" {
+				r.Header.Set(CFConnectingIp, tt.cfIP)
+			}
+
+			assert.Equal(t, tt.want, ClientIP(r, tt.trustProxy))
+		})
+	}
+}
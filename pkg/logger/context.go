@@ -0,0 +1,43 @@
+package logs
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// fieldsKey is the context key of the log fields added by With.
+type fieldsKey struct{}
+
+// With returns a copy of the context with the given key-value pairs added to the fields
+// of the logger returned by Ctx, e.g. With(ctx, "request_id", id).
+func With(ctx context.Context, keysAndValues ...any) context.Context {
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+
+	merged := make([]any, 0, len(fields)+len(keysAndValues))
+	merged = append(merged, fields...)
+	merged = append(merged, keysAndValues...)
+
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// Ctx returns the global logger with the fields added to the context by With and the trace_id and span_id
+// fields of the span of the context, so the logs of a request can be found by the request and its trace.
+// Without fields and a span in the context it returns the global logger as is.
+func Ctx(ctx context.Context) *zap.SugaredLogger {
+	// The global logger skips a caller frame for the functions of this package,
+	// while the returned logger is called directly.
+	l := zap.S().WithOptions(zap.AddCallerSkip(-1))
+
+	if fields, ok := ctx.Value(fieldsKey{}).([]any); ok {
+		l = l.With(fields...)
+	}
+
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+
+	return l.With("trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+}
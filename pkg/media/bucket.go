@@ -0,0 +1,108 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when the object or the image does not exist.
+var ErrNotFound = errors.New("media: not found")
+
+// Bucket is the storage of the media objects addressed by slash-separated keys.
+// DirBucket keeps them in a local directory; an object store is plugged in by implementing Bucket.
+type Bucket interface {
+	// Get returns the object with the key, or ErrNotFound.
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Put stores the object with the key, replacing the existing one.
+	Put(ctx context.Context, key string, data []byte) error
+	// Exists reports whether the object with the key exists.
+	Exists(ctx context.Context, key string) (bool, error)
+}
+
+// DirBucket is a Bucket that keeps the objects as files of a local directory.
+type DirBucket struct {
+	dir string
+}
+
+// NewDirBucket creates a bucket in the directory. The directory is created on the first write.
+func NewDirBucket(dir string) *DirBucket {
+	return &DirBucket{dir: dir}
+}
+
+// path returns the file path of the object with the key.
+func (b *DirBucket) path(key string) (string, error) {
+	clean := filepath.Clean(filepath.FromSlash(key))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") {
+		return "", errors.New("media: invalid key " + key)
+	}
+
+	return filepath.Join(b.dir, clean), nil
+}
+
+// Get returns the object with the key, or ErrNotFound.
+func (b *DirBucket) Get(ctx context.Context, key string) ([]byte, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+
+	return data, err
+}
+
+// Put stores the object with the key. The file is written to a temporary file first and renamed,
+// so readers never see a partially written object.
+func (b *DirBucket) Put(ctx context.Context, key string, data []byte) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// Exists reports whether the object with the key exists.
+func (b *DirBucket) Exists(ctx context.Context, key string) (bool, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
@@ -0,0 +1,245 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"sync"
+)
+
+// Size is the size of a fighter image.
+type Size string
+
+// Available image sizes. SizeOriginal is the downloaded image as is, the other sizes are JPEG
+// thumbnails that fit into a square with the side of SizePixels pixels.
+const (
+	SizeSmall    Size = "small"
+	SizeMedium   Size = "medium"
+	SizeLarge    Size = "large"
+	SizeOriginal Size = "original"
+)
+
+// SizePixels maps the thumbnail sizes to the side of the square the thumbnail fits into.
+var SizePixels = map[Size]int{
+	SizeSmall:  96,
+	SizeMedium: 256,
+	SizeLarge:  512,
+}
+
+// thumbnailQuality is the JPEG quality of the thumbnails.
+const thumbnailQuality = 85
+
+// ParseSize returns the size with the given name.
+func ParseSize(name string) (Size, error) {
+	size := Size(name)
+	if _, ok := SizePixels[size]; ok || size == SizeOriginal {
+		return size, nil
+	}
+
+	return "", fmt.Errorf("media: unknown size '%s'", name)
+}
+
+// Image is a stored image of a single size.
+type Image struct {
+	Data        []byte
+	ContentType string
+	// ETag identifies the content of the image; it is the same for every copy of the same image.
+	ETag string
+}
+
+// Ref links a fighter to the hash of its image. Source is the image url without the query string,
+// so an image that is re-signed by the site is not downloaded again.
+type Ref struct {
+	Hash   string `json:"hash"`
+	Source string `json:"source"`
+}
+
+// Store is a content-addressed store of fighter images. Every image is kept once under the SHA-256 hash
+// of its content together with its thumbnails, and fighters are linked to the hash of their image.
+//
+// Keys of the bucket:
+//
+//	originals/ab/abcdef...           the downloaded image
+//	thumbs/<size>/ab/abcdef....jpg   the thumbnails
+//	fighters/12/1234...              the Ref of the fighter, by the hash of the fighter key
+type Store struct {
+	bucket Bucket
+
+	placeholders sync.Map
+}
+
+// New creates a store in the bucket.
+func New(bucket Bucket) *Store {
+	return &Store{bucket: bucket}
+}
+
+// Hash returns the content hash of the data the image is stored under.
+func Hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// originalKey returns the bucket key of the image with the hash.
+func originalKey(hash string) string {
+	return "originals/" + hash[:2] + "/" + hash
+}
+
+// thumbnailKey returns the bucket key of the thumbnail of the image with the hash.
+func thumbnailKey(hash string, size Size) string {
+	return "thumbs/" + string(size) + "/" + hash[:2] + "/" + hash + ".jpg"
+}
+
+// refKey returns the bucket key of the Ref of the fighter.
+func refKey(fighterKey string) string {
+	hash := Hash([]byte(fighterKey))
+	return "fighters/" + hash[:2] + "/" + hash
+}
+
+// Put stores the image with its thumbnails and returns its hash. An image that is already stored
+// is not written again. Put returns an error if the data is not a GIF, JPEG or PNG image.
+func (s *Store) Put(ctx context.Context, data []byte) (string, error) {
+	hash := Hash(data)
+
+	exists, err := s.bucket.Exists(ctx, originalKey(hash))
+	if err != nil {
+		return "", err
+	}
+
+	if exists {
+		return hash, nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("media: decode image: %w", err)
+	}
+
+	// The thumbnails are written before the original, so an image that exists has all of its thumbnails.
+	for size, px := range SizePixels {
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, thumbnail(img, px), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+			return "", err
+		}
+
+		if err := s.bucket.Put(ctx, thumbnailKey(hash, size), buf.Bytes()); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.bucket.Put(ctx, originalKey(hash), data); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// Link links the fighter with the key to the image.
+func (s *Store) Link(ctx context.Context, fighterKey string, ref Ref) error {
+	data, err := json.Marshal(ref)
+	if err != nil {
+		return err
+	}
+
+	return s.bucket.Put(ctx, refKey(fighterKey), data)
+}
+
+// Lookup returns the image linked to the fighter with the key, or ErrNotFound.
+func (s *Store) Lookup(ctx context.Context, fighterKey string) (Ref, error) {
+	data, err := s.bucket.Get(ctx, refKey(fighterKey))
+	if err != nil {
+		return Ref{}, err
+	}
+
+	var ref Ref
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return Ref{}, fmt.Errorf("media: ref of %s: %w", fighterKey, err)
+	}
+
+	return ref, nil
+}
+
+// Image returns the image with the hash in the given size, or ErrNotFound.
+func (s *Store) Image(ctx context.Context, hash string, size Size) (Image, error) {
+	if len(hash) != sha256.Size*2 {
+		return Image{}, ErrNotFound
+	}
+
+	if size == SizeOriginal {
+		data, err := s.bucket.Get(ctx, originalKey(hash))
+		if err != nil {
+			return Image{}, err
+		}
+
+		return Image{Data: data, ContentType: http.DetectContentType(data), ETag: etag(hash, size)}, nil
+	}
+
+	if _, ok := SizePixels[size]; !ok {
+		return Image{}, ErrNotFound
+	}
+
+	data, err := s.bucket.Get(ctx, thumbnailKey(hash, size))
+	if err != nil {
+		return Image{}, err
+	}
+
+	return Image{Data: data, ContentType: "image/jpeg", ETag: etag(hash, size)}, nil
+}
+
+// FighterImage returns the image of the fighter with the key in the given size, or ErrNotFound.
+func (s *Store) FighterImage(ctx context.Context, fighterKey string, size Size) (Image, error) {
+	ref, err := s.Lookup(ctx, fighterKey)
+	if err != nil {
+		return Image{}, err
+	}
+
+	return s.Image(ctx, ref.Hash, size)
+}
+
+// etag returns the entity tag of the image with the hash in the given size.
+func etag(hash string, size Size) string {
+	return fmt.Sprintf(`"%s-%s"`, hash[:16], size)
+}
+
+// placeholderColor is the color of the placeholder images.
+var placeholderColor = color.RGBA{R: 0xd9, G: 0xd9, B: 0xd9, A: 0xff}
+
+// Placeholder returns the image used when no image of a fighter is available: a plain square JPEG
+// of the given size. The original size gets the placeholder of the largest thumbnail.
+func (s *Store) Placeholder(size Size) Image {
+	px, ok := SizePixels[size]
+	if !ok {
+		size, px = SizeLarge, SizePixels[SizeLarge]
+	}
+
+	if img, ok := s.placeholders.Load(size); ok {
+		return img.(Image)
+	}
+
+	canvas := image.NewRGBA(image.Rect(0, 0, px, px))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: placeholderColor}, image.Point{}, draw.Src)
+
+	var buf bytes.Buffer
+	// Encoding of an in-memory RGBA image does not fail.
+	_ = jpeg.Encode(&buf, canvas, &jpeg.Options{Quality: thumbnailQuality})
+
+	img := Image{Data: buf.Bytes(), ContentType: "image/jpeg", ETag: fmt.Sprintf(`"placeholder-%s"`, size)}
+	s.placeholders.Store(size, img)
+
+	return img
+}
+
+// IsNotFound reports whether the error means that the image does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
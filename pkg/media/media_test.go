@@ -0,0 +1,176 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPNG returns a PNG image of the given size: a red square with a transparent border.
+func testPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := h / 4; y < h*3/4; y++ {
+		for x := w / 4; x < w*3/4; x++ {
+			img.Set(x, y, color.NRGBA{R: 0xff, A: 0xff})
+		}
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+
+	return buf.Bytes()
+}
+
+func TestStorePut(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	s := New(NewDirBucket(dir))
+
+	data := testPNG(t, 400, 1000)
+
+	hash, err := s.Put(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, Hash(data), hash)
+
+	original, err := s.Image(ctx, hash, SizeOriginal)
+	require.NoError(t, err)
+	assert.Equal(t, data, original.Data)
+	assert.Equal(t, "image/png", original.ContentType)
+
+	tests := []struct {
+		size   Size
+		width  int
+		height int
+	}{
+		{size: SizeSmall, width: 38, height: 96},
+		{size: SizeMedium, width: 102, height: 256},
+		{size: SizeLarge, width: 204, height: 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.size), func(t *testing.T) {
+			img, err := s.Image(ctx, hash, tt.size)
+			require.NoError(t, err)
+			assert.Equal(t, "image/jpeg", img.ContentType)
+			assert.Equal(t, `"`+hash[:16]+`-`+string(tt.size)+`"`, img.ETag)
+
+			decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+			require.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, tt.width, tt.height), decoded.Bounds())
+
+			// The transparent border is white and the center keeps its color.
+			r, g, b, _ := decoded.At(0, 0).RGBA()
+			assert.Greater(t, r>>8, uint32(0xf0))
+			assert.Greater(t, g>>8, uint32(0xf0))
+			assert.Greater(t, b>>8, uint32(0xf0))
+
+			r, g, _, _ = decoded.At(tt.width/2, tt.height/2).RGBA()
+			assert.Greater(t, r>>8, uint32(0xf0))
+			assert.Less(t, g>>8, uint32(0x20))
+		})
+	}
+
+	// The same image is stored once.
+	again, err := s.Put(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, hash, again)
+
+	originals, err := filepath.Glob(filepath.Join(dir, "originals", "*", "*"))
+	require.NoError(t, err)
+	assert.Len(t, originals, 1)
+}
+
+func TestStorePutInvalid(t *testing.T) {
+	s := New(NewDirBucket(t.TempDir()))
+
+	_, err := s.Put(context.Background(), []byte("<html>Access denied</html>"))
+	assert.Error(t, err)
+}
+
+func TestStoreLink(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewDirBucket(t.TempDir()))
+
+	hash, err := s.Put(ctx, testPNG(t, 10, 10))
+	require.NoError(t, err)
+
+	fighterUrl := "https://www.ufc.com/athlete/jon-jones"
+
+	_, err = s.Lookup(ctx, fighterUrl)
+	assert.True(t, IsNotFound(err))
+
+	_, err = s.FighterImage(ctx, fighterUrl, SizeSmall)
+	assert.True(t, IsNotFound(err))
+
+	ref := Ref{Hash: hash, Source: "https://dmxg5wxfqgb4u.cloudfront.net/styles/athlete_bio_full_body/jones.png"}
+	require.NoError(t, s.Link(ctx, fighterUrl, ref))
+
+	got, err := s.Lookup(ctx, fighterUrl)
+	require.NoError(t, err)
+	assert.Equal(t, ref, got)
+
+	img, err := s.FighterImage(ctx, fighterUrl, SizeSmall)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", img.ContentType)
+
+	// An image smaller than the thumbnail is not scaled up.
+	decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 10, 10), decoded.Bounds())
+}
+
+func TestStoreImageNotFound(t *testing.T) {
+	ctx := context.Background()
+	s := New(NewDirBucket(t.TempDir()))
+
+	for _, hash := range []string{"", "../../etc/passwd", Hash([]byte("missing"))} {
+		_, err := s.Image(ctx, hash, SizeMedium)
+		assert.True(t, IsNotFound(err), hash)
+	}
+}
+
+func TestPlaceholder(t *testing.T) {
+	s := New(NewDirBucket(t.TempDir()))
+
+	img := s.Placeholder(SizeMedium)
+	assert.Equal(t, `"placeholder-medium"`, img.ETag)
+
+	decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 256, 256), decoded.Bounds())
+
+	assert.Equal(t, `"placeholder-large"`, s.Placeholder(SizeOriginal).ETag)
+	assert.Equal(t, img, s.Placeholder(SizeMedium))
+}
+
+func TestParseSize(t *testing.T) {
+	for _, name := range []string{"small", "medium", "large", "original"} {
+		size, err := ParseSize(name)
+		require.NoError(t, err)
+		assert.Equal(t, Size(name), size)
+	}
+
+	_, err := ParseSize("huge")
+	assert.Error(t, err)
+}
+
+func TestDirBucketInvalidKey(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	b := NewDirBucket(filepath.Join(dir, "bucket"))
+
+	assert.Error(t, b.Put(ctx, "../escape", []byte("x")))
+	_, err := os.Stat(filepath.Join(dir, "escape"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,69 @@
+package media
+
+import (
+	"image"
+	"image/color"
+)
+
+// thumbnail scales the image down to fit into a square with the given side in pixels, keeping the aspect ratio.
+// Every pixel of the thumbnail is the average of the source pixels it covers, composited over a white
+// background since the thumbnails are JPEG images. Images that already fit are not scaled.
+func thumbnail(src image.Image, side int) image.Image {
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	tw, th := w, h
+	if w > side || h > side {
+		if w >= h {
+			tw, th = side, h*side/w
+		} else {
+			tw, th = w*side/h, side
+		}
+	}
+
+	if tw < 1 {
+		tw = 1
+	}
+
+	if th < 1 {
+		th = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+
+	for y := 0; y < th; y++ {
+		y0 := b.Min.Y + y*h/th
+		y1 := b.Min.Y + (y+1)*h/th
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+
+		for x := 0; x < tw; x++ {
+			x0 := b.Min.X + x*w/tw
+			x1 := b.Min.X + (x+1)*w/tw
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			// The colors are premultiplied, so adding the transparency composites the pixel over white.
+			bg := 0xffff - a/n
+			dst.SetRGBA64(x, y, color.RGBA64{
+				R: uint16(r/n + bg),
+				G: uint16(g/n + bg),
+				B: uint16(bl/n + bg),
+				A: 0xffff,
+			})
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,118 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(HTTPMiddleware)
+	router.HandleFunc("/media/fighters/{id:[0-9]+}/{size}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("image"))
+	}).Methods(http.MethodGet)
+	router.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}).Methods(http.MethodPost)
+
+	httpRequests.Reset()
+	httpDuration.Reset()
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/media/fighters/1/small", nil),
+		httptest.NewRequest(http.MethodGet, "/media/fighters/2/large", nil),
+		httptest.NewRequest(http.MethodPost, "/login", nil),
+	} {
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodGet, "/media/fighters/{id:[0-9]+}/{size}", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(httpRequests.WithLabelValues(http.MethodPost, "/login", "401")))
+	assert.Equal(t, 2, testutil.CollectAndCount(httpDuration))
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	grpcHandled.Reset()
+	grpcDuration.Reset()
+
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/AuthService/Login"}
+
+	ok := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+	denied := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+	failed := func(ctx context.Context, req any) (any, error) { return nil, errors.New("db is down") }
+
+	for _, h := range []grpc.UnaryHandler{ok, ok, denied, failed} {
+		interceptor(context.Background(), nil, info, h)
+	}
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(grpcHandled.WithLabelValues("AuthService", "Login", "OK")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(grpcHandled.WithLabelValues("AuthService", "Login", "PermissionDenied")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(grpcHandled.WithLabelValues("AuthService", "Login", "Unknown")))
+	assert.Equal(t, 1, testutil.CollectAndCount(grpcDuration))
+}
+
+func TestSplitMethod(t *testing.T) {
+	tests := []struct {
+		fullMethod  string
+		wantService string
+		wantMethod  string
+	}{
+		{fullMethod: "/AuthService/Login", wantService: "AuthService", wantMethod: "Login"},
+		{fullMethod: "/grpc.health.v1.Health/Check", wantService: "grpc.health.v1.Health", wantMethod: "Check"},
+		{fullMethod: "Login", wantService: "unknown", wantMethod: "Login"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fullMethod, func(t *testing.T) {
+			service, method := splitMethod(tt.fullMethod)
+			assert.Equal(t, tt.wantService, service)
+			assert.Equal(t, tt.wantMethod, method)
+		})
+	}
+}
+
+func TestPoolCollector(t *testing.T) {
+	c := newPoolCollector("main", func() PoolStats {
+		return PoolStats{AcquireCount: 42, AcquireDuration: 1.5, AcquiredConns: 3, IdleConns: 1, TotalConns: 4, MaxConns: 10}
+	})
+
+	expected := `
+# HELP fightbettr_pgxpool_acquire_total Number of successful acquires of a connection from the pool.
+# TYPE fightbettr_pgxpool_acquire_total counter
+fightbettr_pgxpool_acquire_total{pool="main"} 42
+# HELP fightbettr_pgxpool_acquired_conns Number of currently acquired connections.
+# TYPE fightbettr_pgxpool_acquired_conns gauge
+fightbettr_pgxpool_acquired_conns{pool="main"} 3
+# HELP fightbettr_pgxpool_max_conns Maximum size of the pool.
+# TYPE fightbettr_pgxpool_max_conns gauge
+fightbettr_pgxpool_max_conns{pool="main"} 10
+`
+	require.NoError(t, testutil.CollectAndCompare(c, strings.NewReader(expected),
+		"fightbettr_pgxpool_acquire_total", "fightbettr_pgxpool_acquired_conns", "fightbettr_pgxpool_max_conns"))
+	assert.Equal(t, 9, testutil.CollectAndCount(c))
+}
+
+func TestHandler(t *testing.T) {
+	httpRequests.WithLabelValues(http.MethodGet, "/events", "200").Inc()
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `fightbettr_http_requests_total{code="200",method="GET",route="/events"}`)
+}
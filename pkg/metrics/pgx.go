@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PoolStats is a snapshot of the statistics of a connection pool.
+type PoolStats struct {
+	AcquireCount         int64
+	AcquireDuration      float64
+	CanceledAcquireCount int64
+	EmptyAcquireCount    int64
+	AcquiredConns        int32
+	ConstructingConns    int32
+	IdleConns            int32
+	TotalConns           int32
+	MaxConns             int32
+}
+
+// pgxPoolStats returns the statistics of the pool.
+func pgxPoolStats(pool *pgxpool.Pool) func() PoolStats {
+	return func() PoolStats {
+		s := pool.Stat()
+
+		return PoolStats{
+			AcquireCount:         s.AcquireCount(),
+			AcquireDuration:      s.AcquireDuration().Seconds(),
+			CanceledAcquireCount: s.CanceledAcquireCount(),
+			EmptyAcquireCount:    s.EmptyAcquireCount(),
+			AcquiredConns:        s.AcquiredConns(),
+			ConstructingConns:    s.ConstructingConns(),
+			IdleConns:            s.IdleConns(),
+			TotalConns:           s.TotalConns(),
+			MaxConns:             s.MaxConns(),
+		}
+	}
+}
+
+// poolCollector collects the statistics of a connection pool when the metrics are scraped.
+type poolCollector struct {
+	stats func() PoolStats
+
+	acquireCount         *prometheus.Desc
+	acquireDuration      *prometheus.Desc
+	canceledAcquireCount *prometheus.Desc
+	emptyAcquireCount    *prometheus.Desc
+	acquiredConns        *prometheus.Desc
+	constructingConns    *prometheus.Desc
+	idleConns            *prometheus.Desc
+	totalConns           *prometheus.Desc
+	maxConns             *prometheus.Desc
+}
+
+// newPoolCollector creates a collector of the pool statistics labelled with the pool name.
+func newPoolCollector(name string, stats func() PoolStats) *poolCollector {
+	desc := func(metric, help string) *prometheus.Desc {
+		return prometheus.NewDesc(
+			prometheus.BuildFQName(Namespace, "pgxpool", metric),
+			help, nil, prometheus.Labels{"pool": name},
+		)
+	}
+
+	return &poolCollector{
+		stats: stats,
+
+		acquireCount:         desc("acquire_total", "Number of successful acquires of a connection from the pool."),
+		acquireDuration:      desc("acquire_duration_seconds_total", "Total time spent on successful acquires of a connection."),
+		canceledAcquireCount: desc("canceled_acquire_total", "Number of acquires canceled by a context."),
+		emptyAcquireCount:    desc("empty_acquire_total", "Number of acquires that waited for a connection because the pool was empty."),
+		acquiredConns:        desc("acquired_conns", "Number of currently acquired connections."),
+		constructingConns:    desc("constructing_conns", "Number of connections being established."),
+		idleConns:            desc("idle_conns", "Number of currently idle connections."),
+		totalConns:           desc("total_conns", "Total number of connections of the pool."),
+		maxConns:             desc("max_conns", "Maximum size of the pool."),
+	}
+}
+
+// Describe sends the descriptors of the pool metrics.
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.acquireCount
+	ch <- c.acquireDuration
+	ch <- c.canceledAcquireCount
+	ch <- c.emptyAcquireCount
+	ch <- c.acquiredConns
+	ch <- c.constructingConns
+	ch <- c.idleConns
+	ch <- c.totalConns
+	ch <- c.maxConns
+}
+
+// Collect sends the current statistics of the pool.
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+
+	ch <- prometheus.MustNewConstMetric(c.acquireCount, prometheus.CounterValue, float64(s.AcquireCount))
+	ch <- prometheus.MustNewConstMetric(c.acquireDuration, prometheus.CounterValue, s.AcquireDuration)
+	ch <- prometheus.MustNewConstMetric(c.canceledAcquireCount, prometheus.CounterValue, float64(s.CanceledAcquireCount))
+	ch <- prometheus.MustNewConstMetric(c.emptyAcquireCount, prometheus.CounterValue, float64(s.EmptyAcquireCount))
+	ch <- prometheus.MustNewConstMetric(c.acquiredConns, prometheus.GaugeValue, float64(s.AcquiredConns))
+	ch <- prometheus.MustNewConstMetric(c.constructingConns, prometheus.GaugeValue, float64(s.ConstructingConns))
+	ch <- prometheus.MustNewConstMetric(c.idleConns, prometheus.GaugeValue, float64(s.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.totalConns, prometheus.GaugeValue, float64(s.TotalConns))
+	ch <- prometheus.MustNewConstMetric(c.maxConns, prometheus.GaugeValue, float64(s.MaxConns))
+}
+
+// RegisterPgxPool registers the collector of the statistics of the pool (pgxpool.Stat) with the given name.
+func RegisterPgxPool(name string, pool *pgxpool.Pool) error {
+	return prometheus.Register(newPoolCollector(name, pgxPoolStats(pool)))
+}
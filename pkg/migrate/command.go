@@ -0,0 +1,131 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/pgxs"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the migrate command with the up, down, status and create subcommands.
+// The config function is called on execution, after the service configuration has been read.
+func Command(service string, migrations fs.FS, config func() *pgxs.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "migrate",
+		Short:        "Manages database schema migrations",
+		Long:         ``,
+		SilenceUsage: true,
+	}
+
+	upCmd := &cobra.Command{
+		Use:   "up",
+		Short: "Applies pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, _ := cmd.Flags().GetInt("steps")
+
+			return withMigrator(service, migrations, config, func(ctx context.Context, m *Migrator) error {
+				done, err := m.Up(ctx, steps)
+				for _, mig := range done {
+					fmt.Printf("Applied: %d_%s\n", mig.Version, mig.Name)
+				}
+				if err == nil && len(done) == 0 {
+					fmt.Println("No pending migrations")
+				}
+
+				return err
+			})
+		},
+	}
+	upCmd.Flags().Int("steps", 0, "Number of migrations to apply (default is all pending)")
+
+	downCmd := &cobra.Command{
+		Use:   "down",
+		Short: "Reverts applied migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			steps, _ := cmd.Flags().GetInt("steps")
+
+			return withMigrator(service, migrations, config, func(ctx context.Context, m *Migrator) error {
+				done, err := m.Down(ctx, steps)
+				for _, mig := range done {
+					fmt.Printf("Reverted: %d_%s\n", mig.Version, mig.Name)
+				}
+
+				return err
+			})
+		},
+	}
+	downCmd.Flags().Int("steps", 1, "Number of migrations to revert")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows applied and pending migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withMigrator(service, migrations, config, func(ctx context.Context, m *Migrator) error {
+				statuses, err := m.Status(ctx)
+				if err != nil {
+					return err
+				}
+
+				for _, s := range statuses {
+					state := "pending"
+					if s.Applied {
+						state = "applied " + time.Unix(s.AppliedAt, 0).UTC().Format(time.RFC3339)
+					}
+
+					fmt.Printf("%d_%s\t%s\n", s.Version, s.Name, state)
+				}
+
+				return nil
+			})
+		},
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Creates a new pair of up and down migration files",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir, _ := cmd.Flags().GetString("dir")
+
+			up, down, err := Create(dir, args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("Created: %s\nCreated: %s\n", up, down)
+
+			return nil
+		},
+	}
+	createCmd.Flags().String("dir", "./migrations", "Directory of the service migrations")
+
+	cmd.AddCommand(upCmd, downCmd, statusCmd, createCmd)
+
+	return cmd
+}
+
+// withMigrator opens a database connection, creates a Migrator and passes it to fn.
+func withMigrator(service string, migrations fs.FS, config func() *pgxs.Config, fn func(ctx context.Context, m *Migrator) error) error {
+	ctx := context.Background()
+
+	repo, err := pgxs.NewPool(ctx, config())
+	if err != nil {
+		logs.Errorf("Unable to start postgresql connection: %s", err)
+		return err
+	}
+	defer repo.GracefulShutdown()
+
+	m, err := New(repo, service, migrations)
+	if err != nil {
+		return err
+	}
+
+	return fn(ctx, m)
+}
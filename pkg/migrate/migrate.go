@@ -0,0 +1,324 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	logs "fightbettr.com/pkg/logger"
+	"fightbettr.com/pkg/pgxs"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNoMigrations is returned when the migrations source contains no migration files.
+var ErrNoMigrations = errors.New("migrate: no migrations found")
+
+// fileRe matches migration file names like 20240801120000_create_fighters.up.sql.
+var fileRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+const createMigrationsTable = `CREATE TABLE IF NOT EXISTS public.schema_migrations (
+	service character varying(50) NOT NULL,
+	version bigint NOT NULL,
+	name character varying(255) NOT NULL,
+	applied_at bigint NOT NULL,
+	PRIMARY KEY (service, version)
+)`
+
+// Migration is a single versioned schema change with its up and down SQL.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt int64
+}
+
+// Migrator applies the migrations of a single service and tracks them in the schema_migrations table.
+// Several services may share one database, so every row is keyed by the service name.
+type Migrator struct {
+	pgxs.FbRepo
+	service    string
+	migrations []Migration
+}
+
+// New creates a Migrator for the service using the migration files found in fsys.
+func New(repo pgxs.FbRepo, service string, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		FbRepo:     repo,
+		service:    service,
+		migrations: migrations,
+	}, nil
+}
+
+// Load reads the migrations from the root of fsys. Every version must have both an up and a down file.
+// The migrations are returned in ascending order of version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+
+	for _, e := range entries {
+		m := fileRe.FindStringSubmatch(e.Name())
+		if e.IsDir() || m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: %s: %w", e.Name(), err)
+		}
+
+		data, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrate: version %d has different names '%s' and '%s'", version, mig.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mig.Up = string(data)
+		} else {
+			mig.Down = string(data)
+		}
+	}
+
+	if len(byVersion) == 0 {
+		return nil, ErrNoMigrations
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" || mig.Down == "" {
+			return nil, fmt.Errorf("migrate: version %d must have both up and down files", mig.Version)
+		}
+
+		migrations = append(migrations, *mig)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies up to steps pending migrations in ascending order, or all of them if steps is not positive.
+// Every migration runs in its own transaction together with its schema_migrations row.
+// It returns the applied migrations.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+
+	for _, mig := range m.migrations {
+		if steps > 0 && len(done) == steps {
+			break
+		}
+
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+
+		ok, err := m.apply(ctx, mig, true)
+		if err != nil {
+			return done, fmt.Errorf("migrate: %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+
+		if ok {
+			logs.Infof("Migration %d_%s applied", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+	}
+
+	return done, nil
+}
+
+// Down reverts up to steps applied migrations in descending order. Steps lower than 1 revert a single migration.
+// It returns the reverted migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		steps = 1
+	}
+
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+
+		ok, err := m.apply(ctx, mig, false)
+		if err != nil {
+			return done, fmt.Errorf("migrate: %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+
+		if ok {
+			logs.Infof("Migration %d_%s reverted", mig.Version, mig.Name)
+			done = append(done, mig)
+		}
+	}
+
+	return done, nil
+}
+
+// Status returns every known migration with the time it was applied, if it was.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(m.migrations))
+	for i, mig := range m.migrations {
+		at, ok := applied[mig.Version]
+		statuses[i] = Status{Migration: mig, Applied: ok, AppliedAt: at}
+	}
+
+	return statuses, nil
+}
+
+// Create writes an empty pair of up and down migration files named after the current UTC time into dir.
+// It returns the paths of the created files.
+func Create(dir, name string) (string, string, error) {
+	if !regexp.MustCompile(`^[a-z0-9_]+$`).MatchString(name) {
+		return "", "", fmt.Errorf("migrate: name '%s' must contain only lowercase letters, digits and underscores", name)
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%s_%s", time.Now().UTC().Format("20060102150405"), name))
+	up, down := base+".up.sql", base+".down.sql"
+
+	for _, path := range []string{up, down} {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return "", "", err
+		}
+
+		if _, err := f.WriteString("-- " + filepath.Base(path) + "\n"); err != nil {
+			f.Close()
+			return "", "", err
+		}
+
+		if err := f.Close(); err != nil {
+			return "", "", err
+		}
+	}
+
+	return up, down, nil
+}
+
+// applied ensures the schema_migrations table exists and returns the applied versions of the service.
+func (m *Migrator) applied(ctx context.Context) (map[int64]int64, error) {
+	if _, err := m.GetPool().Exec(ctx, createMigrationsTable); err != nil {
+		return nil, m.DebugLogSqlErr(createMigrationsTable, err)
+	}
+
+	q := `SELECT version, applied_at FROM public.schema_migrations WHERE service = $1`
+
+	rows, err := m.GetPool().Query(ctx, q, m.service)
+	if err != nil {
+		return nil, m.DebugLogSqlErr(q, err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]int64)
+
+	for rows.Next() {
+		var version, at int64
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, m.DebugLogSqlErr(q, err)
+		}
+
+		applied[version] = at
+	}
+
+	return applied, rows.Err()
+}
+
+// apply runs the up or down SQL of a migration and records it in a single transaction.
+// A transaction-scoped advisory lock serializes concurrent migrators of the same service,
+// and the version is checked again under the lock. It reports whether the migration was run.
+func (m *Migrator) apply(ctx context.Context, mig Migration, up bool) (bool, error) {
+	tx, err := m.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.ReadCommitted,
+	})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, "schema_migrations:"+m.service); err != nil {
+		return false, err
+	}
+
+	var exists bool
+	q := `SELECT EXISTS (SELECT 1 FROM public.schema_migrations WHERE service = $1 AND version = $2)`
+	if err := tx.QueryRow(ctx, q, m.service, mig.Version).Scan(&exists); err != nil {
+		return false, m.DebugLogSqlErr(q, err)
+	}
+
+	if exists == up {
+		return false, nil
+	}
+
+	if up {
+		if _, err := tx.Exec(ctx, mig.Up); err != nil {
+			return false, err
+		}
+
+		q = `INSERT INTO public.schema_migrations (service, version, name, applied_at) VALUES ($1, $2, $3, $4)`
+		if _, err := tx.Exec(ctx, q, m.service, mig.Version, mig.Name, time.Now().Unix()); err != nil {
+			return false, m.DebugLogSqlErr(q, err)
+		}
+	} else {
+		if _, err := tx.Exec(ctx, mig.Down); err != nil {
+			return false, err
+		}
+
+		q = `DELETE FROM public.schema_migrations WHERE service = $1 AND version = $2`
+		if _, err := tx.Exec(ctx, q, m.service, mig.Version); err != nil {
+			return false, m.DebugLogSqlErr(q, err)
+		}
+	}
+
+	return true, tx.Commit(ctx)
+}
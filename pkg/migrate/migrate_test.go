@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	testCases := []struct {
+		Name          string
+		FS            fstest.MapFS
+		Expected      []Migration
+		ExpectedError string
+	}{
+		{
+			Name: "Sorted by version",
+			FS: fstest.MapFS{
+				"2_add_index.up.sql":      {Data: []byte("CREATE INDEX")},
+				"2_add_index.down.sql":    {Data: []byte("DROP INDEX")},
+				"1_create_table.up.sql":   {Data: []byte("CREATE TABLE")},
+				"1_create_table.down.sql": {Data: []byte("DROP TABLE")},
+				"README.md":               {Data: []byte("ignored")},
+			},
+			Expected: []Migration{
+				{Version: 1, Name: "create_table", Up: "CREATE TABLE", Down: "DROP TABLE"},
+				{Version: 2, Name: "add_index", Up: "CREATE INDEX", Down: "DROP INDEX"},
+			},
+		},
+		{
+			Name:          "Empty",
+			FS:            fstest.MapFS{},
+			ExpectedError: ErrNoMigrations.Error(),
+		},
+		{
+			Name: "Missing down",
+			FS: fstest.MapFS{
+				"1_create_table.up.sql": {Data: []byte("CREATE TABLE")},
+			},
+			ExpectedError: "version 1 must have both up and down files",
+		},
+		{
+			Name: "Different names",
+			FS: fstest.MapFS{
+				"1_create_table.up.sql": {Data: []byte("CREATE TABLE")},
+				"1_drop_table.down.sql": {Data: []byte("DROP TABLE")},
+			},
+			ExpectedError: "version 1 has different names",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			migrations, err := Load(tc.FS)
+
+			if tc.ExpectedError != "" {
+				assert.ErrorContains(t, err, tc.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.Expected, migrations)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "migrations")
+
+	up, down, err := Create(dir, "add_column")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(up, "_add_column.up.sql"))
+	assert.True(t, strings.HasSuffix(down, "_add_column.down.sql"))
+
+	_, err = os.Stat(up)
+	assert.NoError(t, err)
+
+	migrations, err := Load(os.DirFS(dir))
+	assert.NoError(t, err)
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, "add_column", migrations[0].Name)
+
+	_, _, err = Create(dir, "Invalid Name")
+	assert.Error(t, err)
+}
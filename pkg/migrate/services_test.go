@@ -0,0 +1,32 @@
+package migrate_test
+
+import (
+	"io/fs"
+	"testing"
+
+	authmigrations "fightbettr.com/auth/migrations"
+	eventsmigrations "fightbettr.com/events/migrations"
+	fightersmigrations "fightbettr.com/fighters/migrations"
+	"fightbettr.com/pkg/migrate"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceMigrations(t *testing.T) {
+	testCases := []struct {
+		Name string
+		FS   fs.FS
+	}{
+		{Name: authmigrations.Service, FS: authmigrations.FS},
+		{Name: eventsmigrations.Service, FS: eventsmigrations.FS},
+		{Name: fightersmigrations.Service, FS: fightersmigrations.FS},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			migrations, err := migrate.Load(tc.FS)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, migrations)
+		})
+	}
+}
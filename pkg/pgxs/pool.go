@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"fightbettr.com/pkg/tracing"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -30,7 +31,7 @@ func NewPool(ctx context.Context, conf *Config) (*Repo, error) {
 }
 
 // ConnectDBPool creates and returns a new pgxpool.Pool using the configured context and pgxpool.Config.
-// It uses the GetPoolConfig method to obtain the configuration.
+// It uses the GetPoolConfig method to obtain the configuration, and traces every query of the pool.
 // Returns the created pgxpool.Pool and an error if there is any issue.
 func (db *Repo) ConnectDBPool(ctx context.Context) (*pgxpool.Pool, error) {
 	conf, err := db.GetPoolConfig()
@@ -38,6 +39,7 @@ func (db *Repo) ConnectDBPool(ctx context.Context) (*pgxpool.Pool, error) {
 		return nil, fmt.Errorf("pgxs: Unable to prepare postgres config: %s", err)
 	}
 	// conf.ConnConfig.TLSConfig = tlsConfig
+	conf.ConnConfig.Tracer = tracing.QueryTracer{}
 
 	return pgxpool.NewWithConfig(ctx, conf)
 }
@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// Store kinds of the config.
+const (
+	StoreMemory = "memory"
+	StoreRedis  = "redis"
+)
+
+// Policy is the limits of a route: by the IP address of the client and by the logged in user.
+// A zero limit does not apply.
+type Policy struct {
+	IP   Limit
+	User Limit
+}
+
+// RedisConfig holds the connection settings of the Redis store.
+type RedisConfig struct {
+	Addr     string
+	Username string
+	Password string
+	DB       int
+	Prefix   string
+}
+
+// Config holds the rate limiting settings of a service.
+type Config struct {
+	// Enabled turns the limits on.
+	Enabled bool
+	// Store is the kind of the store of the buckets, StoreMemory or StoreRedis.
+	Store string
+	// Redis is the connection of the Redis store.
+	Redis RedisConfig
+	// FailOpen allows the requests when the store fails, instead of rejecting them.
+	FailOpen bool
+	// Routes are the policies by route name.
+	Routes map[string]Policy
+}
+
+// ViperConfig returns the rate limiting config based on the ratelimit values from viper.
+// The policies are read from ratelimit.routes.<route>.ip and ratelimit.routes.<route>.user,
+// written as requests/period, e.g. 10/1m.
+func ViperConfig() (Config, error) {
+	cfg := Config{
+		Enabled: viper.GetBool("ratelimit.enabled"),
+		Store:   viper.GetString("ratelimit.store"),
+		Redis: RedisConfig{
+			Addr:     viper.GetString("ratelimit.redis.addr"),
+			Username: viper.GetString("ratelimit.redis.username"),
+			Password: viper.GetString("ratelimit.redis.password"),
+			DB:       viper.GetInt("ratelimit.redis.db"),
+			Prefix:   viper.GetString("ratelimit.redis.prefix"),
+		},
+		FailOpen: viper.GetBool("ratelimit.fail_open"),
+		Routes:   make(map[string]Policy),
+	}
+
+	for route := range viper.GetStringMap("ratelimit.routes") {
+		var p Policy
+		var err error
+
+		if s := viper.GetString("ratelimit.routes." + route + ".ip"); s != "" {
+			if p.IP, err = ParseLimit(s); err != nil {
+				return Config{}, fmt.Errorf("route %s: %w", route, err)
+			}
+		}
+
+		if s := viper.GetString("ratelimit.routes." + route + ".user"); s != "" {
+			if p.User, err = ParseLimit(s); err != nil {
+				return Config{}, fmt.Errorf("route %s: %w", route, err)
+			}
+		}
+
+		cfg.Routes[route] = p
+	}
+
+	return cfg, nil
+}
+
+// Limiter applies the policies of the routes to the requests.
+type Limiter struct {
+	store    Store
+	routes   map[string]Policy
+	failOpen bool
+}
+
+// NewLimiter creates a limiter of the routes with the buckets of the store.
+func NewLimiter(store Store, routes map[string]Policy, failOpen bool) *Limiter {
+	return &Limiter{store: store, routes: routes, failOpen: failOpen}
+}
+
+// New creates the limiter of the config, or returns nil if the limits are disabled. The memory store
+// evicts the full buckets and the Redis connection is closed when the context is done.
+func New(ctx context.Context, cfg Config) (*Limiter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	var store Store
+
+	switch cfg.Store {
+	case StoreMemory, "":
+		s := NewMemoryStore()
+		go s.Watch(ctx, DefaultEvictInterval)
+		store = s
+	case StoreRedis:
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Username: cfg.Redis.Username,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+
+		go func() {
+			<-ctx.Done()
+			client.Close()
+		}()
+
+		store = NewRedisStore(client, cfg.Redis.Prefix)
+	default:
+		return nil, fmt.Errorf("ratelimit: unknown store %q, want %s or %s", cfg.Store, StoreMemory, StoreRedis)
+	}
+
+	return NewLimiter(store, cfg.Routes, cfg.FailOpen), nil
+}
+
+// Allow takes a token for a request to the route from the bucket of the client IP address and,
+// if the user is known (userID > 0), from the bucket of the user, and returns the stricter result.
+// A request rejected by the IP limit does not take a token of the user. The result has a zero Limit
+// if the route has no policy. If the store fails, the error is returned with a result that allows
+// the request only if the limiter fails open.
+func (l *Limiter) Allow(ctx context.Context, route, ip string, userID int32) (Result, error) {
+	policy, ok := l.routes[route]
+	if !ok {
+		return Result{Allowed: true}, nil
+	}
+
+	res := Result{Allowed: true}
+
+	if policy.IP.Requests > 0 {
+		r, err := l.store.Take(ctx, "ip:"+route+":"+ip, policy.IP)
+		if err != nil {
+			return Result{Allowed: l.failOpen}, err
+		}
+
+		if !r.Allowed {
+			return r, nil
+		}
+
+		res = r
+	}
+
+	if policy.User.Requests > 0 && userID > 0 {
+		r, err := l.store.Take(ctx, "user:"+route+":"+strconv.Itoa(int(userID)), policy.User)
+		if err != nil {
+			return Result{Allowed: l.failOpen}, err
+		}
+
+		if res.Limit == 0 {
+			return r, nil
+		}
+
+		res = Stricter(res, r)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingStore is a store that is unavailable.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("store is down")
+}
+
+func TestLimiterAllow(t *testing.T) {
+	routes := map[string]Policy{
+		"login":      {IP: Limit{Requests: 2, Period: time.Minute}},
+		"create_bet": {IP: Limit{Requests: 3, Period: time.Minute}, User: Limit{Requests: 1, Period: time.Minute}},
+	}
+
+	t.Run("ip limit", func(t *testing.T) {
+		l := NewLimiter(NewMemoryStore(), routes, true)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			res, err := l.Allow(ctx, "login", "10.0.0.1", 0)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+		}
+
+		res, err := l.Allow(ctx, "login", "10.0.0.1", 0)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+
+		res, err = l.Allow(ctx, "login", "10.0.0.2", 0)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed, "other clients are not limited")
+	})
+
+	t.Run("user limit", func(t *testing.T) {
+		l := NewLimiter(NewMemoryStore(), routes, true)
+		ctx := context.Background()
+
+		res, err := l.Allow(ctx, "create_bet", "10.0.0.1", 42)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 1, res.Limit, "the stricter user limit is reported")
+		assert.Equal(t, 0, res.Remaining)
+
+		res, err = l.Allow(ctx, "create_bet", "10.0.0.2", 42)
+		require.NoError(t, err)
+		assert.False(t, res.Allowed, "the user is limited from every address")
+
+		res, err = l.Allow(ctx, "create_bet", "10.0.0.1", 7)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed, "other users are not limited")
+	})
+
+	t.Run("route without policy", func(t *testing.T) {
+		l := NewLimiter(NewMemoryStore(), routes, true)
+
+		res, err := l.Allow(context.Background(), "events", "10.0.0.1", 0)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Zero(t, res.Limit)
+	})
+
+	t.Run("store failure", func(t *testing.T) {
+		res, err := NewLimiter(failingStore{}, routes, true).Allow(context.Background(), "login", "10.0.0.1", 0)
+		assert.Error(t, err)
+		assert.True(t, res.Allowed, "fail open")
+
+		res, err = NewLimiter(failingStore{}, routes, false).Allow(context.Background(), "login", "10.0.0.1", 0)
+		assert.Error(t, err)
+		assert.False(t, res.Allowed, "fail closed")
+	})
+}
+
+func TestViperConfig(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("ratelimit.enabled", true)
+	viper.Set("ratelimit.store", StoreRedis)
+	viper.SetDefault("ratelimit.routes.login.ip", "10/1m")
+	viper.SetDefault("ratelimit.routes.create_bet.ip", "60/1m")
+	viper.SetDefault("ratelimit.routes.create_bet.user", "20/1m")
+
+	cfg, err := ViperConfig()
+	require.NoError(t, err)
+
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, StoreRedis, cfg.Store)
+	assert.Equal(t, map[string]Policy{
+		"login":      {IP: Limit{Requests: 10, Period: time.Minute}},
+		"create_bet": {IP: Limit{Requests: 60, Period: time.Minute}, User: Limit{Requests: 20, Period: time.Minute}},
+	}, cfg.Routes)
+
+	viper.Set("ratelimit.routes.login.ip", "many")
+	_, err = ViperConfig()
+	assert.Error(t, err)
+}
+
+func TestNew(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	l, err := New(ctx, Config{})
+	require.NoError(t, err)
+	assert.Nil(t, l, "disabled")
+
+	l, err = New(ctx, Config{Enabled: true})
+	require.NoError(t, err)
+	assert.NotNil(t, l)
+
+	_, err = New(ctx, Config{Enabled: true, Store: "memcached"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultEvictInterval is the interval the memory store drops the full buckets at
+// when none is set.
+const DefaultEvictInterval = time.Minute
+
+// MemoryStore keeps the buckets in the memory of the process, so every instance of a service
+// limits its own requests. It is safe for concurrent use.
+type MemoryStore struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+}
+
+// memoryBucket is a bucket with the limit it was last taken from, to know when it is full.
+type memoryBucket struct {
+	bucket
+	limit Limit
+}
+
+// NewMemoryStore creates an empty memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, buckets: make(map[string]*memoryBucket)}
+}
+
+// Take takes a token from the bucket of the key.
+func (s *MemoryStore) Take(_ context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &memoryBucket{}
+		s.buckets[key] = b
+	}
+
+	b.limit = limit
+
+	return b.take(limit, s.now()), nil
+}
+
+// Evict drops the buckets that are full again, which behave as new ones, and returns their number.
+func (s *MemoryStore) Evict() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	evicted := 0
+
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) >= b.limit.Period {
+			delete(s.buckets, key)
+			evicted++
+		}
+	}
+
+	return evicted
+}
+
+// Len returns the number of buckets.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets)
+}
+
+// Watch evicts the full buckets every interval, or every DefaultEvictInterval if it is not positive,
+// until the context is done.
+func (s *MemoryStore) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultEvictInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Evict()
+		}
+	}
+}
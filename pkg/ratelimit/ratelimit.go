@@ -0,0 +1,166 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Header names of the rate limit responses, as in the IETF RateLimit header fields draft.
+const (
+	HeaderLimit      = "RateLimit-Limit"
+	HeaderRemaining  = "RateLimit-Remaining"
+	HeaderReset      = "RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// Limit is a token bucket of Requests tokens refilled evenly over Period,
+// e.g. 10 requests per minute allow a burst of 10 and then one request every 6 seconds.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// ParseLimit parses a limit written as requests/period, e.g. 10/1m or 5/1h.
+func ParseLimit(s string) (Limit, error) {
+	n, p, ok := strings.Cut(strings.TrimSpace(s), "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("ratelimit: invalid limit %q, want requests/period", s)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(n))
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit: invalid number of requests in limit %q", s)
+	}
+
+	period, err := time.ParseDuration(strings.TrimSpace(p))
+	if err != nil || period <= 0 {
+		return Limit{}, fmt.Errorf("ratelimit: invalid period in limit %q", s)
+	}
+
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+// String returns the limit as requests/period.
+func (l Limit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// rate returns the number of tokens refilled per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result is the outcome of taking a token from a bucket.
+type Result struct {
+	// Allowed reports whether the bucket had a token for the request.
+	Allowed bool
+	// Limit is the capacity of the bucket.
+	Limit int
+	// Remaining is the number of whole tokens left in the bucket.
+	Remaining int
+	// RetryAfter is the time until the next token, if the request was not allowed.
+	RetryAfter time.Duration
+	// Reset is the time until the bucket is full again.
+	Reset time.Duration
+}
+
+// Store keeps the buckets by key.
+type Store interface {
+	// Take takes a token from the bucket of the key, creating a full bucket of the limit if there is none.
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// bucket is the state of a token bucket.
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// take refills the bucket up to the time now and takes a token from it if there is one.
+// A new bucket has the zero update time and starts full.
+func (b *bucket) take(limit Limit, now time.Time) Result {
+	capacity := float64(limit.Requests)
+
+	if b.updated.IsZero() {
+		b.tokens = capacity
+	} else if elapsed := now.Sub(b.updated).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(capacity, b.tokens+elapsed*limit.rate())
+	}
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return resultOf(limit, allowed, b.tokens)
+}
+
+// resultOf returns the result of a take from a bucket of the limit left with the tokens.
+func resultOf(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.rate()
+
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit.Requests,
+		Remaining: int(tokens),
+		Reset:     seconds((float64(limit.Requests) - tokens) / rate),
+	}
+
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return res
+}
+
+// seconds converts the seconds to a duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// SetHeaders sets the RateLimit-* headers of the result, and the Retry-After header if the request
+// was not allowed. The durations are rounded up to whole seconds, so a client that waits them is allowed.
+func SetHeaders(h http.Header, res Result) {
+	h.Set(HeaderLimit, strconv.Itoa(res.Limit))
+	h.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+	h.Set(HeaderReset, strconv.Itoa(ceilSeconds(res.Reset)))
+
+	if !res.Allowed {
+		h.Set(HeaderRetryAfter, strconv.Itoa(ceilSeconds(res.RetryAfter)))
+	}
+}
+
+// ceilSeconds returns the duration in whole seconds, rounded up.
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+// Stricter returns the result a client should see of two limits applied to the same request:
+// the denied one, or the one with fewer remaining tokens.
+func Stricter(a, b Result) Result {
+	if a.Allowed != b.Allowed {
+		if a.Allowed {
+			return b
+		}
+		return a
+	}
+
+	if !a.Allowed {
+		if a.RetryAfter >= b.RetryAfter {
+			return a
+		}
+		return b
+	}
+
+	if a.Remaining <= b.Remaining {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,210 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clock is the time of the stores of the tests.
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time { return c.t }
+
+func (c *clock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Limit
+		wantErr bool
+	}{
+		{in: "10/1m", want: Limit{Requests: 10, Period: time.Minute}},
+		{in: " 5 / 1h ", want: Limit{Requests: 5, Period: time.Hour}},
+		{in: "10", wantErr: true},
+		{in: "0/1m", wantErr: true},
+		{in: "ten/1m", wantErr: true},
+		{in: "10/minute", wantErr: true},
+		{in: "10/0s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseLimit(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// testStores returns the stores of the tests on the clock.
+func testStores(t *testing.T, c *clock) map[string]Store {
+	mem := NewMemoryStore()
+	mem.now = c.now
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	rs := NewRedisStore(client, "")
+	rs.now = c.now
+
+	return map[string]Store{"memory": mem, "redis": rs}
+}
+
+func TestStoreTake(t *testing.T) {
+	limit := Limit{Requests: 3, Period: 3 * time.Second}
+
+	c := &clock{t: time.Unix(1700000000, 0)}
+	for name, store := range testStores(t, c) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// The full bucket allows a burst of the limit.
+			for i := 2; i >= 0; i-- {
+				res, err := store.Take(ctx, "login:10.0.0.1", limit)
+				require.NoError(t, err)
+				assert.True(t, res.Allowed)
+				assert.Equal(t, 3, res.Limit)
+				assert.Equal(t, i, res.Remaining)
+			}
+
+			res, err := store.Take(ctx, "login:10.0.0.1", limit)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 0, res.Remaining)
+			assert.Equal(t, time.Second, res.RetryAfter)
+			assert.Equal(t, 3*time.Second, res.Reset)
+
+			// Another key has its own bucket.
+			res, err = store.Take(ctx, "login:10.0.0.2", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+
+			// A token is refilled every second.
+			c.advance(500 * time.Millisecond)
+			res, err = store.Take(ctx, "login:10.0.0.1", limit)
+			require.NoError(t, err)
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 500*time.Millisecond, res.RetryAfter)
+
+			c.advance(500 * time.Millisecond)
+			res, err = store.Take(ctx, "login:10.0.0.1", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+
+			// The bucket does not fill over its capacity.
+			c.advance(time.Hour)
+			res, err = store.Take(ctx, "login:10.0.0.1", limit)
+			require.NoError(t, err)
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 2, res.Remaining)
+		})
+	}
+}
+
+func TestRedisStoreExpiresBuckets(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	defer client.Close()
+
+	store := NewRedisStore(client, "test:")
+
+	_, err := store.Take(context.Background(), "login:10.0.0.1", Limit{Requests: 3, Period: time.Minute})
+	require.NoError(t, err)
+
+	assert.True(t, srv.Exists("test:login:10.0.0.1"))
+	assert.Equal(t, time.Minute, srv.TTL("test:login:10.0.0.1"))
+}
+
+func TestRedisStoreError(t *testing.T) {
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr(), MaxRetries: -1})
+	defer client.Close()
+
+	srv.Close()
+
+	_, err := NewRedisStore(client, "").Take(context.Background(), "login:10.0.0.1", Limit{Requests: 3, Period: time.Minute})
+	assert.Error(t, err)
+}
+
+func TestMemoryStoreEvict(t *testing.T) {
+	c := &clock{t: time.Unix(1700000000, 0)}
+	store := NewMemoryStore()
+	store.now = c.now
+
+	ctx := context.Background()
+	_, _ = store.Take(ctx, "short", Limit{Requests: 1, Period: time.Second})
+	_, _ = store.Take(ctx, "long", Limit{Requests: 1, Period: time.Hour})
+
+	c.advance(time.Minute)
+
+	assert.Equal(t, 1, store.Evict())
+	assert.Equal(t, 1, store.Len())
+}
+
+func TestSetHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		res  Result
+		want map[string]string
+	}{
+		{
+			name: "allowed",
+			res:  Result{Allowed: true, Limit: 10, Remaining: 9, Reset: 6 * time.Second},
+			want: map[string]string{
+				HeaderLimit:     "10",
+				HeaderRemaining: "9",
+				HeaderReset:     "6",
+			},
+		},
+		{
+			name: "rejected",
+			res:  Result{Limit: 10, RetryAfter: 1500 * time.Millisecond, Reset: 60 * time.Second},
+			want: map[string]string{
+				HeaderLimit:      "10",
+				HeaderRemaining:  "0",
+				HeaderReset:      "60",
+				HeaderRetryAfter: "2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http.Header{}
+			SetHeaders(h, tt.res)
+
+			assert.Len(t, h, len(tt.want))
+			for name, value := range tt.want {
+				assert.Equal(t, value, h.Get(name), name)
+			}
+		})
+	}
+}
+
+func TestStricter(t *testing.T) {
+	allowed := Result{Allowed: true, Limit: 10, Remaining: 5}
+	fewer := Result{Allowed: true, Limit: 3, Remaining: 1}
+	denied := Result{Limit: 3, RetryAfter: time.Second}
+	longer := Result{Limit: 3, RetryAfter: time.Minute}
+
+	assert.Equal(t, fewer, Stricter(allowed, fewer))
+	assert.Equal(t, fewer, Stricter(fewer, allowed))
+	assert.Equal(t, denied, Stricter(allowed, denied))
+	assert.Equal(t, denied, Stricter(denied, allowed))
+	assert.Equal(t, longer, Stricter(denied, longer))
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultRedisPrefix prefixes the keys of the buckets in Redis when no prefix is set.
+const DefaultRedisPrefix = "ratelimit:"
+
+// takeScript refills the bucket of KEYS[1] with ARGV[1] tokens of capacity and ARGV[2] tokens per
+// millisecond up to the time ARGV[3] in milliseconds and takes a token from it if there is one.
+// The bucket expires once it would be full again, after ARGV[4] milliseconds. It returns whether
+// the token was taken and the tokens left, as a string since Redis truncates the numbers to integers.
+var takeScript = redis.NewScript(`
+local capacity = tonumber(ARGV[1])
+local rate = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "updated")
+local tokens = tonumber(state[1])
+local updated = tonumber(state[2])
+
+if tokens == nil or updated == nil then
+	tokens = capacity
+elseif now > updated then
+	tokens = math.min(capacity, tokens + (now - updated) * rate)
+end
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HSET", KEYS[1], "tokens", tostring(tokens), "updated", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+
+return {allowed, tostring(tokens)}
+`)
+
+// RedisStore keeps the buckets in Redis, or a server compatible with it, so the instances of a service
+// share the limits. A bucket is updated by a script in one round trip, atomically.
+type RedisStore struct {
+	client redis.Scripter
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisStore creates a store of the buckets under the key prefix, or DefaultRedisPrefix if it is empty.
+func NewRedisStore(client redis.Scripter, prefix string) *RedisStore {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+
+	return &RedisStore{client: client, prefix: prefix, now: time.Now}
+}
+
+// Take takes a token from the bucket of the key.
+func (s *RedisStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	rate := limit.rate() / 1000
+
+	v, err := takeScript.Run(ctx, s.client, []string{s.prefix + key},
+		limit.Requests,
+		strconv.FormatFloat(rate, 'g', -1, 64),
+		s.now().UnixMilli(),
+		limit.Period.Milliseconds(),
+	).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: %w", err)
+	}
+
+	if len(v) != 2 {
+		return Result{}, fmt.Errorf("ratelimit: unexpected script result %v", v)
+	}
+
+	allowed, _ := v[0].(int64)
+	left, _ := v[1].(string)
+
+	tokens, err := strconv.ParseFloat(left, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: invalid tokens %q: %w", left, err)
+	}
+
+	return resultOf(limit, allowed == 1, tokens), nil
+}
@@ -0,0 +1,98 @@
+package tlsx
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	logs "fightbettr.com/pkg/logger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ParseRules parses the method=identity[,identity...] entries into the allowed identities by method.
+func ParseRules(entries []string) (map[string][]string, error) {
+	rules := make(map[string][]string, len(entries))
+
+	for _, entry := range entries {
+		method, list, ok := strings.Cut(entry, "=")
+		method = strings.TrimSpace(method)
+		if !ok || !strings.HasPrefix(method, "/") {
+			return nil, fmt.Errorf("tlsx: invalid authorization rule %q, want /Service/Method=identity[,identity...]", entry)
+		}
+
+		for _, id := range strings.Split(list, ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				rules[method] = append(rules[method], id)
+			}
+		}
+
+		if len(rules[method]) == 0 {
+			return nil, fmt.Errorf("tlsx: no identities in authorization rule %q", entry)
+		}
+	}
+
+	return rules, nil
+}
+
+// Identity returns the identity of the verified client certificate of the call: the common name
+// of the certificate, or its first DNS name if it has no common name.
+func Identity(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	cert := info.State.VerifiedChains[0][0]
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName, true
+	}
+
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0], true
+	}
+
+	return "", false
+}
+
+// UnaryServerInterceptor allows the methods of the rules only to the callers whose client certificate
+// identity is listed for the method, and fails the other calls with the PermissionDenied code.
+// The methods without a rule are allowed to every caller.
+func UnaryServerInterceptor(rules map[string][]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		allowed, restricted := rules[info.FullMethod]
+		if !restricted {
+			return handler(ctx, req)
+		}
+
+		id, ok := Identity(ctx)
+		if ok {
+			for _, a := range allowed {
+				if a == id {
+					return handler(ctx, req)
+				}
+			}
+		}
+
+		logs.Ctx(ctx).Warnw("Call is not authorized", "identity", id)
+
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s", identityName(id), info.FullMethod)
+	}
+}
+
+// identityName returns the identity for the error messages.
+func identityName(id string) string {
+	if id == "" {
+		return "unauthenticated caller"
+	}
+
+	return id
+}
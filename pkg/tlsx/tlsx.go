@@ -0,0 +1,313 @@
+package tlsx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	logs "fightbettr.com/pkg/logger"
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DefaultReloadInterval is the interval the certificate files are checked for changes at
+// when the config sets none.
+const DefaultReloadInterval = time.Minute
+
+// ErrCertRequired is returned when a server is configured with TLS but without a certificate.
+var ErrCertRequired = errors.New("tlsx: certificate and key files are required")
+
+// ErrCARequired is returned when client certificates are required but no CA file verifies them.
+var ErrCARequired = errors.New("tlsx: CA file is required to verify client certificates")
+
+// Config holds the TLS settings of the gRPC servers and clients of a service.
+// The certificate of a server must be valid for the name the service is registered under,
+// e.g. event-service, since the clients verify it against the name they dial.
+type Config struct {
+	// Enabled turns TLS on; without it the connections are not encrypted.
+	Enabled bool
+	// CertFile and KeyFile are the PEM certificate and key of the service. A server requires them,
+	// a client presents them to the servers that require client certificates.
+	CertFile string
+	KeyFile  string
+	// CAFile is the PEM bundle of the CA certificates that verify the peers. A client without it
+	// verifies the servers with the system roots.
+	CAFile string
+	// ClientAuth makes a server require a client certificate verified by the CA (mutual TLS).
+	ClientAuth bool
+	// ReloadInterval is the interval the files are checked for changes at, so rotated certificates
+	// are used without a restart.
+	ReloadInterval time.Duration
+	// Authorize lists the methods restricted to some client identities as method=identity[,identity...]
+	// entries, e.g. /EventService/SetResult=Fightbettr-gateway-service. It applies with ClientAuth only.
+	Authorize []string
+}
+
+// ViperConfig returns the TLS config based on the grpc.tls values from viper.
+func ViperConfig() Config {
+	return Config{
+		Enabled:        viper.GetBool("grpc.tls.enabled"),
+		CertFile:       viper.GetString("grpc.tls.cert_file"),
+		KeyFile:        viper.GetString("grpc.tls.key_file"),
+		CAFile:         viper.GetString("grpc.tls.ca_file"),
+		ClientAuth:     viper.GetBool("grpc.tls.client_auth"),
+		ReloadInterval: viper.GetDuration("grpc.tls.reload_interval"),
+		Authorize:      viper.GetStringSlice("grpc.tls.authorize"),
+	}
+}
+
+// Reloader keeps the certificate and the CA pool of a config and reloads them when the files change.
+// A failed reload keeps the previous certificates.
+type Reloader struct {
+	cfg Config
+
+	mu    sync.RWMutex
+	cert  *tls.Certificate
+	pool  *x509.CertPool
+	stamp string
+}
+
+// NewReloader loads the certificate and the CA files of the config.
+func NewReloader(cfg Config) (*Reloader, error) {
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return nil, ErrCertRequired
+	}
+
+	r := &Reloader{cfg: cfg}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Reload loads the files again if any of them changed since they were loaded,
+// and reports whether they were reloaded.
+func (r *Reloader) Reload() (bool, error) {
+	stamp, err := r.filesStamp()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	unchanged := r.stamp == stamp
+	r.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	var cert *tls.Certificate
+	if r.cfg.CertFile != "" {
+		c, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+		if err != nil {
+			return false, fmt.Errorf("tlsx: unable to load certificate: %w", err)
+		}
+		cert = &c
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.CAFile != "" {
+		pem, err := os.ReadFile(r.cfg.CAFile)
+		if err != nil {
+			return false, fmt.Errorf("tlsx: unable to read CA file: %w", err)
+		}
+
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return false, fmt.Errorf("tlsx: no certificates in CA file %s", r.cfg.CAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert, r.pool, r.stamp = cert, pool, stamp
+	r.mu.Unlock()
+
+	return true, nil
+}
+
+// Watch reloads the files every interval, or every DefaultReloadInterval if it is not positive,
+// until the context is done.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				logs.Errorf("Unable to reload TLS certificates, keeping the loaded ones: %s", err)
+				continue
+			}
+
+			if reloaded {
+				logs.Infof("Reloaded TLS certificates from %s", r.cfg.CertFile)
+			}
+		}
+	}
+}
+
+// filesStamp returns the modification times and sizes of the files, which change when a file is replaced.
+func (r *Reloader) filesStamp() (string, error) {
+	var b strings.Builder
+
+	for _, path := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.CAFile} {
+		if path == "" {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", fmt.Errorf("tlsx: %w", err)
+		}
+
+		fmt.Fprintf(&b, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+
+	return b.String(), nil
+}
+
+// current returns the loaded certificate and CA pool.
+func (r *Reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.cert, r.pool
+}
+
+// ServerConfig returns the server TLS config with the loaded certificates.
+func (r *Reloader) ServerConfig() *tls.Config {
+	cert, pool := r.current()
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if cert != nil {
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+
+	if r.cfg.ClientAuth {
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		cfg.ClientCAs = pool
+	}
+
+	return cfg
+}
+
+// ClientConfig returns the client TLS config with the loaded certificates.
+func (r *Reloader) ClientConfig() *tls.Config {
+	cert, pool := r.current()
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12, RootCAs: pool}
+	if cert != nil {
+		cfg.Certificates = []tls.Certificate{*cert}
+	}
+
+	return cfg
+}
+
+// ServerCredentials returns the gRPC server credentials that use the certificates loaded at the handshake.
+func (r *Reloader) ServerCredentials() credentials.TransportCredentials {
+	return &reloadingCredentials{config: r.ServerConfig}
+}
+
+// ClientCredentials returns the gRPC client credentials that use the certificates loaded at the handshake.
+func (r *Reloader) ClientCredentials() credentials.TransportCredentials {
+	return &reloadingCredentials{config: r.ClientConfig}
+}
+
+// reloadingCredentials are TLS credentials built from the current TLS config at every handshake,
+// so new connections use the reloaded certificates while the established ones are kept.
+type reloadingCredentials struct {
+	config func() *tls.Config
+}
+
+func (c *reloadingCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.config()).ClientHandshake(ctx, authority, conn)
+}
+
+func (c *reloadingCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return credentials.NewTLS(c.config()).ServerHandshake(conn)
+}
+
+func (c *reloadingCredentials) Info() credentials.ProtocolInfo {
+	return credentials.NewTLS(c.config()).Info()
+}
+
+func (c *reloadingCredentials) Clone() credentials.TransportCredentials {
+	return &reloadingCredentials{config: c.config}
+}
+
+// OverrideServerName is not supported; the name is always taken from the dialed target.
+func (c *reloadingCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+// ServerOptions returns the options of a gRPC server for the config. Without TLS there are none.
+// With TLS the server uses the certificates reloaded until the context is done and, with client
+// authentication, allows the methods of the Authorize entries to the listed client identities only.
+func ServerOptions(ctx context.Context, cfg Config) ([]grpc.ServerOption, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, ErrCertRequired
+	}
+
+	if cfg.ClientAuth && cfg.CAFile == "" {
+		return nil, ErrCARequired
+	}
+
+	rules, err := ParseRules(cfg.Authorize)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	go r.Watch(ctx, cfg.ReloadInterval)
+
+	opts := []grpc.ServerOption{grpc.Creds(r.ServerCredentials())}
+	if cfg.ClientAuth {
+		opts = append(opts, grpc.ChainUnaryInterceptor(UnaryServerInterceptor(rules)))
+	} else if len(rules) > 0 {
+		logs.Warnf("Authorization of %d methods is disabled, client certificates are not required", len(rules))
+	}
+
+	return opts, nil
+}
+
+// DialOptions returns the options of the gRPC client connections for the config. Without TLS there
+// are none, so the connections stay insecure. With TLS the connections use the certificates reloaded
+// until the context is done.
+func DialOptions(ctx context.Context, cfg Config) ([]grpc.DialOption, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	r, err := NewReloader(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	go r.Watch(ctx, cfg.ReloadInterval)
+
+	return []grpc.DialOption{grpc.WithTransportCredentials(r.ClientCredentials())}, nil
+}
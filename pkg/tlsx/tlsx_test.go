@@ -0,0 +1,313 @@
+package tlsx
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// testCA issues the certificates of the tests.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fightbettr test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue writes a certificate with the given common name, DNS names and serial number and its key
+// into dir and returns their paths.
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64, dnsNames ...string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certPath := filepath.Join(dir, name+".crt")
+	keyPath := filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certPath, keyPath
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	path := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(path, ca.pem, 0o600))
+	return path
+}
+
+// serve starts a gRPC server with the health service and the options of the config.
+func serve(t *testing.T, cfg Config) string {
+	opts, err := ServerOptions(context.Background(), cfg)
+	require.NoError(t, err)
+
+	srv := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	return lis.Addr().String()
+}
+
+// check calls the health service at addr as event-service with the options of the config
+// and returns the serial number of the server certificate.
+func check(t *testing.T, addr string, cfg Config) (int64, error) {
+	opts, err := DialOptions(context.Background(), cfg)
+	require.NoError(t, err)
+
+	// The connections are insecure unless the options set the credentials, as in grpcutil.
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+
+	conn, err := grpc.Dial(addr, append(opts, grpc.WithAuthority("event-service"))...)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var p peer.Peer
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{}, grpc.Peer(&p))
+	if err != nil {
+		return 0, err
+	}
+
+	state := p.AuthInfo.(credentials.TLSInfo).State
+	return state.PeerCertificates[0].SerialNumber.Int64(), nil
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+
+	serverCert, serverKey := ca.issue(t, dir, "event-service", 10, "event-service")
+	gatewayCert, gatewayKey := ca.issue(t, dir, "Fightbettr-gateway-service", 20)
+
+	otherDir := t.TempDir()
+	other := newTestCA(t)
+	otherCert, otherKey := other.issue(t, otherDir, "Fightbettr-gateway-service", 30)
+
+	addr := serve(t, Config{Enabled: true, CertFile: serverCert, KeyFile: serverKey, CAFile: caFile, ClientAuth: true})
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "client certificate", cfg: Config{Enabled: true, CertFile: gatewayCert, KeyFile: gatewayKey, CAFile: caFile}},
+		{name: "no client certificate", cfg: Config{Enabled: true, CAFile: caFile}, wantErr: true},
+		{name: "client certificate of another CA", cfg: Config{Enabled: true, CertFile: otherCert, KeyFile: otherKey, CAFile: caFile}, wantErr: true},
+		{name: "server not trusted", cfg: Config{Enabled: true, CertFile: gatewayCert, KeyFile: gatewayKey}, wantErr: true},
+		{name: "insecure client", cfg: Config{}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serial, err := check(t, addr, tt.cfg)
+			if tt.wantErr {
+				assert.Equal(t, codes.Unavailable, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(10), serial)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+
+	serverCert, serverKey := ca.issue(t, dir, "event-service", 10, "event-service")
+
+	cfg := Config{Enabled: true, CertFile: serverCert, KeyFile: serverKey, CAFile: caFile, ReloadInterval: 10 * time.Millisecond}
+	addr := serve(t, cfg)
+	client := Config{Enabled: true, CAFile: caFile}
+
+	serial, err := check(t, addr, client)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), serial)
+
+	// The rotated certificate is written over the served one.
+	time.Sleep(10 * time.Millisecond)
+	ca.issue(t, dir, "event-service", 11, "event-service")
+
+	assert.Eventually(t, func() bool {
+		serial, err := check(t, addr, client)
+		return err == nil && serial == 11
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestReloadKeepsCertificatesOnError(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, dir, "event-service", 10, "event-service")
+
+	r, err := NewReloader(Config{CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+
+	reloaded, err := r.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged files are not reloaded")
+
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0o600))
+
+	_, err = r.Reload()
+	assert.Error(t, err)
+	assert.Len(t, r.ServerConfig().Certificates, 1)
+}
+
+func TestServerOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantOpts int
+		wantErr  error
+	}{
+		{name: "disabled", cfg: Config{}, wantOpts: 0},
+		{name: "no certificate", cfg: Config{Enabled: true}, wantErr: ErrCertRequired},
+		{name: "client auth without CA", cfg: Config{Enabled: true, CertFile: "a.crt", KeyFile: "a.key", ClientAuth: true}, wantErr: ErrCARequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, err := ServerOptions(context.Background(), tt.cfg)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, opts, tt.wantOpts)
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name:    "rules",
+			entries: []string{"/EventService/SetResult=Fightbettr-gateway-service", "/EventService/CreateEvent = a, b"},
+			want: map[string][]string{
+				"/EventService/SetResult":   {"Fightbettr-gateway-service"},
+				"/EventService/CreateEvent": {"a", "b"},
+			},
+		},
+		{name: "no rules", want: map[string][]string{}},
+		{name: "no identities", entries: []string{"/EventService/SetResult="}, wantErr: true},
+		{name: "no method", entries: []string{"Fightbettr-gateway-service"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRules(tt.entries)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := UnaryServerInterceptor(map[string][]string{
+		"/EventService/SetResult": {"Fightbettr-gateway-service"},
+	})
+
+	withIdentity := func(cn string, dnsNames ...string) context.Context {
+		cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}, DNSNames: dnsNames}
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+		})
+	}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		wantCode codes.Code
+	}{
+		{name: "gateway sets results", ctx: withIdentity("Fightbettr-gateway-service"), method: "/EventService/SetResult", wantCode: codes.OK},
+		{name: "identity from DNS name", ctx: withIdentity("", "Fightbettr-gateway-service"), method: "/EventService/SetResult", wantCode: codes.OK},
+		{name: "scraper may not set results", ctx: withIdentity("scrapper-service"), method: "/EventService/SetResult", wantCode: codes.PermissionDenied},
+		{name: "no certificate", ctx: context.Background(), method: "/EventService/SetResult", wantCode: codes.PermissionDenied},
+		{name: "unrestricted method", ctx: withIdentity("scrapper-service"), method: "/EventService/GetEvents", wantCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := func(ctx context.Context, req any) (any, error) { return "ok", nil }
+
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/viper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+// Names of the span exporters.
+const (
+	None   = "none"
+	Stdout = "stdout"
+	File   = "file"
+	OTLP   = "otlp"
+)
+
+// Config selects the span exporter and holds the sampling and resource settings.
+type Config struct {
+	// ServiceName, ServiceVersion and Environment describe the service in the resource of every span.
+	ServiceName    string
+	ServiceVersion string
+	Environment    string
+	// Exporter is one of none, stdout, file and otlp.
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	// Insecure disables TLS towards the OTLP collector.
+	Insecure bool
+	// FilePath is the file the spans are appended to by the file exporter.
+	FilePath string
+	// SampleRatio is the share of the new traces that are sampled, from 0 to 1.
+	// Spans of a trace started by a caller follow the sampling decision of the caller.
+	SampleRatio float64
+}
+
+// ShutdownFunc flushes the pending spans and stops the exporter.
+type ShutdownFunc func(ctx context.Context) error
+
+// ViperConfig returns the tracing config based on the app and tracing values from viper.
+func ViperConfig() Config {
+	return Config{
+		ServiceName:    viper.GetString("app.name"),
+		ServiceVersion: viper.GetString("app.version"),
+		Environment:    viper.GetString("app.env"),
+		Exporter:       viper.GetString("tracing.exporter"),
+		Endpoint:       viper.GetString("tracing.otlp.endpoint"),
+		Insecure:       viper.GetBool("tracing.otlp.insecure"),
+		FilePath:       viper.GetString("tracing.file.path"),
+		SampleRatio:    viper.GetFloat64("tracing.sample_ratio"),
+	}
+}
+
+// Init installs the global tracer provider exporting the spans with the configured exporter and
+// the W3C trace context and baggage propagators, so the otelgrpc interceptors and the HTTP middleware
+// continue the traces of the callers. With the none exporter, or an empty one, the spans are not recorded
+// but the trace context is still propagated.
+// The returned function must be called on shutdown to flush the pending spans.
+func Init(ctx context.Context, cfg Config) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closeExporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if exporter == nil {
+		return func(ctx context.Context) error { return nil }, nil
+	}
+
+	res, err := newResource(ctx, cfg)
+	if err != nil {
+		closeExporter()
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		err := tp.Shutdown(ctx)
+		return errors.Join(err, closeExporter())
+	}, nil
+}
+
+// newExporter creates the configured span exporter and the function that releases its resources.
+// It returns a nil exporter for the none exporter.
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, func() error, error) {
+	noop := func() error { return nil }
+
+	switch cfg.Exporter {
+	case None, "":
+		return nil, noop, nil
+	case Stdout:
+		exp, err := stdouttrace.New(stdouttrace.WithPrettyPrint())
+		return exp, noop, err
+	case File:
+		f, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tracing: unable to open spans file: %w", err)
+		}
+
+		exp, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, nil, err
+		}
+
+		return exp, f.Close, nil
+	case OTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exp, err := otlptracegrpc.New(ctx, opts...)
+		return exp, noop, err
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+// newResource describes the service the spans come from. The attributes from the
+// OTEL_RESOURCE_ATTRIBUTES and OTEL_SERVICE_NAME environment variables take precedence.
+func newResource(ctx context.Context, cfg Config) (*resource.Resource, error) {
+	return resource.New(ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithAttributes(
+			semconv.ServiceName(cfg.ServiceName),
+			semconv.ServiceVersion(cfg.ServiceVersion),
+			semconv.DeploymentEnvironment(cfg.Environment),
+		),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithFromEnv(),
+	)
+}
@@ -0,0 +1,121 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestInit(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "none", cfg: Config{Exporter: None}},
+		{name: "empty exporter", cfg: Config{}},
+		{name: "stdout", cfg: Config{Exporter: Stdout, SampleRatio: 1}},
+		{name: "file", cfg: Config{Exporter: File, FilePath: filepath.Join(dir, "traces.json"), SampleRatio: 1}},
+		{name: "file in a missing dir", cfg: Config{Exporter: File, FilePath: filepath.Join(dir, "missing", "traces.json")}, wantErr: true},
+		{name: "unknown exporter", cfg: Config{Exporter: "jaeger"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+			shutdown, err := Init(context.Background(), tt.cfg)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, []string{"traceparent", "tracestate", "baggage"}, otel.GetTextMapPropagator().Fields())
+			assert.NoError(t, shutdown(context.Background()))
+		})
+	}
+}
+
+func TestInitFileExporter(t *testing.T) {
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Init(context.Background(), Config{
+		ServiceName: "auth-service",
+		Exporter:    File,
+		FilePath:    path,
+		SampleRatio: 1,
+	})
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "Login")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Name":"Login"`)
+	assert.Contains(t, string(data), `"auth-service"`)
+}
+
+func TestQueryTracer(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	tracer := QueryTracer{}
+
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "select * from fighters where id = $1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	ctx = tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "\n\tINSERT INTO bets VALUES ($1)"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: errors.New("duplicate key")})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "SELECT", spans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, spans[0].SpanKind())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.statement", "select * from fighters where id = $1"))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64("db.rows_affected", 1))
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "INSERT", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "duplicate key", spans[1].Status().Description)
+}
+
+func TestOperation(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want string
+	}{
+		{sql: "select 1", want: "SELECT"},
+		{sql: "  WITH x AS (select 1) select * from x", want: "WITH"},
+		{sql: "", want: "query"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, operation(tt.sql))
+		})
+	}
+}
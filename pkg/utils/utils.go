@@ -47,6 +47,38 @@ func LoadJwtCerts() error {
 
 	viper.Set("auth.jwt.signing_key", cert.PrivateKey)
 
+	if err := loadJwtParseKey(certPath); err != nil {
+		return err
+	}
+
+	logs.Debugw("Loaded jwt certs",
+		"cert_path", viper.GetString("auth.jwt.cert"),
+		"key_path", viper.GetString("auth.jwt.key"),
+	)
+
+	return nil
+}
+
+// LoadJwtParseKey loads the public key of the JWT certificate from the path in the configuration
+// (auth.jwt.cert), so a service that only verifies the tokens issued by the auth service does not need
+// the private key. The loaded key is set in the configuration as auth.jwt.parse_key.
+func LoadJwtParseKey() error {
+	certPath := viper.GetString("auth.jwt.cert")
+	if len(certPath) == 0 {
+		return ErrAuthCertsPathRequired
+	}
+
+	if err := loadJwtParseKey(certPath); err != nil {
+		return err
+	}
+
+	logs.Debugw("Loaded jwt parse key", "cert_path", certPath)
+
+	return nil
+}
+
+// loadJwtParseKey reads the certificate at certPath and sets its public key as auth.jwt.parse_key.
+func loadJwtParseKey(certPath string) error {
 	clientCert, err := os.ReadFile(certPath)
 	if err != nil {
 		logs.Errorf("Unable to read key file bytes: %s", err)
@@ -54,6 +86,12 @@ func LoadJwtCerts() error {
 	}
 
 	block, _ := pem.Decode(clientCert)
+	if block == nil {
+		err := fmt.Errorf("no PEM data in certificate file %s", certPath)
+		logs.Errorf("Unable to parse certificate: %s", err)
+		return err
+	}
+
 	readCert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
 		logs.Errorf("Unable to parse certificate: %s", err)
@@ -62,10 +100,5 @@ func LoadJwtCerts() error {
 
 	viper.Set("auth.jwt.parse_key", readCert.PublicKey)
 
-	logs.Debugw("Loaded jwt certs",
-		"cert_path", viper.GetString("auth.jwt.cert"),
-		"key_path", viper.GetString("auth.jwt.key"),
-	)
-
 	return nil
 }
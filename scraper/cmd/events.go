@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"fightbettr.com/scraper/internal/scraper"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	scrapeCmd.AddCommand(scrapeEventsCmd)
+
+	scrapeEventsCmd.Flags().Int("past-pages", 1, "Number of pages of completed events to scrape results from")
+	scrapeEventsCmd.Flags().String("output-path", "", "Events collection path (default is ./collection/events.json)")
+
+	bindViperFlag(scrapeEventsCmd, "events.past_pages", "past-pages")
+	bindViperFlag(scrapeEventsCmd, "events.output_path", "output-path")
+}
+
+// scrapeEventsCmd represents the scrape events command. It is used to scrape upcoming event cards
+// and results of completed events into the events collection.
+var scrapeEventsCmd = &cobra.Command{
+	Use:          "events",
+	Short:        "Scrape event cards and fight results",
+	Long:         ``,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scraper.RunEvents(cmd.Context(), viper.GetInt("events.past_pages"))
+	},
+}
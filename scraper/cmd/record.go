@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"fightbettr.com/scraper/internal/fixture"
+	"github.com/spf13/cobra"
+)
+
+// defaultFixtureUrls are the pages recorded when no urls are given.
+var defaultFixtureUrls = []string{
+	fixture.Origin + "/athletes/all",
+	fixture.Origin + "/events",
+}
+
+func init() {
+	rootCmd.AddCommand(recordCmd)
+
+	recordCmd.Flags().String("dir", "./internal/scraper/testdata/fixtures", "Directory of the recorded fixtures")
+}
+
+// recordCmd represents the record command. It is used to capture fresh HTML fixtures for the parser tests.
+// Expects the page urls to record; the athletes and events listings are recorded by default.
+var recordCmd = &cobra.Command{
+	Use:   "record [url...]",
+	Short: "Records HTML fixtures of source pages for the parser tests",
+	Long:  ``,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+
+		urls := args
+		if len(urls) == 0 {
+			urls = defaultFixtureUrls
+		}
+
+		client := &http.Client{Timeout: 30 * time.Second}
+
+		files, err := fixture.Record(context.Background(), client, urls, dir)
+		for _, f := range files {
+			fmt.Println("Recorded:", f)
+		}
+
+		return err
+	},
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"log"
+	"os"
 	"time"
 
 	"fightbettr.com/scraper/pkg/version"
@@ -32,9 +33,11 @@ var rootCmd = &cobra.Command{
 // Execute runs the root command for the scraper service.
 // It executes the necessary logic for the command-line interface,
 // handling errors and logging them if they occur.
+// The process exits with a non-zero status if the command fails.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err.Error())
+		os.Exit(1)
 	}
 }
 
@@ -45,11 +48,15 @@ func init() {
 	rootCmd.PersistentFlags().Bool("proxy", false, "Run with proxy")
 	rootCmd.PersistentFlags().Bool("add", false, "Add results to previus fighters collection")
 	rootCmd.PersistentFlags().Int("start", 0, "start page")
+	rootCmd.PersistentFlags().Int("parallelism", 2, "Maximum number of pages fetched at once from a host")
+	rootCmd.PersistentFlags().Bool("ignore-robots", false, "Ignore the restrictions of robots.txt")
 
 	bindViperPersistentFlag(rootCmd, "config_path", "config")
 	bindViperPersistentFlag(rootCmd, "proxy", "proxy")
 	bindViperPersistentFlag(rootCmd, "add", "add")
 	bindViperPersistentFlag(rootCmd, "start", "start")
+	bindViperPersistentFlag(rootCmd, "parallelism", "parallelism")
+	bindViperPersistentFlag(rootCmd, "ignore_robots", "ignore-robots")
 }
 
 // initConfig initializes the service configuration.
@@ -68,7 +75,7 @@ func initConfig() {
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
 }
 
@@ -78,6 +85,82 @@ func setConfigDefaults() {
 	viper.SetDefault("app.name", version.Name)
 	viper.SetDefault("app.version", version.DevVersion)
 	viper.SetDefault("app.run_date", time.Unix(version.RunDate, 0).Format(time.RFC1123))
+
+	// scraper defaults
+	viper.SetDefault("base_url", "https://www.ufc.com")
+	viper.SetDefault("delay", 3*time.Second)
+
+	// concurrency defaults
+	viper.SetDefault("parallelism", 2)
+	viper.SetDefault("ignore_robots", false)
+	viper.SetDefault("retry.max", 3)
+	viper.SetDefault("retry.base_delay", time.Second)
+	viper.SetDefault("retry.max_delay", 30*time.Second)
+	viper.SetDefault("progress.interval", 10*time.Second)
+
+	// proxy pool defaults
+	viper.SetDefault("proxy_pool.max_failures", 3)
+	viper.SetDefault("proxy_pool.cooldown", 5*time.Minute)
+
+	// sources defaults
+	viper.SetDefault("sources.enabled", []string{"ufc"})
+	viper.SetDefault("sources.ufcstats.base_url", "http://ufcstats.com")
+	viper.SetDefault("sources.ufcstats.delay", time.Second)
+
+	// checkpoint defaults
+	viper.SetDefault("checkpoint.path", "./collection/checkpoint.jsonl")
+
+	// output defaults
+	viper.SetDefault("output.sinks", []string{"json"})
+	viper.SetDefault("output.path", "./collection/fighters.json")
+	viper.SetDefault("output.batch_size", 100)
+	viper.SetDefault("output.release_missing", true)
+
+	// service registry defaults
+	viper.SetDefault("registry.backend", "consul")
+	viper.SetDefault("registry.addr", "localhost:8500")
+
+	// tracing defaults
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.otlp.endpoint", "localhost:4317")
+	viper.SetDefault("tracing.otlp.insecure", true)
+	viper.SetDefault("tracing.file.path", "./logs/traces.json")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("tracing.shutdown_timeout", 5*time.Second)
+
+	// gRPC TLS defaults
+	viper.SetDefault("grpc.tls.enabled", false)
+	viper.SetDefault("grpc.tls.cert_file", "")
+	viper.SetDefault("grpc.tls.key_file", "")
+	viper.SetDefault("grpc.tls.ca_file", "")
+	viper.SetDefault("grpc.tls.reload_interval", time.Minute)
+
+	// media defaults
+	viper.SetDefault("media.mirror", false)
+	// the media directory of the gateway, which must be shared with the scraper to mirror the images
+	viper.SetDefault("media.dir", "")
+
+	// validation defaults
+	viper.SetDefault("validation.max_error_rate", 0.05)
+	viper.SetDefault("validation.report", "./collection/report.json")
+
+	// events defaults
+	viper.SetDefault("events.past_pages", 1)
+	viper.SetDefault("events.output_path", "./collection/events.json")
+
+	// health checks
+	viper.SetDefault("health.interval", time.Second)
+	viper.SetDefault("health.timeout", 2*time.Second)
+
+	// daemon defaults
+	viper.SetDefault("serve.addr", ":9095")
+	// manual runs are disabled until a token is set
+	viper.SetDefault("serve.token", "")
+	viper.SetDefault("serve.results_past_pages", 5)
+	viper.SetDefault("serve.shutdown_timeout", 15*time.Second)
+	viper.SetDefault("schedule.roster", "0 4 * * 1")
+	viper.SetDefault("schedule.events", "@every 6h")
+	viper.SetDefault("schedule.results", "0 6 * * 0")
 }
 
 // bindViperPersistentFlag binds a Viper configuration flag to a persistent Cobra command flag.
@@ -86,3 +169,10 @@ func bindViperPersistentFlag(cmd *cobra.Command, viperVal, flagName string) {
 		log.Printf("Failed to bind viper flag: %s", err)
 	}
 }
+
+// bindViperFlag binds a Viper configuration flag to a local Cobra command flag.
+func bindViperFlag(cmd *cobra.Command, viperVal, flagName string) {
+	if err := viper.BindPFlag(viperVal, cmd.Flags().Lookup(flagName)); err != nil {
+		log.Printf("Failed to bind viper flag: %s", err)
+	}
+}
@@ -7,15 +7,50 @@ import (
 
 func init() {
 	rootCmd.AddCommand(scrapeCmd)
+
+	scrapeCmd.Flags().Bool("resume", false, "Resume the previous run from the checkpoint store")
+	scrapeCmd.Flags().Bool("incremental", false, "Re-fetch only athletes whose pages changed since the last run")
+	scrapeCmd.Flags().String("checkpoint", "", "Checkpoint store path (default is ./collection/checkpoint.jsonl)")
+	scrapeCmd.Flags().Float64("max-error-rate", 0.05, "Share of rejected fighters above which the run fails")
+	scrapeCmd.Flags().String("report", "", "Validation report path (default is ./collection/report.json)")
+	scrapeCmd.Flags().StringSlice("sources", []string{"ufc"}, "Sources in the order of priority: ufc, ufcstats")
+	scrapeCmd.Flags().StringSlice("output", []string{"json"}, "Output sinks: json, ndjson, db, grpc")
+	scrapeCmd.Flags().String("output-path", "", "Fighters collection path of the json sink (default is ./collection/fighters.json)")
+	scrapeCmd.Flags().Int("batch-size", 100, "Number of fighters sent at once by the db and grpc sinks")
+	scrapeCmd.Flags().String("registry", "localhost:8500", "Address of the service registry used to reach the fighters service")
+	scrapeCmd.Flags().Bool("mirror-images", false, "Download fighter images and thumbnails into the media store")
+	scrapeCmd.Flags().String("media-dir", "", "Media store directory shared with the gateway, required with --mirror-images")
+
+	bindViperFlag(scrapeCmd, "resume", "resume")
+	bindViperFlag(scrapeCmd, "incremental", "incremental")
+	bindViperFlag(scrapeCmd, "checkpoint.path", "checkpoint")
+	bindViperFlag(scrapeCmd, "validation.max_error_rate", "max-error-rate")
+	bindViperFlag(scrapeCmd, "validation.report", "report")
+	bindViperFlag(scrapeCmd, "sources.enabled", "sources")
+	bindViperFlag(scrapeCmd, "output.sinks", "output")
+	bindViperFlag(scrapeCmd, "output.path", "output-path")
+	bindViperFlag(scrapeCmd, "output.batch_size", "batch-size")
+	bindViperFlag(scrapeCmd, "registry.addr", "registry")
+	bindViperFlag(scrapeCmd, "media.mirror", "mirror-images")
+	bindViperFlag(scrapeCmd, "media.dir", "media-dir")
 }
 
-// scrapeCmd represents the scrape command. It is used to run web-scrapper to update data
+// scrapeCmd represents the scrape command. It is used to run web-scrapper to update data.
+// Progress is checkpointed, so an interrupted run can be continued with --resume.
+// Scraped fighters are written to the sinks selected with --output, for example
+// `scrape --output ndjson | fighters repo update --source -` or `scrape --output json,grpc`.
+// With several --sources the same athletes are merged, e.g. `scrape --sources ufc,ufcstats`.
+// The command fails when the share of fighters rejected by validation exceeds --max-error-rate.
+// With --mirror-images the fighter images are mirrored into --media-dir, the media directory served by the gateway.
+// Pages are fetched concurrently, at most --parallelism at once from a host, robots.txt is respected
+// unless --ignore-robots is set, and requests failed with 429 or 5xx are retried with exponential backoff.
 var scrapeCmd = &cobra.Command{
 	Use:              "scrape",
 	Short:            "Run WEB Scraper",
 	Long:             ``,
 	TraverseChildren: true,
-	Run: func(cmd *cobra.Command, args []string) {
-		scraper.Run()
+	SilenceUsage:     true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scraper.Run(cmd.Context())
 	},
 }
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"fightbettr.com/pkg/discovery"
+	"fightbettr.com/pkg/discovery/backend"
+	"fightbettr.com/pkg/health"
+	"fightbettr.com/pkg/sigx"
+	"fightbettr.com/pkg/tracing"
+	"fightbettr.com/scraper/internal/daemon"
+	"fightbettr.com/scraper/internal/scraper"
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/version"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", "", "Address of the status endpoint (default is :9095)")
+	serveCmd.Flags().String("advertise-addr", "", "Address registered in the service registry (default is the host name with the port of --addr)")
+	serveCmd.Flags().String("roster", "", "Schedule of the roster scrape (default is 0 4 * * 1)")
+	serveCmd.Flags().String("events", "", "Schedule of the event cards scrape (default is @every 6h)")
+	serveCmd.Flags().String("results", "", "Schedule of the fight results scrape (default is 0 6 * * 0)")
+	serveCmd.Flags().String("registry", "", "Address of the service registry (default is localhost:8500)")
+	serveCmd.Flags().String("token", "", "Bearer token required by POST /jobs/{name}/run (manual runs are disabled without it)")
+
+	bindViperFlag(serveCmd, "serve.addr", "addr")
+	bindViperFlag(serveCmd, "serve.advertise_addr", "advertise-addr")
+	bindViperFlag(serveCmd, "schedule.roster", "roster")
+	bindViperFlag(serveCmd, "schedule.events", "events")
+	bindViperFlag(serveCmd, "schedule.results", "results")
+	bindViperFlag(serveCmd, "registry.addr", "registry")
+	bindViperFlag(serveCmd, "serve.token", "token")
+}
+
+// serveCmd represents the serve command. It runs the scraper as a daemon that scrapes the roster,
+// the event cards and the fight results on cron-like schedules, e.g. `serve --events "@every 3h"`.
+// An empty schedule disables the scrape. Only one scrape runs at a time: a scrape that is due while
+// another one is running is skipped. The status of the scrapes with their last and next runs is served
+// at /status of --addr, and the daemon registers itself in the service registry at --advertise-addr,
+// which must be reachable from the other hosts. A scrape is started manually with POST /jobs/{name}/run,
+// which requires serve.token as a bearer token.
+var serveCmd = &cobra.Command{
+	Use:          "serve",
+	Short:        "Run scheduled scrapes",
+	Long:         ``,
+	SilenceUsage: true,
+	RunE:         runServe,
+}
+
+// scheduledJobs returns the scrapes run by the daemon. The events scrape keeps the upcoming cards
+// together with the latest results up to date, the results scrape goes through serve.results_past_pages
+// pages of completed events.
+func scheduledJobs() []daemon.Job {
+	return []daemon.Job{
+		{
+			Name:     "roster",
+			Schedule: viper.GetString("schedule.roster"),
+			Run: func(ctx context.Context) error {
+				return scraper.Run(ctx)
+			},
+		},
+		{
+			Name:     "events",
+			Schedule: viper.GetString("schedule.events"),
+			Run: func(ctx context.Context) error {
+				return scraper.RunEvents(ctx, viper.GetInt("events.past_pages"))
+			},
+		},
+		{
+			Name:     "results",
+			Schedule: viper.GetString("schedule.results"),
+			Run: func(ctx context.Context) error {
+				return scraper.RunEvents(ctx, viper.GetInt("serve.results_past_pages"))
+			},
+		},
+	}
+}
+
+// runServe is the main function executed when the serve command is run.
+// It schedules the scrapes, starts the status endpoint and registers the daemon in the service registry.
+// On a signal it stops scheduling and waits up to serve.shutdown_timeout for the running scrape.
+func runServe(cmd *cobra.Command, args []string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if err := logger.Initialize(os.O_APPEND); err != nil {
+		return err
+	}
+	l := logger.Get()
+
+	shutdownTracing, err := tracing.Init(ctx, tracing.ViperConfig())
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("tracing.shutdown_timeout"))
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			l.Errorf("Unable to flush traces: %s", err)
+		}
+	}()
+
+	d, err := daemon.New(scheduledJobs())
+	if err != nil {
+		return err
+	}
+
+	addr := viper.GetString("serve.addr")
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("error while listening on %s: %w", addr, err)
+	}
+
+	registry, err := backend.FromViper()
+	if err != nil {
+		return err
+	}
+
+	advertised, err := advertiseAddr(lis.Addr())
+	if err != nil {
+		return err
+	}
+
+	instanceID := discovery.GenerateInstanceID(version.Name)
+	if err := registry.Register(ctx, instanceID, version.Name, advertised); err != nil {
+		return err
+	}
+
+	// The daemon is healthy while it schedules the scrapes.
+	checker := health.New(viper.GetDuration("health.timeout"))
+	checker.Add("scheduler", d.Check)
+
+	go health.Watch(ctx, checker, viper.GetDuration("health.interval"),
+		health.RegistryReporter(registry, instanceID, version.Name),
+	)
+
+	defer registry.Deregister(ctx, instanceID, version.Name)
+
+	srv := &http.Server{
+		Handler:           d.Handler(viper.GetString("serve.token")),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	d.Start()
+
+	sigx.Listen(func(signal os.Signal) {
+		l.Infow("shutdown", "signal", signal.String())
+		cancel()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), viper.GetDuration("serve.shutdown_timeout"))
+		defer cancel()
+
+		if err := d.Stop(shutdownCtx); err != nil {
+			l.Errorf("Running scrape is not finished: %s", err)
+		}
+
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			l.Errorf("Failed to shutdown status endpoint: %s", err)
+		}
+	})
+
+	fmt.Printf("Scraper daemon is listening on %s, registered at %s\n", lis.Addr(), advertised)
+	for _, s := range d.Status() {
+		fmt.Printf("  %-8s %-14s next run %s\n", s.Name, s.Schedule, s.NextRun.Format(time.RFC1123))
+	}
+
+	if err := srv.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+// advertiseAddr returns the address the daemon registers in the service registry: serve.advertise_addr
+// if it is set, and otherwise the host of serve.addr with the port of the listener. When serve.addr has no host
+// or listens on every interface, the host name of the machine is used.
+func advertiseAddr(lis net.Addr) (string, error) {
+	if addr := viper.GetString("serve.advertise_addr"); addr != "" {
+		return addr, nil
+	}
+
+	host, _, err := net.SplitHostPort(viper.GetString("serve.addr"))
+	if err != nil {
+		return "", err
+	}
+
+	_, port, err := net.SplitHostPort(lis.String())
+	if err != nil {
+		return "", err
+	}
+
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		if host, err = os.Hostname(); err != nil {
+			return "", fmt.Errorf("error while getting the host name, set serve.advertise_addr: %w", err)
+		}
+	}
+
+	return net.JoinHostPort(host, port), nil
+}
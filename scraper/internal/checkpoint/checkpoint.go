@@ -0,0 +1,219 @@
+package checkpoint
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"fightbettr.com/scraper/pkg/model"
+)
+
+// Entry represents a scraped athlete page together with the validators of the response
+// and the fighter parsed from it.
+type Entry struct {
+	Url          string        `json:"url"`
+	ETag         string        `json:"etag,omitempty"`
+	LastModified string        `json:"lastModified,omitempty"`
+	Hash         string        `json:"hash"`
+	FetchedAt    int64         `json:"fetchedAt"`
+	Fighter      model.Fighter `json:"fighter"`
+}
+
+// Store is a local checkpoint store of scraped athlete pages.
+// Every entry is appended to the file as a JSON line as soon as it is put, so the progress
+// survives a crash of the scraper. A later entry of the same url replaces the earlier one.
+// Store is safe for concurrent use.
+type Store struct {
+	mu      sync.Mutex
+	path    string
+	file    *os.File
+	entries map[string]Entry
+	order   []string
+}
+
+// Open opens the checkpoint store at the path and loads its entries.
+// If reset is true, the existing entries are discarded and the store starts empty.
+// A truncated last line, left by a crash in the middle of a write, is ignored.
+func Open(path string, reset bool) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		path:    path,
+		entries: make(map[string]Entry),
+	}
+
+	if !reset {
+		if err := s.load(); err != nil {
+			return nil, err
+		}
+	}
+
+	flag := os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	if reset {
+		flag |= os.O_TRUNC
+	}
+
+	file, err := os.OpenFile(path, flag, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.file = file
+
+	return s, nil
+}
+
+// load reads the entries from the checkpoint file, if it exists.
+// A truncated last line is cut off the file, so the next entry starts on a new line.
+func (s *Store) load() error {
+	file, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var offset int64
+
+	r := bufio.NewReader(file)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			if len(line) > 0 {
+				return os.Truncate(s.path, offset)
+			}
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+
+		s.set(e)
+		offset += int64(len(line))
+	}
+}
+
+// set stores the entry in memory keeping the order of the first appearance of the url.
+func (s *Store) set(e Entry) {
+	if _, ok := s.entries[e.Url]; !ok {
+		s.order = append(s.order, e.Url)
+	}
+
+	s.entries[e.Url] = e
+}
+
+// Get returns the entry of the url.
+func (s *Store) Get(url string) (Entry, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[url]
+	return e, ok
+}
+
+// Put appends the entry to the checkpoint file and syncs it to disk.
+func (s *Store) Put(e Entry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return err
+	}
+
+	s.set(e)
+
+	return nil
+}
+
+// Len returns the number of checkpointed urls.
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.order)
+}
+
+// Compact rewrites the checkpoint file keeping only the latest entry of every url.
+// The file is replaced atomically, so a crash during compaction keeps the previous file.
+func (s *Store) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for _, url := range s.order {
+		if err := enc.Encode(s.entries[url]); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+
+	s.file, err = os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND, 0644)
+	return err
+}
+
+// Close closes the checkpoint file.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// Hash returns the hex encoded SHA-256 hash of the page body.
+func Hash(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,181 @@
+package checkpoint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testEntries = []Entry{
+	{Url: "https://www.ufc.com/athlete/jon-jones", ETag: `"v1"`, Hash: "h1", Fighter: model.Fighter{Name: "Jon Jones"}},
+	{Url: "https://www.ufc.com/athlete/amanda-nunes", Hash: "h2", Fighter: model.Fighter{Name: "Amanda Nunes"}},
+	{Url: "https://www.ufc.com/athlete/jon-jones", ETag: `"v2"`, Hash: "h3", Fighter: model.Fighter{Name: "Jon 'Bones' Jones"}},
+}
+
+// openWith opens a new store in a temporary directory and puts the entries into it.
+func openWith(t *testing.T, entries ...Entry) (*Store, string) {
+	path := filepath.Join(t.TempDir(), "collection", "checkpoint.jsonl")
+
+	s, err := Open(path, false)
+	require.NoError(t, err)
+
+	for _, e := range entries {
+		require.NoError(t, s.Put(e))
+	}
+
+	return s, path
+}
+
+// lines returns the lines of the file.
+func lines(t *testing.T, path string) []string {
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestPutAndGet(t *testing.T) {
+	s, path := openWith(t, testEntries...)
+	defer s.Close()
+
+	assert.Equal(t, 2, s.Len())
+	assert.Len(t, lines(t, path), 3)
+
+	e, ok := s.Get(testEntries[0].Url)
+	require.True(t, ok)
+	assert.Equal(t, testEntries[2], e)
+
+	_, ok = s.Get("https://www.ufc.com/athlete/unknown")
+	assert.False(t, ok)
+}
+
+func TestOpenLaterEntryOverrides(t *testing.T) {
+	s, path := openWith(t, testEntries...)
+	require.NoError(t, s.Close())
+
+	s, err := Open(path, false)
+	require.NoError(t, err)
+	defer s.Close()
+
+	assert.Equal(t, 2, s.Len())
+
+	e, ok := s.Get(testEntries[0].Url)
+	require.True(t, ok)
+	assert.Equal(t, `"v2"`, e.ETag)
+	assert.Equal(t, "Jon 'Bones' Jones", e.Fighter.Name)
+
+	e, ok = s.Get(testEntries[1].Url)
+	require.True(t, ok)
+	assert.Equal(t, testEntries[1], e)
+}
+
+func TestOpenTruncatesPartialLastLine(t *testing.T) {
+	s, path := openWith(t, testEntries[:2]...)
+	require.NoError(t, s.Close())
+
+	complete, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"url":"https://www.ufc.com/athlete/new-prospect","ha`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	s, err = Open(path, false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, s.Len())
+	_, ok := s.Get("https://www.ufc.com/athlete/new-prospect")
+	assert.False(t, ok)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, complete, data, "the partial line is cut off the file")
+
+	// The next entry starts on its own line.
+	require.NoError(t, s.Put(testEntries[2]))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path, false)
+	require.NoError(t, err)
+	defer s.Close()
+
+	e, ok := s.Get(testEntries[2].Url)
+	require.True(t, ok)
+	assert.Equal(t, testEntries[2], e)
+}
+
+func TestOpenInvalidLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checkpoint.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("not json\n"), 0644))
+
+	_, err := Open(path, false)
+	assert.Error(t, err)
+}
+
+func TestOpenReset(t *testing.T) {
+	s, path := openWith(t, testEntries...)
+	require.NoError(t, s.Close())
+
+	s, err := Open(path, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, s.Len())
+	_, ok := s.Get(testEntries[0].Url)
+	assert.False(t, ok)
+	assert.Empty(t, lines(t, path))
+
+	require.NoError(t, s.Put(testEntries[1]))
+	require.NoError(t, s.Close())
+
+	s, err = Open(path, false)
+	require.NoError(t, err)
+	defer s.Close()
+
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestCompact(t *testing.T) {
+	s, path := openWith(t, testEntries...)
+	defer s.Close()
+
+	before, err := os.Stat(path)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Compact())
+
+	after, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.False(t, os.SameFile(before, after), "the file is replaced by a renamed temporary file")
+
+	dir, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	require.Len(t, dir, 1, "no temporary file is left")
+	assert.Equal(t, filepath.Base(path), dir[0].Name())
+
+	compacted := lines(t, path)
+	require.Len(t, compacted, 2)
+	assert.Contains(t, compacted[0], `"hash":"h3"`, "the latest entry is kept in the order of the first appearance")
+	assert.Contains(t, compacted[1], `"hash":"h2"`)
+
+	// The store keeps appending to the compacted file.
+	require.NoError(t, s.Put(Entry{Url: "https://www.ufc.com/athlete/new-prospect", Hash: "h4"}))
+	assert.Len(t, lines(t, path), 3)
+	assert.Equal(t, 3, s.Len())
+}
+
+func TestHash(t *testing.T) {
+	assert.Equal(t, Hash([]byte("page")), Hash([]byte("page")))
+	assert.NotEqual(t, Hash([]byte("page")), Hash([]byte("other page")))
+	assert.Len(t, Hash(nil), 64)
+}
@@ -0,0 +1,318 @@
+package daemon
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"fightbettr.com/scraper/pkg/logger"
+	"github.com/robfig/cron/v3"
+)
+
+// Statuses of a job run.
+const (
+	StatusOK      = "ok"
+	StatusFailed  = "failed"
+	StatusSkipped = "skipped"
+)
+
+// Job is a scrape that runs on a schedule. The schedule is a standard five-field cron expression
+// such as "0 3 * * 1", or a descriptor such as "@daily" or "@every 6h".
+type Job struct {
+	Name     string
+	Schedule string
+	Run      func(ctx context.Context) error
+}
+
+// RunInfo describes a single run of a job.
+type RunInfo struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Duration string    `json:"duration"`
+	Status   string    `json:"status"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// JobStatus is the state of a job reported by the status endpoint.
+type JobStatus struct {
+	Name     string    `json:"name"`
+	Schedule string    `json:"schedule"`
+	Running  bool      `json:"running"`
+	Runs     int       `json:"runs"`
+	Failures int       `json:"failures"`
+	Skipped  int       `json:"skipped"`
+	LastRun  *RunInfo  `json:"lastRun,omitempty"`
+	NextRun  time.Time `json:"nextRun"`
+}
+
+// job is a scheduled job together with its state.
+type job struct {
+	Job
+	entryID cron.EntryID
+	status  JobStatus
+}
+
+// Daemon runs the jobs on their schedules. The jobs share the state of the scraper, so a single lock
+// prevents overlapping runs: a job that is due while another one is running is skipped until its next run.
+type Daemon struct {
+	cron *cron.Cron
+
+	// lock is held during a run of any job.
+	lock sync.Mutex
+	// wg waits for the runs started by RunNow; the scheduler waits for its own runs.
+	wg sync.WaitGroup
+
+	mu   sync.Mutex
+	jobs map[string]*job
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// New creates a daemon with the jobs. Jobs with an empty schedule are disabled.
+// It returns an error if a schedule cannot be parsed or two jobs have the same name.
+func New(jobs []Job) (*Daemon, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &Daemon{
+		cron:   cron.New(),
+		jobs:   make(map[string]*job),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+
+	for _, j := range jobs {
+		if j.Schedule == "" {
+			continue
+		}
+
+		if _, ok := d.jobs[j.Name]; ok {
+			cancel()
+			return nil, fmt.Errorf("daemon: duplicate job '%s'", j.Name)
+		}
+
+		dj := &job{Job: j, status: JobStatus{Name: j.Name, Schedule: j.Schedule}}
+
+		id, err := d.cron.AddFunc(j.Schedule, func() { d.run(dj) })
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("daemon: schedule of job '%s': %w", j.Name, err)
+		}
+
+		dj.entryID = id
+		d.jobs[j.Name] = dj
+	}
+
+	if len(d.jobs) == 0 {
+		cancel()
+		return nil, fmt.Errorf("daemon: no jobs scheduled")
+	}
+
+	return d, nil
+}
+
+// Start starts running the jobs on their schedules.
+func (d *Daemon) Start() {
+	d.cron.Start()
+}
+
+// Stop stops scheduling new runs, cancels the context of the running job and waits until it returns
+// or the context is done.
+func (d *Daemon) Stop(ctx context.Context) error {
+	stopped := d.cron.Stop()
+	d.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		<-stopped.Done()
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Check is the health check of the daemon. It returns an error once the daemon is stopped,
+// since no job runs on its schedule anymore.
+func (d *Daemon) Check(ctx context.Context) error {
+	if d.ctx.Err() != nil {
+		return fmt.Errorf("daemon: stopped")
+	}
+
+	return nil
+}
+
+// RunNow starts a run of the job with the given name outside of its schedule.
+// The run is skipped like a scheduled one if another job is running.
+func (d *Daemon) RunNow(name string) error {
+	d.mu.Lock()
+	j, ok := d.jobs[name]
+	d.mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("daemon: unknown job '%s'", name)
+	}
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		d.run(j)
+	}()
+
+	return nil
+}
+
+// run runs the job unless another job holds the lock, and records the outcome of the run.
+func (d *Daemon) run(j *job) {
+	start := time.Now()
+
+	if !d.lock.TryLock() {
+		d.finish(j, RunInfo{Start: start, End: start, Status: StatusSkipped})
+		logger.Get().Warnw(j.Name, "type", "job skipped", "reason", "another job is running")
+		return
+	}
+	defer d.lock.Unlock()
+
+	d.mu.Lock()
+	j.status.Running = true
+	d.mu.Unlock()
+
+	logger.Get().Infow(j.Name, "type", "job started")
+
+	err := j.Run(d.ctx)
+
+	info := RunInfo{Start: start, End: time.Now(), Status: StatusOK}
+	if err != nil {
+		info.Status = StatusFailed
+		info.Error = err.Error()
+	}
+
+	d.finish(j, info)
+
+	// The jobs initialize the logger of the run, so the outcome is logged after the run.
+	logger.Get().Infow(j.Name, "type", "job finished", "status", info.Status, "duration", info.Duration, "error", info.Error)
+}
+
+// finish records the run of the job.
+func (d *Daemon) finish(j *job, info RunInfo) {
+	info.Duration = info.End.Sub(info.Start).Round(time.Millisecond).String()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	switch info.Status {
+	case StatusSkipped:
+		j.status.Skipped++
+	case StatusFailed:
+		j.status.Runs++
+		j.status.Failures++
+	default:
+		j.status.Runs++
+	}
+
+	if info.Status != StatusSkipped {
+		j.status.Running = false
+	}
+
+	j.status.LastRun = &info
+}
+
+// Status returns the state of every job ordered by name, together with the time of its next run.
+func (d *Daemon) Status() []JobStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(d.jobs))
+	for _, j := range d.jobs {
+		s := j.status
+		if s.LastRun != nil {
+			last := *s.LastRun
+			s.LastRun = &last
+		}
+		s.NextRun = d.cron.Entry(j.entryID).Next
+
+		statuses = append(statuses, s)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+// Handler returns the HTTP handler of the daemon:
+//
+//	GET  /status           the state of the jobs
+//	POST /jobs/{name}/run  starts a run of the job
+//	GET  /health           reports that the daemon is up
+//
+// A run can release fighters, so the run endpoint requires the token as a bearer token in the
+// Authorization header; with an empty token manual runs are disabled.
+func (d *Daemon) Handler(token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/status", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"jobs": d.Status()})
+	})
+
+	mux.HandleFunc("/jobs/", func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/jobs/"), "/run")
+		if !ok || name == "" || strings.Contains(name, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method != http.MethodPost {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if token == "" {
+			http.Error(w, "manual runs are disabled", http.StatusForbidden)
+			return
+		}
+
+		if !authorized(r, token) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+
+		if err := d.RunNow(name); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	return mux
+}
+
+// authorized reports whether the request carries the token as a bearer token.
+func authorized(r *http.Request, token string) bool {
+	got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(got), []byte(token)) == 1
+}
@@ -0,0 +1,251 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"fightbettr.com/scraper/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Set(zap.NewNop().Sugar())
+	os.Exit(m.Run())
+}
+
+func TestNew(t *testing.T) {
+	noop := func(ctx context.Context) error { return nil }
+
+	tests := []struct {
+		name    string
+		jobs    []Job
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "cron and descriptors",
+			jobs: []Job{{Name: "roster", Schedule: "0 3 * * 1", Run: noop}, {Name: "events", Schedule: "@every 6h", Run: noop}},
+			want: []string{"events", "roster"},
+		},
+		{
+			name: "disabled job",
+			jobs: []Job{{Name: "roster", Schedule: "@daily", Run: noop}, {Name: "events", Run: noop}},
+			want: []string{"roster"},
+		},
+		{
+			name:    "invalid schedule",
+			jobs:    []Job{{Name: "roster", Schedule: "every day", Run: noop}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate job",
+			jobs:    []Job{{Name: "roster", Schedule: "@daily", Run: noop}, {Name: "roster", Schedule: "@hourly", Run: noop}},
+			wantErr: true,
+		},
+		{
+			name:    "no jobs",
+			jobs:    []Job{{Name: "roster", Run: noop}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := New(tt.jobs)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, s := range d.Status() {
+				names = append(names, s.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestNextRun(t *testing.T) {
+	d, err := New([]Job{{Name: "events", Schedule: "@every 1h", Run: func(ctx context.Context) error { return nil }}})
+	require.NoError(t, err)
+
+	assert.True(t, d.Status()[0].NextRun.IsZero(), "next run is unknown until the daemon is started")
+
+	d.Start()
+	defer d.Stop(context.Background())
+
+	next := d.Status()[0].NextRun
+	assert.WithinDuration(t, time.Now().Add(time.Hour), next, time.Minute)
+}
+
+func TestOverlappingRuns(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	d, err := New([]Job{
+		{Name: "roster", Schedule: "@daily", Run: func(ctx context.Context) error {
+			close(started)
+			<-release
+			return nil
+		}},
+		{Name: "events", Schedule: "@daily", Run: func(ctx context.Context) error {
+			return errors.New("site is down")
+		}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, d.RunNow("roster"))
+	<-started
+
+	// Both jobs are skipped while the roster is scraped.
+	d.run(d.jobs["roster"])
+	d.run(d.jobs["events"])
+
+	status := statusByName(d.Status())
+	assert.True(t, status["roster"].Running)
+	assert.Equal(t, 1, status["roster"].Skipped)
+	assert.Equal(t, 1, status["events"].Skipped)
+	assert.Equal(t, StatusSkipped, status["events"].LastRun.Status)
+
+	close(release)
+	require.NoError(t, d.Stop(context.Background()))
+
+	d.run(d.jobs["events"])
+
+	status = statusByName(d.Status())
+	assert.False(t, status["roster"].Running)
+	assert.Equal(t, 1, status["roster"].Runs)
+	assert.Equal(t, StatusOK, status["roster"].LastRun.Status)
+	assert.Equal(t, 1, status["events"].Runs)
+	assert.Equal(t, 1, status["events"].Failures)
+	assert.Equal(t, StatusFailed, status["events"].LastRun.Status)
+	assert.Equal(t, "site is down", status["events"].LastRun.Error)
+}
+
+func TestStopWaitsForRun(t *testing.T) {
+	d, err := New([]Job{{Name: "roster", Schedule: "@daily", Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}}})
+	require.NoError(t, err)
+
+	require.NoError(t, d.RunNow("roster"))
+	require.Eventually(t, func() bool { return d.Status()[0].Running }, time.Second, time.Millisecond)
+
+	require.NoError(t, d.Stop(context.Background()))
+	assert.Equal(t, StatusFailed, d.Status()[0].LastRun.Status)
+}
+
+func TestCheck(t *testing.T) {
+	d, err := New([]Job{{Name: "roster", Schedule: "@daily", Run: func(ctx context.Context) error { return nil }}})
+	require.NoError(t, err)
+
+	d.Start()
+	assert.NoError(t, d.Check(context.Background()))
+
+	require.NoError(t, d.Stop(context.Background()))
+	assert.Error(t, d.Check(context.Background()))
+}
+
+func TestHandler(t *testing.T) {
+	ran := make(chan struct{}, 1)
+
+	d, err := New([]Job{{Name: "roster", Schedule: "@daily", Run: func(ctx context.Context) error {
+		ran <- struct{}{}
+		return nil
+	}}})
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(d.Handler("secret"))
+	defer srv.Close()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{name: "status", method: http.MethodGet, path: "/status", want: http.StatusOK},
+		{name: "status by post", method: http.MethodPost, path: "/status", want: http.StatusMethodNotAllowed},
+		{name: "health", method: http.MethodGet, path: "/health", want: http.StatusOK},
+		{name: "run without token", method: http.MethodPost, path: "/jobs/roster/run", want: http.StatusUnauthorized},
+		{name: "run with wrong token", method: http.MethodPost, path: "/jobs/roster/run", token: "guess", want: http.StatusUnauthorized},
+		{name: "run", method: http.MethodPost, path: "/jobs/roster/run", token: "secret", want: http.StatusAccepted},
+		{name: "run by get", method: http.MethodGet, path: "/jobs/roster/run", token: "secret", want: http.StatusMethodNotAllowed},
+		{name: "run unknown job", method: http.MethodPost, path: "/jobs/results/run", token: "secret", want: http.StatusNotFound},
+		{name: "unknown path", method: http.MethodPost, path: "/jobs/roster", want: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+			require.NoError(t, err)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			assert.Equal(t, tt.want, resp.StatusCode)
+		})
+	}
+
+	<-ran
+	require.NoError(t, d.Stop(context.Background()))
+
+	resp, err := http.Get(srv.URL + "/status")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body struct {
+		Jobs []JobStatus `json:"jobs"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	require.Len(t, body.Jobs, 1)
+	assert.Equal(t, "roster", body.Jobs[0].Name)
+	assert.Equal(t, "@daily", body.Jobs[0].Schedule)
+	assert.Equal(t, 1, body.Jobs[0].Runs)
+	assert.Equal(t, StatusOK, body.Jobs[0].LastRun.Status)
+}
+
+func TestHandlerWithoutToken(t *testing.T) {
+	d, err := New([]Job{{Name: "roster", Schedule: "@daily", Run: func(ctx context.Context) error { return nil }}})
+	require.NoError(t, err)
+	defer d.Stop(context.Background())
+
+	srv := httptest.NewServer(d.Handler(""))
+	defer srv.Close()
+
+	req, err := http.NewRequest(http.MethodPost, srv.URL+"/jobs/roster/run", nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer ")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Zero(t, d.Status()[0].Runs)
+}
+
+func statusByName(statuses []JobStatus) map[string]JobStatus {
+	res := make(map[string]JobStatus, len(statuses))
+	for _, s := range statuses {
+		res[s.Name] = s
+	}
+
+	return res
+}
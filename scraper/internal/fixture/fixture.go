@@ -0,0 +1,173 @@
+package fixture
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Origin is the site the fixtures are recorded from.
+const Origin = "https://www.ufc.com"
+
+var unsafeRe = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// FileName returns the fixture file name of the page url. The path segments and the query
+// are joined with underscores, so /athletes/all?page=2 is stored as athletes_all_page-2.html.
+func FileName(rawUrl string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.Trim(u.Path, "/")
+	if name == "" {
+		name = "index"
+	}
+
+	name = strings.ReplaceAll(name, "/", "_")
+	if u.RawQuery != "" {
+		name += "_" + strings.NewReplacer("=", "-", "&", "_").Replace(u.RawQuery)
+	}
+
+	return unsafeRe.ReplaceAllString(name, "-") + ".html", nil
+}
+
+// Record fetches the pages and writes every response body into dir as a fixture.
+// It returns the paths of the written files.
+func Record(ctx context.Context, client *http.Client, urls []string, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	var files []string
+
+	for _, u := range urls {
+		name, err := FileName(u)
+		if err != nil {
+			return files, err
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return files, err
+		}
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return files, err
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return files, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return files, fmt.Errorf("fixture: %s: unexpected status %s", u, resp.Status)
+		}
+
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, body, 0644); err != nil {
+			return files, err
+		}
+
+		files = append(files, path)
+	}
+
+	return files, nil
+}
+
+// Server is a local server that serves recorded fixtures and keeps the paths of the requests.
+type Server struct {
+	*httptest.Server
+
+	// Origin is the site whose absolute links are rewritten to the server url.
+	Origin string
+
+	dir      string
+	mu       sync.Mutex
+	requests []string
+	handlers map[string]http.HandlerFunc
+}
+
+// NewServer starts a local server that serves the fixtures from dir by the request url.
+// Absolute links to Origin in the fixtures are rewritten to the server url, so the collectors
+// never leave the server. Requests without a fixture get 404 Not Found.
+func NewServer(dir string) *Server {
+	return NewServerFor(dir, Origin)
+}
+
+// NewServerFor starts a local server like NewServer for the fixtures recorded from the given origin.
+func NewServerFor(dir, origin string) *Server {
+	s := &Server{Origin: origin, dir: dir, handlers: make(map[string]http.HandlerFunc)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.RequestURI())
+		h, ok := s.handlers[r.URL.Path]
+		s.mu.Unlock()
+
+		if ok {
+			h(w, r)
+			return
+		}
+
+		s.ServeFixture(w, r)
+	}))
+
+	return s
+}
+
+// Handle serves the requests to the path with h instead of the fixture.
+// The handler may still respond with the fixture by calling ServeFixture.
+func (s *Server) Handle(path string, h http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[path] = h
+}
+
+// ServeFixture responds with the fixture of the request url.
+func (s *Server) ServeFixture(w http.ResponseWriter, r *http.Request) {
+	name, err := FileName(r.URL.String())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	body, err := os.ReadFile(filepath.Join(s.dir, name))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(bytes.ReplaceAll(body, []byte(s.Origin), []byte(s.URL)))
+}
+
+// Requests returns the request uris received by the server in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string(nil), s.requests...)
+}
+
+// Reset forgets the received requests.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+}
@@ -0,0 +1,63 @@
+package fixture
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{url: "https://www.ufc.com/athletes/all", expected: "athletes_all.html"},
+		{url: "https://www.ufc.com/athletes/all?page=2", expected: "athletes_all_page-2.html"},
+		{url: "https://www.ufc.com/athlete/jon-jones", expected: "athlete_jon-jones.html"},
+		{url: "https://www.ufc.com/", expected: "index.html"},
+		{url: "/events", expected: "events.html"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			name, err := FileName(tc.url)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, name)
+		})
+	}
+}
+
+func TestRecordAndServe(t *testing.T) {
+	dir := t.TempDir()
+	page := `<a href="` + Origin + `/athlete/jon-jones">Jon Jones</a>`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "athletes_all.html"), []byte(page), 0644))
+
+	srv := NewServer(dir)
+	defer srv.Close()
+
+	out := t.TempDir()
+	files, err := Record(context.Background(), http.DefaultClient, []string{srv.URL + "/athletes/all"}, out)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(out, "athletes_all.html")}, files)
+
+	recorded, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, `<a href="`+srv.URL+`/athlete/jon-jones">Jon Jones</a>`, string(recorded))
+
+	resp, err := http.Get(srv.URL + "/athlete/unknown")
+	require.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	_, err = Record(context.Background(), http.DefaultClient, []string{srv.URL + "/athlete/unknown"}, out)
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"/athletes/all", "/athlete/unknown", "/athlete/unknown"}, srv.Requests())
+}
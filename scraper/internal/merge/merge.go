@@ -0,0 +1,157 @@
+package merge
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fightbettr.com/scraper/pkg/model"
+)
+
+// Set represents the fighters scraped from a single source.
+type Set struct {
+	Source   string
+	Fighters []model.Fighter
+}
+
+// Link represents the urls of the same athlete in two sources. Links are used for athletes
+// that can not be matched by the name and the debut date, e.g. because of a different spelling of the name.
+type Link struct {
+	From string `mapstructure:"from"`
+	To   string `mapstructure:"to"`
+}
+
+// skipFields lists the fields that are not merged. They identify the record in a single source
+// or hold the merge metadata.
+var skipFields = map[string]struct{}{
+	"fighter_id": {},
+	"stat_id":    {},
+	"fighterUrl": {},
+	"provenance": {},
+	"sourceUrls": {},
+}
+
+// Fighters reconciles the same athletes across the sets and returns the merged records.
+// The sets are given in the order of priority: the first set is the primary source, and a field
+// is taken from the first source in which it is not empty. The fighter url of a merged record is
+// the url in the highest-priority source the athlete was found in.
+// Athletes are matched by the configured links between their urls, or else by the normalized name
+// together with the debut date. Athletes without a debut date are matched only by the links.
+// Every merged record has the provenance of its fields and the urls of the athlete in every source.
+func Fighters(sets []Set, links []Link) []model.Fighter {
+	aliases := make(map[string][]string, len(links)*2)
+	for _, link := range links {
+		aliases[link.From] = append(aliases[link.From], link.To)
+		aliases[link.To] = append(aliases[link.To], link.From)
+	}
+
+	var merged []model.Fighter
+	byUrl := make(map[string]int)
+	byKey := make(map[string]int)
+
+	for _, set := range sets {
+		for _, f := range set.Fighters {
+			i, ok := find(f, aliases, byUrl, byKey)
+			if ok {
+				if url, exists := merged[i].SourceUrls[set.Source]; exists {
+					if url == f.FighterUrl {
+						continue
+					}
+
+					// another athlete of the same source with the same name and debut
+					ok = false
+				}
+			}
+
+			if !ok {
+				i = len(merged)
+				merged = append(merged, model.Fighter{
+					FighterUrl: f.FighterUrl,
+					Provenance: map[string]string{},
+					SourceUrls: map[string]string{},
+				})
+			}
+
+			mergeFields(reflect.ValueOf(&merged[i]).Elem(), reflect.ValueOf(f), "", set.Source, merged[i].Provenance)
+			merged[i].SourceUrls[set.Source] = f.FighterUrl
+
+			byUrl[f.FighterUrl] = i
+			if k, ok := key(merged[i]); ok {
+				if _, exists := byKey[k]; !exists {
+					byKey[k] = i
+				}
+			}
+		}
+	}
+
+	return merged
+}
+
+// find returns the index of the merged record of the fighter.
+func find(f model.Fighter, aliases map[string][]string, byUrl, byKey map[string]int) (int, bool) {
+	if i, ok := byUrl[f.FighterUrl]; ok {
+		return i, true
+	}
+
+	for _, alias := range aliases[f.FighterUrl] {
+		if i, ok := byUrl[alias]; ok {
+			return i, true
+		}
+	}
+
+	if k, ok := key(f); ok {
+		if i, ok := byKey[k]; ok {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+// key returns the matching key of the fighter built from the normalized name and the debut date.
+// It reports false if the fighter has no name or no debut date.
+func key(f model.Fighter) (string, bool) {
+	name := normalizeName(f.Name)
+	if name == "" || f.DebutTimestamp == 0 {
+		return "", false
+	}
+
+	const day = 24 * 60 * 60
+
+	return name + "|" + strconv.Itoa(f.DebutTimestamp/day), true
+}
+
+// normalizeName lowercases the name and keeps only letters and digits separated by single spaces.
+func normalizeName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	return strings.Join(words, " ")
+}
+
+// mergeFields sets the empty fields of dst to the values of src and records the source of every set field.
+// Nested structs are merged recursively with the JSON name of the struct as the prefix.
+func mergeFields(dst, src reflect.Value, prefix, source string, provenance map[string]string) {
+	t := dst.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if _, skip := skipFields[name]; skip || name == "" || name == "-" {
+			continue
+		}
+
+		df, sf := dst.Field(i), src.Field(i)
+
+		if df.Kind() == reflect.Struct {
+			mergeFields(df, sf, prefix+name+".", source, provenance)
+			continue
+		}
+
+		if df.IsZero() && !sf.IsZero() {
+			df.Set(sf)
+			provenance[prefix+name] = source
+		}
+	}
+}
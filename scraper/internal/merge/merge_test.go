@@ -0,0 +1,101 @@
+package merge
+
+import (
+	"testing"
+
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFighters(t *testing.T) {
+	primary := Set{Source: "ufc", Fighters: []model.Fighter{
+		{Name: "Jon Jones", FighterUrl: "ufc/jon-jones", DebutTimestamp: 1218240000, Height: 76, Stats: model.FighterStats{StrAccuracy: 57}},
+		{Name: "New Prospect", FighterUrl: "ufc/new-prospect", Height: 66},
+		{Name: "Jon Jones", FighterUrl: "ufc/jon-jones-2", DebutTimestamp: 1218240000},
+		{Name: "Jon Jones", FighterUrl: "ufc/jon-jones", DebutTimestamp: 1218240000, Height: 99},
+	}}
+
+	secondary := Set{Source: "stats", Fighters: []model.Fighter{
+		{Name: "JON  JONES", FighterUrl: "stats/1", DebutTimestamp: 1218240000 + 3600, Height: 77, Reach: 84, Stats: model.FighterStats{StrAccuracy: 58, TkdAccuracy: 45}},
+		{Name: "Prospect", FighterUrl: "stats/2", Reach: 68},
+		{Name: "Khabib Nurmagomedov", FighterUrl: "stats/3", DebutTimestamp: 1327017600},
+	}}
+
+	links := []Link{{From: "stats/2", To: "ufc/new-prospect"}}
+
+	merged := Fighters([]Set{primary, secondary}, links)
+	require.Len(t, merged, 4)
+
+	tests := []struct {
+		name               string
+		fighter            model.Fighter
+		expectedUrl        string
+		expectedSourceUrls map[string]string
+		expectedProvenance map[string]string
+	}{
+		{
+			name:               "Matched by name and debut day",
+			fighter:            merged[0],
+			expectedUrl:        "ufc/jon-jones",
+			expectedSourceUrls: map[string]string{"ufc": "ufc/jon-jones", "stats": "stats/1"},
+			expectedProvenance: map[string]string{
+				"name": "ufc", "debutTimestamp": "ufc", "height": "ufc", "stats.strAccuracy": "ufc",
+				"reach": "stats", "stats.tkdAccuracy": "stats",
+			},
+		},
+		{
+			name:               "Matched by link",
+			fighter:            merged[1],
+			expectedUrl:        "ufc/new-prospect",
+			expectedSourceUrls: map[string]string{"ufc": "ufc/new-prospect", "stats": "stats/2"},
+			expectedProvenance: map[string]string{"name": "ufc", "height": "ufc", "reach": "stats"},
+		},
+		{
+			name:               "Namesake of the same source",
+			fighter:            merged[2],
+			expectedUrl:        "ufc/jon-jones-2",
+			expectedSourceUrls: map[string]string{"ufc": "ufc/jon-jones-2"},
+			expectedProvenance: map[string]string{"name": "ufc", "debutTimestamp": "ufc"},
+		},
+		{
+			name:               "Only in the secondary source",
+			fighter:            merged[3],
+			expectedUrl:        "stats/3",
+			expectedSourceUrls: map[string]string{"stats": "stats/3"},
+			expectedProvenance: map[string]string{"name": "stats", "debutTimestamp": "stats"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedUrl, tc.fighter.FighterUrl)
+			assert.Equal(t, tc.expectedSourceUrls, tc.fighter.SourceUrls)
+			assert.Equal(t, tc.expectedProvenance, tc.fighter.Provenance)
+		})
+	}
+
+	// the primary source wins, and the duplicate of the same url is ignored
+	assert.Equal(t, float32(76), merged[0].Height)
+	assert.Equal(t, 57, merged[0].Stats.StrAccuracy)
+	assert.Equal(t, float32(84), merged[0].Reach)
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Jon Jones", expected: "jon jones"},
+		{name: "  JON   JONES ", expected: "jon jones"},
+		{name: "Jon 'Bones' Jones", expected: "jon bones jones"},
+		{name: "Jan Błachowicz", expected: "jan błachowicz"},
+		{name: "", expected: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeName(tc.name))
+		})
+	}
+}
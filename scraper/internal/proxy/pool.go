@@ -0,0 +1,273 @@
+package proxy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultScheme is the scheme of proxy entries given as a bare host:port.
+const DefaultScheme = "socks5h"
+
+// ErrNoProxy is returned when every proxy of the pool is quarantined.
+var ErrNoProxy = errors.New("no healthy proxy available")
+
+// schemes lists the supported proxy schemes.
+var schemes = map[string]struct{}{
+	"http":    {},
+	"https":   {},
+	"socks5":  {},
+	"socks5h": {},
+}
+
+// Options configure when a proxy is quarantined.
+type Options struct {
+	// MaxFailures is the number of consecutive failures after which a proxy is quarantined.
+	MaxFailures int
+	// Cooldown is how long a quarantined proxy is not used.
+	Cooldown time.Duration
+}
+
+// Stats is the health of a single proxy of the pool.
+type Stats struct {
+	// Proxy is the proxy url with the password redacted.
+	Proxy       string
+	Requests    int
+	Successes   int
+	Failures    int
+	Quarantines int
+	AvgLatency  time.Duration
+	// QuarantinedUntil is the end of the current quarantine, zero if the proxy is in use.
+	QuarantinedUntil time.Time
+}
+
+// proxy is a proxy of the pool together with its health.
+type proxy struct {
+	url              *url.URL
+	requests         int
+	successes        int
+	failures         int
+	consecutive      int
+	quarantines      int
+	latency          time.Duration
+	quarantinedUntil time.Time
+}
+
+// assignment is the proxy assigned to a request in flight.
+type assignment struct {
+	proxy *proxy
+	start time.Time
+}
+
+// Pool assigns proxies to requests in turn and tracks the success, failure and latency of every proxy.
+// A proxy that fails MaxFailures times in a row is quarantined for Cooldown; after the cooldown it is
+// used again, but a single failure quarantines it once more until it succeeds.
+type Pool struct {
+	opts      Options
+	transport http.RoundTripper
+
+	mu       sync.Mutex
+	proxies  []*proxy
+	next     int
+	assigned map[*http.Request]assignment
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// New creates a pool of the proxy entries. An entry is a proxy url with the http, https, socks5 or socks5h scheme
+// and optional credentials, or a bare host:port that is used with DefaultScheme and the given login and password.
+func New(entries []string, login, password string, opts Options) (*Pool, error) {
+	if len(entries) == 0 {
+		return nil, errors.New("proxy: no proxies configured")
+	}
+
+	if opts.MaxFailures <= 0 {
+		opts.MaxFailures = 1
+	}
+
+	p := &Pool{
+		opts:     opts,
+		assigned: make(map[*http.Request]assignment),
+		now:      time.Now,
+	}
+
+	for _, entry := range entries {
+		u, err := parseEntry(entry, login, password)
+		if err != nil {
+			return nil, err
+		}
+
+		p.proxies = append(p.proxies, &proxy{url: u})
+	}
+
+	p.transport = &roundTripper{
+		pool: p,
+		next: &http.Transport{Proxy: p.Proxy},
+	}
+
+	return p, nil
+}
+
+// parseEntry parses a proxy entry of the pool.
+func parseEntry(entry, login, password string) (*url.URL, error) {
+	entry = strings.TrimSpace(entry)
+	if !strings.Contains(entry, "://") {
+		entry = DefaultScheme + "://" + entry
+	}
+
+	u, err := url.Parse(entry)
+	if err != nil {
+		return nil, fmt.Errorf("proxy: %w", err)
+	}
+
+	if _, ok := schemes[u.Scheme]; !ok {
+		return nil, fmt.Errorf("proxy: unsupported scheme '%s' of %s", u.Scheme, u.Redacted())
+	}
+
+	if u.Host == "" {
+		return nil, fmt.Errorf("proxy: missing host in %s", u.Redacted())
+	}
+
+	if u.User == nil && login != "" {
+		u.User = url.UserPassword(login, password)
+	}
+
+	return u, nil
+}
+
+// Transport returns the transport that sends the requests through the proxies of the pool. It is shared by
+// every collector of the run, so connections to the same proxy are reused.
+func (p *Pool) Transport() http.RoundTripper {
+	return p.transport
+}
+
+// Proxy is the colly.ProxyFunc of the pool. It assigns the next proxy that is not quarantined to the request,
+// or returns ErrNoProxy if every proxy is quarantined. A request retried by the transport keeps its proxy.
+func (p *Pool) Proxy(req *http.Request) (*url.URL, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if a, ok := p.assigned[req]; ok {
+		return a.proxy.url, nil
+	}
+
+	now := p.now()
+	for i := 0; i < len(p.proxies); i++ {
+		pr := p.proxies[p.next]
+		p.next = (p.next + 1) % len(p.proxies)
+
+		if now.Before(pr.quarantinedUntil) {
+			continue
+		}
+
+		p.assigned[req] = assignment{proxy: pr, start: now}
+
+		return pr.url, nil
+	}
+
+	return nil, ErrNoProxy
+}
+
+// observe records the outcome of the request sent through its assigned proxy.
+func (p *Pool) observe(req *http.Request, resp *http.Response, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	a, ok := p.assigned[req]
+	if !ok {
+		return
+	}
+	delete(p.assigned, req)
+
+	now := p.now()
+	pr := a.proxy
+	pr.requests++
+	pr.latency += now.Sub(a.start)
+
+	if !failed(resp, err) {
+		pr.successes++
+		pr.consecutive = 0
+		return
+	}
+
+	pr.failures++
+	pr.consecutive++
+
+	if pr.consecutive >= p.opts.MaxFailures {
+		pr.quarantines++
+		pr.quarantinedUntil = now.Add(p.opts.Cooldown)
+		// After the cooldown the proxy is on probation: the next failure quarantines it again.
+		pr.consecutive = p.opts.MaxFailures - 1
+	}
+}
+
+// failed reports whether the response means that the proxy failed: the request could not be sent,
+// the proxy rejected it or could not reach the site, or the site blocked or throttled the proxy address.
+func failed(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusForbidden, http.StatusProxyAuthRequired, http.StatusTooManyRequests,
+		http.StatusBadGateway, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// Stats returns the health of every proxy of the pool, the most failing proxies first.
+func (p *Pool) Stats() []Stats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	stats := make([]Stats, 0, len(p.proxies))
+
+	for _, pr := range p.proxies {
+		s := Stats{
+			Proxy:       pr.url.Redacted(),
+			Requests:    pr.requests,
+			Successes:   pr.successes,
+			Failures:    pr.failures,
+			Quarantines: pr.quarantines,
+		}
+
+		if pr.requests > 0 {
+			s.AvgLatency = pr.latency / time.Duration(pr.requests)
+		}
+
+		if now.Before(pr.quarantinedUntil) {
+			s.QuarantinedUntil = pr.quarantinedUntil
+		}
+
+		stats = append(stats, s)
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].Failures > stats[j].Failures
+	})
+
+	return stats
+}
+
+// roundTripper sends the requests with the next transport and records their outcome in the pool.
+type roundTripper struct {
+	pool *Pool
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	rt.pool.observe(req, resp, err)
+
+	return resp, err
+}
@@ -0,0 +1,289 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+)
+
+// HostLimit is a rate limit of the requests to the hosts matching DomainGlob.
+// It is configured in the limits list and takes precedence over the limit of the source.
+type HostLimit struct {
+	DomainGlob  string        `mapstructure:"domain_glob"`
+	Parallelism int           `mapstructure:"parallelism"`
+	Delay       time.Duration `mapstructure:"delay"`
+	RandomDelay time.Duration `mapstructure:"random_delay"`
+}
+
+// progress counts the pages of a run. A page is queued from its first request until it is scraped or failed,
+// retries of the same page are not counted again.
+type progress struct {
+	requested atomic.Int64
+	done      atomic.Int64
+	failed    atomic.Int64
+}
+
+// runProgress is the progress of the current run, shared by all collectors of the run.
+var runProgress = &progress{}
+
+// runCtx is the context of the current run. Once it is done, the collectors of the run abort their requests
+// and the retries stop waiting, so the run returns as soon as the requests in flight are finished.
+var runCtx = context.Background()
+
+// String returns the progress in the form printed during the run.
+func (p *progress) String() string {
+	done, failed := p.done.Load(), p.failed.Load()
+	queued := p.requested.Load() - done - failed
+
+	return fmt.Sprintf("Pages done: %d, queued: %d, failed: %d", done, queued, failed)
+}
+
+// report prints the progress every interval until the returned function is called.
+// The stop function prints the final progress. A non-positive interval reports only the final progress.
+func (p *progress) report(interval time.Duration) (stop func()) {
+	quit := make(chan struct{})
+	var once sync.Once
+	var wg sync.WaitGroup
+
+	if interval > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ticker.C:
+					p.log()
+				case <-quit:
+					return
+				}
+			}
+		}()
+	}
+
+	return func() {
+		once.Do(func() {
+			close(quit)
+			wg.Wait()
+			p.log()
+		})
+	}
+}
+
+// log prints the progress to the console and the log.
+func (p *progress) log() {
+	fmt.Fprintln(out, p.String())
+	l.Infow("progress", "type", "progress",
+		"done", p.done.Load(), "failed", p.failed.Load(), "requested", p.requested.Load())
+}
+
+// newCollector creates an asynchronous collector configured from the concurrency options.
+// At most parallelism pages are fetched at once. The limits list is applied before the default
+// rule of the source, robots.txt is respected unless ignore_robots is set, and failed requests
+// are retried by handleError.
+func newCollector(def *colly.LimitRule) (*colly.Collector, error) {
+	c := colly.NewCollector(colly.Async(true))
+	c.IgnoreRobotsTxt = viper.GetBool("ignore_robots")
+
+	rules, err := limitRules(def)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.Limits(rules); err != nil {
+		return nil, err
+	}
+
+	c.OnRequest(abortOnDone)
+	c.OnRequest(countRequest)
+	c.OnScraped(countScraped)
+
+	return c, nil
+}
+
+// cloneCollector creates a collector that shares the configuration, the limits and the robots.txt cache of c,
+// so the limits apply to the requests of both collectors together.
+func cloneCollector(c *colly.Collector) *colly.Collector {
+	clone := c.Clone()
+	clone.OnRequest(abortOnDone)
+	clone.OnRequest(countRequest)
+	clone.OnScraped(countScraped)
+
+	return clone
+}
+
+// limitRules returns the rules of the limits list followed by the default rule of the source.
+// Rules without parallelism get the parallelism option.
+func limitRules(def *colly.LimitRule) ([]*colly.LimitRule, error) {
+	var limits []HostLimit
+	if err := viper.UnmarshalKey("limits", &limits); err != nil {
+		return nil, fmt.Errorf("limits: %w", err)
+	}
+
+	parallelism := viper.GetInt("parallelism")
+
+	rules := make([]*colly.LimitRule, 0, len(limits)+1)
+	for _, lim := range limits {
+		rules = append(rules, &colly.LimitRule{
+			DomainGlob:  lim.DomainGlob,
+			Parallelism: lim.Parallelism,
+			Delay:       lim.Delay,
+			RandomDelay: lim.RandomDelay,
+		})
+	}
+
+	rule := *def
+	rules = append(rules, &rule)
+
+	for _, r := range rules {
+		if r.Parallelism <= 0 {
+			r.Parallelism = parallelism
+		}
+	}
+
+	return rules, nil
+}
+
+// abortOnDone is a callback function used with colly that aborts the request once the context of the run
+// is done. The aborted page is counted as failed.
+func abortOnDone(r *colly.Request) {
+	if runCtx.Err() == nil {
+		return
+	}
+
+	r.Abort()
+	runProgress.failed.Add(1)
+}
+
+// countRequest is a callback function used with colly that counts the page as queued on its first request.
+func countRequest(r *colly.Request) {
+	if r.Ctx.Get(retriesKey(r)) == "" {
+		runProgress.requested.Add(1)
+	}
+}
+
+// countScraped is a callback function used with colly that counts the page as done.
+func countScraped(r *colly.Response) {
+	runProgress.done.Add(1)
+}
+
+// retry retries the request after a backoff if it failed with 429 Too Many Requests, a 5xx status
+// or without a response, and it has been retried fewer than retry.max times. The backoff starts at
+// retry.base_delay and doubles with every attempt up to retry.max_delay; a Retry-After header of the
+// response is used instead when present. It returns true if the request is retried.
+func retry(r *colly.Response, err error) bool {
+	if !retryable(r.StatusCode) {
+		return false
+	}
+
+	key := retriesKey(r.Request)
+	attempt, _ := strconv.Atoi(r.Ctx.Get(key))
+	if attempt >= viper.GetInt("retry.max") {
+		return false
+	}
+
+	wait := backoff(attempt, r.Headers)
+
+	l.Infow(r.Request.URL.String(), "type", "retry",
+		"status", r.StatusCode, "attempt", attempt+1, "wait", wait.String(), "error", err)
+
+	select {
+	case <-time.After(wait):
+	case <-runCtx.Done():
+		return false
+	}
+
+	r.Ctx.Put(key, strconv.Itoa(attempt+1))
+	if err := r.Request.Retry(); err != nil {
+		l.Errorf("Retry error [%s]: %s", r.Request.URL, err)
+		return false
+	}
+
+	return true
+}
+
+// retriesKey returns the request context key of the number of retries of the request.
+// The key includes the url because the pages visited from a page share its context.
+func retriesKey(r *colly.Request) string {
+	return "retries:" + r.URL.String()
+}
+
+// retryable reports whether a request with the status should be retried.
+// A zero status means the request failed without a response.
+func retryable(status int) bool {
+	return status == 0 || status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// backoff returns how long to wait before the retry with the given number of previous attempts.
+func backoff(attempt int, headers *http.Header) time.Duration {
+	maxDelay := viper.GetDuration("retry.max_delay")
+
+	if headers != nil {
+		if secs, err := strconv.Atoi(headers.Get("Retry-After")); err == nil && secs >= 0 {
+			wait := time.Duration(secs) * time.Second
+			if maxDelay > 0 && wait > maxDelay {
+				wait = maxDelay
+			}
+
+			return wait
+		}
+	}
+
+	wait := viper.GetDuration("retry.base_delay") << attempt
+	if wait > 0 {
+		wait += time.Duration(rand.Int63n(int64(wait)/2 + 1))
+	}
+
+	if maxDelay > 0 && wait > maxDelay {
+		wait = maxDelay
+	}
+
+	return wait
+}
+
+// handleError is a callback function used with colly that retries failed requests. When the request is
+// not retried, a 304 Not Modified athlete page is taken from the checkpoint and other errors are logged
+// and counted as failed pages.
+func handleError(r *colly.Response, err error) {
+	if retry(r, err) {
+		return
+	}
+
+	if r.StatusCode == http.StatusNotModified && store != nil {
+		if e, ok := store.Get(r.Request.URL.String()); ok {
+			reuseCheckpoint(e, e.ETag, e.LastModified)
+			runProgress.done.Add(1)
+			return
+		}
+	}
+
+	runProgress.failed.Add(1)
+	l.Errorf("Request error [%s]: %s", r.Request.URL, err)
+}
+
+// visit visits the url with the visit function of a collector or a request. Urls blocked by robots.txt
+// are logged and counted as failed pages like other urls the collector rejects.
+func visit(visitFn func(string) error, url string) {
+	switch err := visitFn(url); err {
+	case nil, colly.ErrAlreadyVisited:
+		return
+	case colly.ErrRobotsTxtBlocked:
+		l.Infow(url, "type", "blocked by robots.txt")
+	default:
+		l.Errorf("Request error [%s]: %s", url, err)
+	}
+
+	runProgress.requested.Add(1)
+	runProgress.failed.Add(1)
+}
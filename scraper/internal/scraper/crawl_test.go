@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fightbettr.com/scraper/internal/fixture"
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectFightersRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int32
+		status   int
+		fighters int
+		failed   int64
+	}{
+		{name: "service unavailable", failures: 2, status: http.StatusServiceUnavailable, fighters: 3},
+		{name: "too many requests", failures: 1, status: http.StatusTooManyRequests, fighters: 3},
+		{name: "retries exhausted", failures: 10, status: http.StatusBadGateway, fighters: 0, failed: 1},
+		{name: "not retryable", failures: 1, status: http.StatusForbidden, fighters: 0, failed: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := fixture.NewServer(fixturesDir)
+			defer srv.Close()
+
+			var calls atomic.Int32
+			srv.Handle("/athletes/all", func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.Header().Set("Retry-After", "0")
+					w.WriteHeader(tt.status)
+					return
+				}
+
+				srv.ServeFixture(w, r)
+			})
+
+			openTestStore(t)
+
+			require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false))
+
+			assert.Len(t, collection.Fighters, tt.fighters)
+			assert.Equal(t, tt.failed, runProgress.failed.Load())
+		})
+	}
+}
+
+func TestCollectFightersRobots(t *testing.T) {
+	viper.Set("ignore_robots", false)
+	defer viper.Set("ignore_robots", true)
+
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	srv.Handle("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("User-agent: *\nDisallow: /athlete/jon-jones\n"))
+	})
+
+	openTestStore(t)
+
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false))
+
+	require.Len(t, collection.Fighters, 2)
+	for _, f := range collection.Fighters {
+		assert.NotEqual(t, srv.URL+"/athlete/jon-jones", f.FighterUrl)
+	}
+	assert.NotContains(t, srv.Requests(), "/athlete/jon-jones")
+	assert.Equal(t, int64(1), runProgress.failed.Load())
+}
+
+func TestProgress(t *testing.T) {
+	p := &progress{}
+	p.requested.Add(5)
+	p.done.Add(3)
+	p.failed.Add(1)
+
+	assert.Equal(t, "Pages done: 3, queued: 1, failed: 1", p.String())
+}
+
+func TestBackoff(t *testing.T) {
+	viper.Set("retry.base_delay", time.Second)
+	viper.Set("retry.max_delay", 5*time.Second)
+	defer func() {
+		viper.Set("retry.base_delay", time.Duration(0))
+		viper.Set("retry.max_delay", time.Duration(0))
+	}()
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		min, max   time.Duration
+	}{
+		{name: "first attempt", attempt: 0, min: time.Second, max: 1500 * time.Millisecond},
+		{name: "third attempt", attempt: 2, min: 4 * time.Second, max: 5 * time.Second},
+		{name: "capped", attempt: 5, min: 5 * time.Second, max: 5 * time.Second},
+		{name: "retry after", attempt: 3, retryAfter: "2", min: 2 * time.Second, max: 2 * time.Second},
+		{name: "retry after capped", attempt: 0, retryAfter: "60", min: 5 * time.Second, max: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tt.retryAfter != "" {
+				headers.Set("Retry-After", tt.retryAfter)
+			}
+
+			wait := backoff(tt.attempt, &headers)
+
+			assert.GreaterOrEqual(t, wait, tt.min)
+			assert.LessOrEqual(t, wait, tt.max)
+		})
+	}
+}
+
+func TestLimitRules(t *testing.T) {
+	viper.Set("limits", []map[string]any{
+		{"domain_glob": "*.ufc.com", "parallelism": 1, "delay": "2s"},
+		{"domain_glob": "ufcstats.com", "random_delay": "500ms"},
+	})
+	defer viper.Set("limits", nil)
+
+	rules, err := limitRules(&colly.LimitRule{DomainGlob: "*", RandomDelay: time.Second})
+	require.NoError(t, err)
+
+	require.Len(t, rules, 3)
+	assert.Equal(t, &colly.LimitRule{DomainGlob: "*.ufc.com", Parallelism: 1, Delay: 2 * time.Second}, rules[0])
+	assert.Equal(t, &colly.LimitRule{DomainGlob: "ufcstats.com", Parallelism: 4, RandomDelay: 500 * time.Millisecond}, rules[1])
+	assert.Equal(t, &colly.LimitRule{DomainGlob: "*", Parallelism: 4, RandomDelay: time.Second}, rules[2])
+}
+
+func TestCollectFightersCanceled(t *testing.T) {
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	openTestStore(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := collectFighters(ctx, ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	assert.Empty(t, collection.Fighters)
+	assert.Empty(t, srv.Requests())
+}
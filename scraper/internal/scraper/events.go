@@ -0,0 +1,284 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fightbettr.com/scraper/internal/scraperutil"
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/model"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+)
+
+var eventCollector *colly.Collector
+var eventDetailsCollector *colly.Collector
+var eventsCollection = model.EventsCollection{}
+var eventsMu sync.Mutex
+
+// RunEvents is responsible for scraping the events listing. It visits the upcoming events and
+// the given number of pages of completed events, scrapes the fight card of every event
+// together with the results of completed bouts and saves the collected data to the JSON file at events.output_path.
+// When the context is done, the collectors stop fetching pages and nothing is saved.
+// An error is returned if the collection can not be saved.
+func RunEvents(ctx context.Context, pastPages int) error {
+	useProxy := viper.GetBool("proxy")
+	toAdd := viper.GetBool("add")
+
+	logFlag := scraperutil.GetLoggerFlag(toAdd)
+	if err := logger.Initialize(logFlag); err != nil {
+		fmt.Fprintln(out, "Error while initializing logger: ", err)
+		return err
+	}
+
+	l = logger.Get()
+
+	if useProxy {
+		if err := openProxyPool(); err != nil {
+			return fmt.Errorf("error while creating proxy pool: %w", err)
+		}
+		defer reportProxies()
+	}
+
+	if err := collectEvents(ctx, viper.GetString("base_url")+"/events", pastPages, useProxy); err != nil {
+		return fmt.Errorf("error while request: %w", err)
+	}
+
+	fmt.Fprintln(out, "DONE")
+	l.Infow("DONE", "type", "result")
+
+	if err := saveEventsToJSON(viper.GetString("events.output_path"), eventsCollection, toAdd); err != nil {
+		return fmt.Errorf("error while saving events: %w", err)
+	}
+
+	return nil
+}
+
+// collectEvents creates the collectors, visits the events listing at eventsUrl together with the given number
+// of pages of completed events, and waits until every event page found there is parsed into the EventsCollection.
+// The event pages are fetched concurrently, so the collection is sorted by the event url.
+// If the context is done, the remaining pages are not fetched and the error of the context is returned.
+func collectEvents(ctx context.Context, eventsUrl string, pastPages int, useProxy bool) error {
+	runCtx = ctx
+	eventsCollection = model.EventsCollection{}
+
+	var err error
+	eventCollector, err = newCollector(&colly.LimitRule{
+		DomainGlob:  "*",
+		RandomDelay: viper.GetDuration("delay"),
+	})
+	if err != nil {
+		return err
+	}
+
+	eventDetailsCollector = cloneCollector(eventCollector)
+
+	runProgress = &progress{}
+	stop := runProgress.report(viper.GetDuration("progress.interval"))
+	defer stop()
+
+	if useProxy {
+		useProxies(eventCollector)
+	}
+
+	eventCollector.OnRequest(setUserAgent)
+	eventDetailsCollector.OnRequest(setUserAgent)
+	eventCollector.OnError(handleError)
+	eventDetailsCollector.OnError(handleError)
+
+	eventCollector.OnHTML("#events-list-upcoming .c-card-event--result__headline a[href]", parseEventsListing)
+	if pastPages > 0 {
+		eventCollector.OnHTML("#events-list-past .c-card-event--result__headline a[href]", parseEventsListing)
+	}
+	eventDetailsCollector.OnHTML("body", getEventData)
+
+	if err := eventCollector.Visit(eventsUrl); err != nil {
+		return err
+	}
+
+	for page := 1; page < pastPages; page++ {
+		visit(eventCollector.Visit, fmt.Sprintf("%s?page=%d", eventsUrl, page))
+	}
+
+	eventCollector.Wait()
+	eventDetailsCollector.Wait()
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sort.Slice(eventsCollection.Events, func(i, j int) bool {
+		return eventsCollection.Events[i].EventUrl < eventsCollection.Events[j].EventUrl
+	})
+
+	return nil
+}
+
+// parseEventsListing is a callback function used with colly that extracts event URLs from the events listing.
+// It converts the event URL to an absolute URL and uses the eventDetailsCollector to visit the event page.
+func parseEventsListing(e *colly.HTMLElement) {
+	wg.Add(1)
+	defer wg.Done()
+
+	eventURL := e.Request.AbsoluteURL(e.Attr("href"))
+
+	fmt.Fprintln(out, "Event link:", eventURL)
+	l.Infow(eventURL, "type", "event link")
+
+	visit(eventDetailsCollector.Visit, eventURL)
+}
+
+// getEventData is a callback function used with colly that extracts the event and its fight card
+// from the event page and appends it to the EventsCollection.
+func getEventData(e *colly.HTMLElement) {
+	wg.Add(1)
+	defer wg.Done()
+
+	event := ParseEvent(e.DOM, e.Request.URL.String())
+	if len(event.Fights) == 0 {
+		l.Infow(event.EventUrl, "type", "event without fights")
+		return
+	}
+
+	eventsMu.Lock()
+	eventsCollection.Events = append(eventsCollection.Events, event)
+	eventsMu.Unlock()
+}
+
+// ParseEvent parses the event page and returns the event with all bouts of the fight card.
+// The event is considered done when all of its bouts have a result.
+func ParseEvent(page *goquery.Selection, eventUrl string) model.Event {
+	event := model.Event{
+		Name:     squashSpaces(page.Find("div.field--name-node-title h1").First().Text()),
+		Headline: squashSpaces(page.Find(".c-hero__headline").First().Text()),
+		EventUrl: eventUrl,
+	}
+
+	if event.Name == "" {
+		event.Name = event.Headline
+	}
+
+	if ts, ok := page.Find(".c-hero__headline-suffix").First().Attr("data-timestamp"); ok {
+		v, err := strconv.ParseInt(strings.TrimSpace(ts), 10, 64)
+		if err != nil {
+			l.Errorf("Event timestamp conversion error: %s", err)
+		} else {
+			event.Timestamp = v
+		}
+	}
+
+	event.IsDone = true
+	page.Find(".c-listing-fight").Each(func(index int, fightEl *goquery.Selection) {
+		fight := parseFight(fightEl, eventUrl)
+		if fight.FighterRedUrl == "" || fight.FighterBlueUrl == "" {
+			return
+		}
+
+		event.IsDone = event.IsDone && fight.IsDone
+		event.Fights = append(event.Fights, fight)
+	})
+
+	if len(event.Fights) == 0 {
+		event.IsDone = false
+	}
+
+	return event
+}
+
+// parseFight parses a single bout of the fight card. The result fields are set only for completed bouts.
+func parseFight(fightEl *goquery.Selection, eventUrl string) model.Fight {
+	red := fightEl.Find(".c-listing-fight__corner-name--red").First()
+	blue := fightEl.Find(".c-listing-fight__corner-name--blue").First()
+
+	fight := model.Fight{
+		FighterRedName:  squashSpaces(red.Text()),
+		FighterRedUrl:   absoluteUrl(eventUrl, red.Find("a[href]").AttrOr("href", "")),
+		FighterBlueName: squashSpaces(blue.Text()),
+		FighterBlueUrl:  absoluteUrl(eventUrl, blue.Find("a[href]").AttrOr("href", "")),
+		WeightClass:     squashSpaces(fightEl.Find(".c-listing-fight__class-text").First().Text()),
+	}
+
+	redOutcome := strings.ToLower(squashSpaces(fightEl.Find(".c-listing-fight__corner--red .c-listing-fight__outcome-wrapper").First().Text()))
+	blueOutcome := strings.ToLower(squashSpaces(fightEl.Find(".c-listing-fight__corner--blue .c-listing-fight__outcome-wrapper").First().Text()))
+
+	switch {
+	case redOutcome == "win":
+		fight.Outcome = model.OutcomeRed
+		fight.WinnerUrl = fight.FighterRedUrl
+	case blueOutcome == "win":
+		fight.Outcome = model.OutcomeBlue
+		fight.WinnerUrl = fight.FighterBlueUrl
+	case redOutcome == "draw" || blueOutcome == "draw":
+		fight.Outcome = model.OutcomeDraw
+	case strings.Contains(redOutcome, "no contest") || strings.Contains(blueOutcome, "no contest"):
+		fight.Outcome = model.OutcomeNoContest
+	}
+
+	results := fightEl.Find(".c-listing-fight__result-text")
+	fight.Method = squashSpaces(results.Filter(".method").First().Text())
+	fight.Time = squashSpaces(results.Filter(".time").First().Text())
+
+	if round := squashSpaces(results.Filter(".round").First().Text()); round != "" {
+		v, err := strconv.Atoi(round)
+		if err != nil {
+			l.Errorf("Round conversion error: %s", err)
+		} else {
+			fight.Round = v
+		}
+	}
+
+	fight.IsDone = fight.Outcome != ""
+
+	return fight
+}
+
+// absoluteUrl resolves the athlete link relative to the event page.
+func absoluteUrl(base, href string) string {
+	if href == "" {
+		return ""
+	}
+
+	baseUrl, err := url.Parse(base)
+	if err != nil {
+		return href
+	}
+
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+
+	return baseUrl.ResolveReference(ref).String()
+}
+
+var spacesRe = regexp.MustCompile(`\s+`)
+
+// squashSpaces trims the string and replaces every sequence of whitespace with a single space.
+func squashSpaces(s string) string {
+	return strings.TrimSpace(spacesRe.ReplaceAllString(s, " "))
+}
+
+// saveEventsToJSON writes the provided EventsCollection to the events collection file at path.
+// If toAdd is true, the events are merged into the existing collection instead of replacing it.
+func saveEventsToJSON(path string, c model.EventsCollection, toAdd bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if toAdd {
+		return scraperutil.AddToExistedEventsCollection(path, c)
+	}
+
+	return scraperutil.CreateNewEventsCollection(path, c)
+}
@@ -0,0 +1,155 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"fightbettr.com/pkg/media"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/spf13/viper"
+)
+
+// maxImageSize limits the size of a downloaded fighter image.
+const maxImageSize = 20 << 20
+
+// errNoMediaDir is returned when the images are mirrored without media.dir.
+var errNoMediaDir = errors.New("media.dir is not set: it must be the media directory the gateway serves the images from")
+
+// mirrorResult counts the fighter images of a mirroring run.
+type mirrorResult struct {
+	mirrored  atomic.Int64
+	unchanged atomic.Int64
+	failed    atomic.Int64
+}
+
+// mirrorImages downloads the image of every fighter into the media store in media.dir and links it to the
+// fighter url, so the images are served by the gateway after the signed ufc.com urls expire. Images whose
+// url without the query string is the same as at the previous run are not downloaded again.
+// At most parallelism images are downloaded at once, through the proxy pool when it is used.
+// The gateway reads the images from its own media.dir, so both must point to the same shared directory.
+// It returns an error if media.dir is not set or any image is not mirrored.
+func mirrorImages(ctx context.Context, fighters []model.Fighter) error {
+	dir := viper.GetString("media.dir")
+	if dir == "" {
+		return errNoMediaDir
+	}
+
+	store := media.New(media.NewDirBucket(dir))
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	if proxies != nil {
+		client.Transport = proxies.Transport()
+	}
+
+	parallelism := viper.GetInt("parallelism")
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	var res mirrorResult
+	var wg sync.WaitGroup
+	queue := make(chan model.Fighter)
+
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for f := range queue {
+				mirrorImage(ctx, store, client, f, &res)
+			}
+		}()
+	}
+
+	for _, f := range fighters {
+		if f.ImageUrl != "" && f.FighterUrl != "" {
+			queue <- f
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	fmt.Fprintf(out, "Images mirrored: %d, unchanged: %d, failed: %d\n",
+		res.mirrored.Load(), res.unchanged.Load(), res.failed.Load())
+	l.Infow("images", "type", "result",
+		"mirrored", res.mirrored.Load(), "unchanged", res.unchanged.Load(), "failed", res.failed.Load())
+
+	if failed := res.failed.Load(); failed > 0 {
+		return fmt.Errorf("%d fighter images not mirrored", failed)
+	}
+
+	return nil
+}
+
+// mirrorImage downloads the image of the fighter into the store unless it is unchanged, and links it to the fighter.
+func mirrorImage(ctx context.Context, store *media.Store, client *http.Client, f model.Fighter, res *mirrorResult) {
+	source := imageSource(f.ImageUrl)
+
+	if ref, err := store.Lookup(ctx, f.FighterUrl); err == nil && ref.Source == source {
+		res.unchanged.Add(1)
+		return
+	}
+
+	data, err := downloadImage(ctx, client, f.ImageUrl)
+	if err != nil {
+		res.failed.Add(1)
+		l.Errorf("Image download error [%s]: %s", f.ImageUrl, err)
+		return
+	}
+
+	hash, err := store.Put(ctx, data)
+	if err != nil {
+		res.failed.Add(1)
+		l.Errorf("Image store error [%s]: %s", f.ImageUrl, err)
+		return
+	}
+
+	if err := store.Link(ctx, f.FighterUrl, media.Ref{Hash: hash, Source: source}); err != nil {
+		res.failed.Add(1)
+		l.Errorf("Image link error [%s]: %s", f.FighterUrl, err)
+		return
+	}
+
+	res.mirrored.Add(1)
+}
+
+// downloadImage returns the body of the image at the url.
+func downloadImage(ctx context.Context, client *http.Client, imageUrl string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Mozilla/5.0")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxImageSize))
+}
+
+// imageSource returns the image url without the query string, which holds the expiring signature.
+func imageSource(imageUrl string) string {
+	u, err := url.Parse(imageUrl)
+	if err != nil {
+		return imageUrl
+	}
+
+	u.RawQuery = ""
+	u.Fragment = ""
+
+	return u.String()
+}
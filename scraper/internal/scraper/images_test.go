@@ -0,0 +1,82 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"fightbettr.com/pkg/media"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorImages(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 300, 600))))
+
+	var downloads atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+
+		if r.URL.Path == "/missing.png" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	viper.Set("media.dir", dir)
+	defer viper.Set("media.dir", nil)
+
+	fighters := []model.Fighter{
+		{FighterUrl: "https://www.ufc.com/athlete/jon-jones", ImageUrl: srv.URL + "/jones.png?itok=a"},
+		// The same picture is stored once.
+		{FighterUrl: "https://www.ufc.com/athlete/jones-twin", ImageUrl: srv.URL + "/twin.png?itok=a"},
+		{FighterUrl: "https://www.ufc.com/athlete/no-image"},
+		{FighterUrl: "https://www.ufc.com/athlete/broken", ImageUrl: srv.URL + "/missing.png"},
+	}
+
+	ctx := context.Background()
+	assert.EqualError(t, mirrorImages(ctx, fighters), "1 fighter images not mirrored")
+	assert.Equal(t, int32(3), downloads.Load())
+
+	store := media.New(media.NewDirBucket(dir))
+
+	ref, err := store.Lookup(ctx, "https://www.ufc.com/athlete/jon-jones")
+	require.NoError(t, err)
+	assert.Equal(t, media.Ref{Hash: media.Hash(buf.Bytes()), Source: srv.URL + "/jones.png"}, ref)
+
+	twin, err := store.Lookup(ctx, "https://www.ufc.com/athlete/jones-twin")
+	require.NoError(t, err)
+	assert.Equal(t, ref.Hash, twin.Hash)
+
+	_, err = store.Lookup(ctx, "https://www.ufc.com/athlete/broken")
+	assert.True(t, media.IsNotFound(err))
+
+	img, err := store.FighterImage(ctx, "https://www.ufc.com/athlete/jon-jones", media.SizeSmall)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", img.ContentType)
+
+	// A re-signed url of the same image is not downloaded again.
+	fighters[0].ImageUrl = srv.URL + "/jones.png?itok=b"
+	assert.NoError(t, mirrorImages(ctx, fighters[:1]))
+	assert.Equal(t, int32(3), downloads.Load())
+}
+
+func TestMirrorImagesNoMediaDir(t *testing.T) {
+	viper.Set("media.dir", "")
+	defer viper.Set("media.dir", nil)
+
+	err := mirrorImages(context.Background(), []model.Fighter{{FighterUrl: "https://www.ufc.com/athlete/jon-jones", ImageUrl: "https://www.ufc.com/jones.png"}})
+	assert.ErrorIs(t, err, errNoMediaDir)
+}
@@ -0,0 +1,53 @@
+package scraper
+
+import (
+	"fmt"
+	"time"
+
+	"fightbettr.com/scraper/internal/proxy"
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+)
+
+// proxies is the proxy pool of the run. It is nil when the run does not use proxies.
+var proxies *proxy.Pool
+
+// openProxyPool creates the proxy pool of the run from the Proxys list. Bare host:port entries use
+// the Login and Password options, and failing proxies are quarantined as set in the proxy_pool options.
+func openProxyPool() error {
+	var err error
+	proxies, err = proxy.New(viper.GetStringSlice("Proxys"), viper.GetString("Login"), viper.GetString("Password"), proxy.Options{
+		MaxFailures: viper.GetInt("proxy_pool.max_failures"),
+		Cooldown:    viper.GetDuration("proxy_pool.cooldown"),
+	})
+
+	return err
+}
+
+// useProxies sends the requests of the collector and its clones through the proxy pool of the run.
+func useProxies(c *colly.Collector) {
+	if proxies != nil {
+		c.WithTransport(proxies.Transport())
+	}
+}
+
+// reportProxies prints the health of every proxy of the pool at the end of the run.
+func reportProxies() {
+	if proxies == nil {
+		return
+	}
+
+	fmt.Fprintln(out, "Proxy pool health:")
+	for _, s := range proxies.Stats() {
+		state := "active"
+		if !s.QuarantinedUntil.IsZero() {
+			state = "quarantined until " + s.QuarantinedUntil.Format(time.TimeOnly)
+		}
+
+		fmt.Fprintf(out, "  %s: requests: %d, failures: %d, quarantines: %d, avg latency: %s, %s\n",
+			s.Proxy, s.Requests, s.Failures, s.Quarantines, s.AvgLatency.Round(time.Millisecond), state)
+		l.Infow(s.Proxy, "type", "proxy health",
+			"requests", s.Requests, "successes", s.Successes, "failures", s.Failures,
+			"quarantines", s.Quarantines, "avg_latency", s.AvgLatency.String(), "state", state)
+	}
+}
@@ -1,17 +1,23 @@
 package scraper
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"math/rand"
+	"io"
+	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"fightbettr.com/scraper/internal/checkpoint"
+	"fightbettr.com/scraper/internal/merge"
 	"fightbettr.com/scraper/internal/scraperutil"
-	data "fightbettr.com/scraper/pkg"
+	"fightbettr.com/scraper/internal/sink"
+	"fightbettr.com/scraper/internal/validate"
 	"fightbettr.com/scraper/pkg/logger"
 	"fightbettr.com/scraper/pkg/model"
 
@@ -24,7 +30,15 @@ import (
 var gc *colly.Collector
 var detailsCollector *colly.Collector
 var collection = model.FightersCollection{}
+var collected = make(map[string]struct{})
+var collectionMu sync.Mutex
+var store *checkpoint.Store
+var incremental bool
 var wg sync.WaitGroup
+
+// out receives the progress of a run. It is switched to the standard error
+// when the scraped data is streamed to the standard output.
+var out io.Writer = os.Stdout
 var l *zap.SugaredLogger
 
 type Config struct {
@@ -37,119 +51,393 @@ type Config struct {
 // It sets up the logger, creates collector instances, defines URL and limits for the main collector,
 // and specifies callback functions for HTML elements. It initiates the web scraping process by visiting
 // the initial URL and waits for the wait group to finish before printing "DONE" to the console and saving
-// the collected data to a JSON file using the saveToJSON function.
-func Run() {
+// the collected data to the output sinks configured in output.sinks.
+// Fighters are scraped from every source listed in sources.enabled, and if there are several sources,
+// the same athletes are merged into a single record with the provenance of its fields.
+// Every parsed athlete page is written to the checkpoint store as it is scraped. With the resume option
+// athletes from the checkpoint are not fetched again; with the incremental option they are fetched
+// conditionally and re-parsed only if the page has changed since the last run.
+// Before saving, every fighter is validated: rejected fighters are not saved, and the report of rejected
+// and suspicious fighters is written to the report file. Run returns an error when the share of rejected
+// fighters exceeds the configured threshold. After a complete scrape the active fighters absent from it are
+// released in the sinks that keep the roster of the fighters service. With the media.mirror option
+// the images of the valid fighters are downloaded into the media store in media.dir, which is required then;
+// the fighters are written even if some images fail, but Run returns an error.
+// When the context is done, the collectors stop fetching pages and Run returns the error of the context
+// without writing the fighters scraped so far.
+func Run(ctx context.Context) error {
 	useProxy := viper.GetBool("proxy")
 	toAdd := viper.GetBool("add")
 	startPage := viper.GetInt("start")
+	resume := viper.GetBool("resume")
+	incremental = viper.GetBool("incremental")
+	sinks := viper.GetStringSlice("output.sinks")
+
+	if sink.UsesStdout(sinks) {
+		out = os.Stderr
+	}
 
 	logFlag := scraperutil.GetLoggerFlag(toAdd)
 	if err := logger.Initialize(logFlag); err != nil {
-		fmt.Println("Error while initializing logger: ", err)
-		return
+		fmt.Fprintln(out, "Error while initializing logger: ", err)
+		return err
 	}
 
 	l = logger.Get()
-	gc = colly.NewCollector()
-	detailsCollector = gc.Clone()
 
-	url := "https://www.ufc.com/athletes/all"
+	if viper.GetBool("media.mirror") && viper.GetString("media.dir") == "" {
+		return errNoMediaDir
+	}
 
-	if startPage > 0 {
-		url = fmt.Sprintf("%s?page=%d", url, startPage)
+	srcs, links, err := configuredSources()
+	if err != nil {
+		return err
 	}
 
-	gc.Limit(&colly.LimitRule{
-		DomainGlob:  "*",
-		RandomDelay: 3 * time.Second,
-	})
+	output, err := sink.New(ctx, sinks)
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	if useProxy {
+		if err := openProxyPool(); err != nil {
+			return err
+		}
+		defer reportProxies()
+	}
 
-	gc.OnRequest(func(r *colly.Request) {
-		if useProxy {
-			login := viper.GetString("Login")
-			password := viper.GetString("Password")
-			proxy := getProxy()
-			proxyUrl := fmt.Sprintf("socks5h://%s:%s@%s", login, password, proxy)
+	store, err = checkpoint.Open(viper.GetString("checkpoint.path"), !resume && !incremental)
+	if err != nil {
+		return fmt.Errorf("error while opening checkpoint store: %w", err)
+	}
+	defer store.Close()
+
+	if store.Len() > 0 {
+		fmt.Fprintln(out, "Checkpointed athletes:", store.Len())
+		l.Infow(strconv.Itoa(store.Len()), "type", "checkpointed athletes")
+	}
 
-			gc.SetProxy(proxyUrl)
-			l.Infow(proxy, "type", "proxy address")
+	var sets []merge.Set
+	var failed int64
+	for _, src := range srcs {
+		if err := collectFighters(ctx, src, src.ListingUrls(startPage), resume, useProxy); err != nil {
+			return fmt.Errorf("error while request: %w", err)
 		}
 
-		r.Headers.Set("User-Agent", "Mozilla/5.0")
-	})
+		failed += runProgress.failed.Load()
+		sets = append(sets, merge.Set{Source: src.Name(), Fighters: collection.Fighters})
+	}
+
+	fmt.Fprintln(out, "DONE")
+	l.Infow("DONE", "type", "result")
+
+	fighters := sets[0].Fighters
+	if len(sets) > 1 {
+		fighters = merge.Fighters(sets, links)
+		fmt.Fprintln(out, "Merged athletes:", len(fighters))
+		l.Infow(strconv.Itoa(len(fighters)), "type", "merged athletes")
+	}
+
+	valid, report := validate.Fighters(fighters)
+	collection.Fighters = valid
+
+	saveErr := saveFighters(ctx, output, fighters, report, startPage == 0 && failed == 0)
 
-	gc.OnHTML("div[class*='flipcard__action'] a[href]", parseAthletesListing)
-	gc.OnHTML("li.pager__item a[href]", moveNextPage)
-	detailsCollector.OnHTML("div[class='hero-profile-wrap']", getData)
+	if err := store.Compact(); err != nil {
+		l.Errorf("Checkpoint compaction error: %s", err)
+	}
+
+	return saveErr
+}
+
+// saveFighters checks the validation report of the scraped fighters and, only if the share of rejected fighters
+// is within the validation.max_error_rate threshold, mirrors the images of the valid fighters in collection,
+// writes them to the output and releases the missing fighters. A broken scrape is thus never written to the sinks.
+// The error of the image mirroring is returned together with the output error.
+func saveFighters(ctx context.Context, output sink.Sink, fighters []model.Fighter, report validate.Report, complete bool) error {
+	if err := checkReport(report); err != nil {
+		return err
+	}
+
+	var mirrorErr error
+	if viper.GetBool("media.mirror") {
+		if mirrorErr = mirrorImages(ctx, collection.Fighters); mirrorErr != nil {
+			l.Errorf("Image mirror error: %s", mirrorErr)
+		}
+	}
 
-	err := gc.Visit(url)
+	err := output.Write(ctx, collection.Fighters)
+	if err == nil {
+		err = releaseMissing(ctx, output, fighters, complete)
+	}
 	if err != nil {
-		log.Fatalf("Error while request: %v", err)
+		l.Errorf("Output error: %s", err)
+	}
+
+	return errors.Join(err, mirrorErr)
+}
+
+// releaseMissing releases the active fighters absent from the scraped fighters in the sinks that keep
+// the roster of the fighters service, unless output.release_missing is turned off. Only a complete scrape,
+// started from the first listing page without any failed page, is a roster: the fighters of the pages
+// that are not scraped would be released otherwise. The rejected fighters are part of the roster too.
+func releaseMissing(ctx context.Context, output sink.Sink, fighters []model.Fighter, complete bool) error {
+	r, ok := output.(sink.Releaser)
+	if !ok || !viper.GetBool("output.release_missing") {
+		return nil
+	}
+
+	urls := make([]string, 0, len(fighters))
+	for _, f := range fighters {
+		if f.FighterUrl != "" {
+			urls = append(urls, f.FighterUrl)
+		}
+	}
+
+	if !complete || len(urls) == 0 {
+		l.Warnw("incomplete scrape, missing fighters are not released", "type", "result")
+		return nil
+	}
+
+	return r.Release(ctx, urls)
+}
+
+// configuredSources returns the source adapters listed in sources.enabled in the order of priority,
+// together with the links between the urls of the same athletes in different sources from sources.links.
+func configuredSources() ([]Source, []merge.Link, error) {
+	var srcs []Source
+	for _, name := range viper.GetStringSlice("sources.enabled") {
+		src, err := getSource(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		srcs = append(srcs, src)
+	}
+
+	if len(srcs) == 0 {
+		return nil, nil, fmt.Errorf("no sources enabled")
+	}
+
+	var links []merge.Link
+	if err := viper.UnmarshalKey("sources.links", &links); err != nil {
+		return nil, nil, fmt.Errorf("sources.links: %w", err)
+	}
+
+	return srcs, links, nil
+}
+
+// checkReport writes the validation report to the report file and returns an error
+// if the share of rejected fighters exceeds the validation.max_error_rate threshold.
+func checkReport(report validate.Report) error {
+	reportPath := viper.GetString("validation.report")
+	if err := report.Write(reportPath); err != nil {
+		l.Errorf("Validation report writing error: %s", err)
+	}
+
+	fmt.Fprintf(out, "Validated: %d, rejected: %d, suspicious: %d\n", report.Total, report.Rejected, report.Suspicious)
+	l.Infow("validation", "type", "result",
+		"total", report.Total, "rejected", report.Rejected, "suspicious", report.Suspicious, "report", reportPath)
+
+	maxErrorRate := viper.GetFloat64("validation.max_error_rate")
+	if rate := report.ErrorRate(); rate > maxErrorRate {
+		return fmt.Errorf("rejected %d of %d fighters (%.2f%%), above the threshold of %.2f%%, see %s",
+			report.Rejected, report.Total, rate*100, maxErrorRate*100, reportPath)
+	}
+
+	return nil
+}
+
+// collectFighters creates the collectors for the source, visits the athletes listing urls and every athlete page
+// found there, and waits until all of them are parsed into the FightersCollection. The pages are fetched
+// concurrently, so the collection is sorted by the fighter url to keep the output stable between runs.
+// If the context is done, the remaining pages are not fetched and the error of the context is returned.
+func collectFighters(ctx context.Context, src Source, urls []string, resume, useProxy bool) error {
+	runCtx = ctx
+	collection = model.FightersCollection{}
+	collected = make(map[string]struct{})
+
+	var err error
+	if gc, err = newCollector(src.LimitRule()); err != nil {
+		return err
 	}
 
+	detailsCollector = cloneCollector(gc)
+
+	runProgress = &progress{}
+	stop := runProgress.report(viper.GetDuration("progress.interval"))
+	defer stop()
+
+	if useProxy {
+		useProxies(gc)
+	}
+
+	gc.OnRequest(setUserAgent)
+
+	gc.OnError(handleError)
+	gc.OnHTML(src.AthleteSelector(), parseAthletesListing(resume))
+	if sel := src.NextPageSelector(); sel != "" {
+		gc.OnHTML(sel, moveNextPage)
+	}
+	detailsCollector.OnRequest(setConditionalHeaders)
+	detailsCollector.OnResponse(checkUnchanged)
+	detailsCollector.OnError(handleError)
+	detailsCollector.OnHTML(src.ProfileSelector(), getData(src))
+
+	for _, url := range urls {
+		if err := gc.Visit(url); err != nil {
+			return err
+		}
+	}
+
+	gc.Wait()
+	detailsCollector.Wait()
 	wg.Wait()
 
-	fmt.Println("DONE")
-	l.Infow("DONE", "type", "result")
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	sort.Slice(collection.Fighters, func(i, j int) bool {
+		return collection.Fighters[i].FighterUrl < collection.Fighters[j].FighterUrl
+	})
 
-	saveToJSON(collection, toAdd)
+	return nil
 }
 
-// parseAthletesListing is a callback function used with colly that extracts athlete URLs from a given colly.HTMLElement 'e'.
-// It increments the wait group and defers its decrement for synchronization. The function extracts the athlete's URL,
-// converts it to an absolute URL, prints it to the console, and then uses a detailsCollector to visit the athlete's detailed page.
-// This function is typically used during web scraping to collect athlete URLs for subsequent detailed data extraction.
-func parseAthletesListing(e *colly.HTMLElement) {
-	wg.Add(1)
-	defer wg.Done()
+// addFighter appends the fighter to the FightersCollection unless a fighter with the same url is already there.
+func addFighter(f model.Fighter) {
+	collectionMu.Lock()
+	defer collectionMu.Unlock()
 
-	athleteURL := e.Attr("href")
-	athleteURL = e.Request.AbsoluteURL(athleteURL)
+	if _, ok := collected[f.FighterUrl]; ok {
+		return
+	}
 
-	fmt.Println("Athlete link:", athleteURL)
-	l.Infow(athleteURL, "type", "athlete link")
+	collected[f.FighterUrl] = struct{}{}
+	collection.Fighters = append(collection.Fighters, f)
+}
 
-	detailsCollector.Visit(athleteURL)
+// setConditionalHeaders is a callback function used with colly that makes the athlete page request
+// conditional in the incremental mode, using the ETag and Last-Modified values of the checkpointed response.
+func setConditionalHeaders(r *colly.Request) {
+	if !incremental {
+		return
+	}
 
-	// Multithread scrapping
-	// go func() {
-	// 	defer wg.Done()
-	// 	athleteURL := e.Attr("href")
-	// 	athleteURL = e.Request.AbsoluteURL(athleteURL)
+	e, ok := store.Get(r.URL.String())
+	if !ok {
+		return
+	}
 
-	// 	fmt.Println("Athlete link:", athleteURL)
+	if e.ETag != "" {
+		r.Headers.Set("If-None-Match", e.ETag)
+	}
 
-	// 	detailsCollector.Visit(athleteURL)
-	// }()
+	if e.LastModified != "" {
+		r.Headers.Set("If-Modified-Since", e.LastModified)
+	}
 }
 
-// getData is a callback function used with colly that extracts fighter data from a given colly.HTMLElement 'e'.
-// It initializes a Fighter model, sets basic information such as name, nickname, URL, and image, and then calls
-// SetDivision and SetStatistic functions to update division and general statistics. Finally, it calls parseData
-// to extract additional details about the fighter and appends the resulting Fighter instance to a FightersCollection.
-// This function is typically used during web scraping to gather comprehensive information about a fighter.
-func getData(e *colly.HTMLElement) {
-	wg.Add(1)
-	defer wg.Done()
+// checkUnchanged is a callback function used with colly that compares the athlete page with the checkpointed one.
+// In the incremental mode a page with the same content hash is not parsed again and the checkpointed fighter is used.
+// The validators and the hash of the response are kept in the request context for getData.
+func checkUnchanged(r *colly.Response) {
+	hash := checkpoint.Hash(r.Body)
 
-	fighterEl := e.DOM.Parent()
+	r.Ctx.Put("etag", r.Headers.Get("ETag"))
+	r.Ctx.Put("last_modified", r.Headers.Get("Last-Modified"))
+	r.Ctx.Put("hash", hash)
 
-	profileEl := fighterEl.Find("div.hero-profile-wrap")
-	statString := profileEl.Find("p.hero-profile__division-body").Text()
+	if !incremental {
+		return
+	}
 
-	fighter := model.Fighter{
-		Name:       strings.TrimSpace(profileEl.Find("h1.hero-profile__name").Text()),
-		NickName:   strings.TrimSpace(profileEl.Find("p.hero-profile__nickname").Text()),
-		FighterUrl: e.Request.URL.String(),
-		ImageUrl:   profileEl.Find(".hero-profile__image-wrap img").AttrOr("src", ""),
+	e, ok := store.Get(r.Request.URL.String())
+	if !ok || e.Hash != hash {
+		return
 	}
 
-	data.SetDivision(&fighter, profileEl.Find("p.hero-profile__division-title").Text())
-	data.SetStatistic(&fighter, statString)
+	r.Ctx.Put("unchanged", "true")
+	reuseCheckpoint(e, r.Headers.Get("ETag"), r.Headers.Get("Last-Modified"))
+}
 
-	parseData(&fighter, fighterEl)
+// reuseCheckpoint adds the checkpointed fighter to the collection and refreshes the checkpoint entry.
+func reuseCheckpoint(e checkpoint.Entry, etag, lastModified string) {
+	l.Infow(e.Url, "type", "athlete unchanged")
 
-	collection.Fighters = append(collection.Fighters, fighter)
+	if etag != "" {
+		e.ETag = etag
+	}
+
+	if lastModified != "" {
+		e.LastModified = lastModified
+	}
+
+	e.FetchedAt = time.Now().Unix()
+	if err := store.Put(e); err != nil {
+		l.Errorf("Checkpoint write error: %s", err)
+	}
+
+	addFighter(e.Fighter)
+}
+
+// parseAthletesListing returns a callback function used with colly that extracts athlete URLs from a given colly.HTMLElement 'e'.
+// It increments the wait group and defers its decrement for synchronization. The function extracts the athlete's URL,
+// converts it to an absolute URL, prints it to the console, and then queues the athlete's detailed page in the detailsCollector.
+// When resuming, athletes that are already in the checkpoint store are taken from it instead of being visited again.
+// This function is typically used during web scraping to collect athlete URLs for subsequent detailed data extraction.
+func parseAthletesListing(resume bool) colly.HTMLCallback {
+	return func(e *colly.HTMLElement) {
+		wg.Add(1)
+		defer wg.Done()
+
+		athleteURL := e.Attr("href")
+		athleteURL = e.Request.AbsoluteURL(athleteURL)
+
+		if resume {
+			if entry, ok := store.Get(athleteURL); ok {
+				addFighter(entry.Fighter)
+				return
+			}
+		}
+
+		fmt.Fprintln(out, "Athlete link:", athleteURL)
+		l.Infow(athleteURL, "type", "athlete link")
+
+		visit(detailsCollector.Visit, athleteURL)
+	}
+}
+
+// getData returns a callback function used with colly that extracts fighter data from the athlete page
+// with the ParseFighter method of the source. The fighter is written to the checkpoint store and appended
+// to the FightersCollection. Pages that are unchanged since the last run are not parsed again.
+func getData(src Source) colly.HTMLCallback {
+	return func(e *colly.HTMLElement) {
+		wg.Add(1)
+		defer wg.Done()
+
+		if e.Request.Ctx.Get("unchanged") != "" {
+			return
+		}
+
+		fighter := src.ParseFighter(e)
+
+		entry := checkpoint.Entry{
+			Url:          fighter.FighterUrl,
+			ETag:         e.Request.Ctx.Get("etag"),
+			LastModified: e.Request.Ctx.Get("last_modified"),
+			Hash:         e.Request.Ctx.Get("hash"),
+			FetchedAt:    time.Now().Unix(),
+			Fighter:      fighter,
+		}
+		if err := store.Put(entry); err != nil {
+			l.Errorf("Checkpoint write error: %s", err)
+		}
+
+		addFighter(fighter)
+	}
 }
 
 // parseData a unifying function for parsing data from different blocks of information
@@ -394,32 +682,13 @@ func moveNextPage(e *colly.HTMLElement) {
 	nextUrl := e.Attr("href")
 	nextUrl = e.Request.AbsoluteURL(nextUrl)
 
-	fmt.Println("Next page:", nextUrl)
+	fmt.Fprintln(out, "Next page:", nextUrl)
 	l.Infow(nextUrl, "type", "next page")
 
-	e.Request.Visit(nextUrl)
-}
-
-// saveToJSON marshals the provided FightersCollection to JSON format
-// and writes it to the file. If there is an error during
-// marshalling, file opening, or file writing, it logs the error. This function
-// is used to store fighter data in JSON format.
-func saveToJSON(c model.FightersCollection, toAdd bool) {
-	if toAdd {
-		scraperutil.AddToExistedCollection(c)
-	} else {
-		scraperutil.CreateNewCollection(c)
-	}
+	visit(e.Request.Visit, nextUrl)
 }
 
-func getProxy() string {
-	proxys := viper.GetStringSlice("Proxys")
-	if len(proxys) == 0 {
-		return ""
-	}
-
-	rand.Seed(time.Now().UnixNano())
-	idx := rand.Intn(len(proxys))
-
-	return proxys[idx]
+// setUserAgent is a callback function used with colly that sets the User-Agent header of the request.
+func setUserAgent(r *colly.Request) {
+	r.Headers.Set("User-Agent", "Mozilla/5.0")
 }
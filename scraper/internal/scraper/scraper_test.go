@@ -0,0 +1,333 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"fightbettr.com/scraper/internal/checkpoint"
+	"fightbettr.com/scraper/internal/fixture"
+	"fightbettr.com/scraper/internal/merge"
+	"fightbettr.com/scraper/internal/validate"
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+const fixturesDir = "testdata/fixtures"
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+
+	l = zap.NewNop().Sugar()
+	logger.Set(l)
+	viper.Set("delay", time.Duration(0))
+	viper.Set("sources.ufcstats.delay", time.Duration(0))
+	viper.Set("parallelism", 4)
+	viper.Set("retry.max", 3)
+	viper.Set("retry.base_delay", time.Duration(0))
+	// The fixtures have no robots.txt, and its requests would be recorded by the fixture servers.
+	viper.Set("ignore_robots", true)
+
+	now = func() time.Time {
+		return time.Date(2024, time.December, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	os.Exit(m.Run())
+}
+
+// openTestStore opens an empty checkpoint store in a temporary directory.
+func openTestStore(t *testing.T) {
+	t.Helper()
+
+	var err error
+	store, err = checkpoint.Open(filepath.Join(t.TempDir(), "checkpoint.jsonl"), true)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		store.Close()
+		incremental = false
+	})
+}
+
+// assertGolden compares the JSON representation of v with the golden file.
+// The urls of the fixture servers are replaced with their origins, so the golden files are stable.
+// With the -update flag the golden file is rewritten instead.
+func assertGolden(t *testing.T, name string, v any, servers ...*fixture.Server) {
+	t.Helper()
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	require.NoError(t, err)
+
+	actual := string(data) + "\n"
+	for _, srv := range servers {
+		actual = strings.ReplaceAll(actual, srv.URL, srv.Origin)
+	}
+	path := filepath.Join("testdata", "golden", name)
+
+	if *update {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), actual)
+}
+
+func TestCollectFighters(t *testing.T) {
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	openTestStore(t)
+
+	err := collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false)
+	require.NoError(t, err)
+
+	assert.Len(t, collection.Fighters, 3)
+	assert.Equal(t, 3, store.Len())
+	assertGolden(t, "fighters.json", collection, srv)
+
+	// The record of New Prospect is missing from the fixture and falls back to 0-0-0.
+	_, report := validate.Fighters(collection.Fighters)
+	assert.Equal(t, 1, report.Rejected, "%+v", report.Records)
+	for _, r := range report.Records {
+		if r.Rejected {
+			assert.Equal(t, srv.URL+"/athlete/new-prospect", r.FighterUrl)
+			assert.Contains(t, r.Issues, validate.Issue{Field: "record", Severity: validate.SeverityError, Message: "record is empty or not parsed"})
+		}
+	}
+}
+
+func TestCollectFightersIncremental(t *testing.T) {
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	openTestStore(t)
+
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false))
+	first := collection
+
+	incremental = true
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false))
+
+	assert.Equal(t, first, collection)
+}
+
+func TestCollectFightersResume(t *testing.T) {
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	openTestStore(t)
+
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, false, false))
+	first := collection
+
+	srv.Reset()
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{srv.URL + "/athletes/all"}, true, false))
+
+	assert.Equal(t, []string{"/athletes/all", "/athletes/all?page=1"}, srv.Requests())
+	assert.ElementsMatch(t, first.Fighters, collection.Fighters)
+}
+
+// statsOrigin is the site the ufcstats fixtures are recorded from.
+const statsOrigin = "http://ufcstats.com"
+
+// statsListingUrls returns the urls of the ufcstats listing pages that have fixtures.
+func statsListingUrls(srv *fixture.Server) []string {
+	var urls []string
+	for _, c := range []string{"j", "n", "p"} {
+		urls = append(urls, srv.URL+"/statistics/fighters?char="+c+"&page=all")
+	}
+
+	return urls
+}
+
+func TestCollectFightersUfcStats(t *testing.T) {
+	srv := fixture.NewServerFor(fixturesDir, statsOrigin)
+	defer srv.Close()
+
+	openTestStore(t)
+
+	err := collectFighters(context.Background(), ufcStatsSource{}, statsListingUrls(srv), false, false)
+	require.NoError(t, err)
+
+	assert.Len(t, collection.Fighters, 4)
+	assertGolden(t, "ufcstats_fighters.json", collection, srv)
+}
+
+func TestMergeSources(t *testing.T) {
+	ufcSrv := fixture.NewServer(fixturesDir)
+	defer ufcSrv.Close()
+
+	statsSrv := fixture.NewServerFor(fixturesDir, statsOrigin)
+	defer statsSrv.Close()
+
+	openTestStore(t)
+
+	require.NoError(t, collectFighters(context.Background(), ufcSource{}, []string{ufcSrv.URL + "/athletes/all"}, false, false))
+	ufcSet := merge.Set{Source: ufcSourceName, Fighters: collection.Fighters}
+
+	require.NoError(t, collectFighters(context.Background(), ufcStatsSource{}, statsListingUrls(statsSrv), false, false))
+	statsSet := merge.Set{Source: ufcStatsSourceName, Fighters: collection.Fighters}
+
+	links := []merge.Link{
+		{From: statsSrv.URL + "/fighter-details/b1d5e3a2c4f60718", To: ufcSrv.URL + "/athlete/new-prospect"},
+	}
+
+	merged := merge.Fighters([]merge.Set{ufcSet, statsSet}, links)
+
+	// Jon Jones and Amanda Nunes are matched by the name and the debut, New Prospect by the link.
+	require.Len(t, merged, 4)
+	assertGolden(t, "merged_fighters.json", merged, ufcSrv, statsSrv)
+}
+
+func TestCollectEvents(t *testing.T) {
+	srv := fixture.NewServer(fixturesDir)
+	defer srv.Close()
+
+	err := collectEvents(context.Background(), srv.URL+"/events", 1, false)
+	require.NoError(t, err)
+
+	assert.Len(t, eventsCollection.Events, 2)
+	assertGolden(t, "events.json", eventsCollection, srv)
+}
+
+// releasingSink records the written fighters and the urls of the Release calls.
+type releasingSink struct {
+	written  [][]model.Fighter
+	released [][]string
+}
+
+func (s *releasingSink) Write(ctx context.Context, fighters []model.Fighter) error {
+	s.written = append(s.written, fighters)
+	return nil
+}
+
+func (s *releasingSink) Release(ctx context.Context, urls []string) error {
+	s.released = append(s.released, urls)
+	return nil
+}
+
+func (s *releasingSink) Close() error { return nil }
+
+func TestReleaseMissing(t *testing.T) {
+	defer viper.Set("output.release_missing", false)
+
+	fighters := []model.Fighter{{FighterUrl: "url-1"}, {Name: "No Url"}, {FighterUrl: "url-2"}}
+
+	tests := []struct {
+		name     string
+		fighters []model.Fighter
+		complete bool
+		enabled  bool
+		want     [][]string
+	}{
+		{name: "Complete scrape", fighters: fighters, complete: true, enabled: true, want: [][]string{{"url-1", "url-2"}}},
+		{name: "Incomplete scrape", fighters: fighters, enabled: true},
+		{name: "No fighter urls", fighters: fighters[1:2], complete: true, enabled: true},
+		{name: "Disabled", fighters: fighters, complete: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("output.release_missing", tt.enabled)
+
+			s := &releasingSink{}
+			require.NoError(t, releaseMissing(context.Background(), s, tt.fighters, tt.complete))
+			assert.Equal(t, tt.want, s.released)
+		})
+	}
+}
+
+func TestSaveFighters(t *testing.T) {
+	viper.Set("validation.report", filepath.Join(t.TempDir(), "report.json"))
+	viper.Set("validation.max_error_rate", 0.25)
+	viper.Set("output.release_missing", true)
+	defer func() {
+		viper.Set("validation.report", "")
+		viper.Set("validation.max_error_rate", 0.0)
+		viper.Set("output.release_missing", false)
+	}()
+
+	fighters := []model.Fighter{{FighterUrl: "url-1"}, {FighterUrl: "url-2"}, {FighterUrl: "url-3"}}
+
+	tests := []struct {
+		name     string
+		report   validate.Report
+		err      bool
+		written  [][]model.Fighter
+		released [][]string
+	}{
+		{
+			name:     "Within the threshold",
+			report:   validate.Report{Total: 4, Valid: 3, Rejected: 1},
+			written:  [][]model.Fighter{fighters},
+			released: [][]string{{"url-1", "url-2", "url-3"}},
+		},
+		{
+			name:   "Above the threshold",
+			report: validate.Report{Total: 4, Valid: 2, Rejected: 2},
+			err:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection = model.FightersCollection{Fighters: fighters}
+			s := &releasingSink{}
+
+			err := saveFighters(context.Background(), s, fighters, tt.report, true)
+			if tt.err {
+				assert.ErrorContains(t, err, "above the threshold")
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.written, s.written)
+			assert.Equal(t, tt.released, s.released)
+			assert.FileExists(t, viper.GetString("validation.report"))
+		})
+	}
+}
+
+func TestSaveEventsToJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection", "events.json")
+
+	first := model.EventsCollection{Events: []model.Event{{Name: "UFC 300", EventUrl: "url-300"}}}
+	second := model.EventsCollection{Events: []model.Event{
+		{Name: "UFC 300", EventUrl: "url-300", IsDone: true},
+		{Name: "UFC 310", EventUrl: "url-310"},
+	}}
+
+	// The collection file is created in add mode when it does not exist yet.
+	require.NoError(t, saveEventsToJSON(path, first, true))
+	require.NoError(t, saveEventsToJSON(path, second, true))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var saved model.EventsCollection
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, second, saved)
+
+	require.NoError(t, saveEventsToJSON(path, first, false))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, first, saved)
+
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0644))
+	assert.Error(t, saveEventsToJSON(path, first, true))
+}
@@ -0,0 +1,52 @@
+package scraper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/gocolly/colly"
+)
+
+// Source represents a website the fighters are scraped from. The adapter knows where the athletes listing
+// is and how it is paginated, how to parse the athlete page and how often the site may be requested.
+type Source interface {
+	// Name returns the name of the source. It is used in the provenance of merged records.
+	Name() string
+	// ListingUrls returns the urls of the athletes listing the scrape starts from.
+	ListingUrls(startPage int) []string
+	// AthleteSelector returns the selector of the athlete page links on the listing page.
+	AthleteSelector() string
+	// NextPageSelector returns the selector of the next listing page link,
+	// or an empty string if all listing pages are returned by ListingUrls.
+	NextPageSelector() string
+	// ProfileSelector returns the selector of the athlete page element the fighter is parsed from.
+	ProfileSelector() string
+	// ParseFighter parses the fighter from the element matched by ProfileSelector.
+	ParseFighter(e *colly.HTMLElement) model.Fighter
+	// LimitRule returns the rate limit of the requests to the source.
+	LimitRule() *colly.LimitRule
+}
+
+// sources lists the available source adapters by their name.
+var sources = map[string]Source{
+	ufcSourceName:      ufcSource{},
+	ufcStatsSourceName: ufcStatsSource{},
+}
+
+// getSource returns the source adapter with the given name.
+func getSource(name string) (Source, error) {
+	src, ok := sources[strings.TrimSpace(name)]
+	if !ok {
+		names := make([]string, 0, len(sources))
+		for n := range sources {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("unknown source '%s', available sources: %s", name, strings.Join(names, ", "))
+	}
+
+	return src, nil
+}
@@ -0,0 +1,80 @@
+package scraper
+
+import (
+	"fmt"
+	"strings"
+
+	data "fightbettr.com/scraper/pkg"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+)
+
+const ufcSourceName = "ufc"
+
+// ufcSource is the adapter of the athletes pages of ufc.com.
+// The listing is paginated with the "Load more" link, and the site is requested with a random delay.
+type ufcSource struct{}
+
+// Name returns the name of the source.
+func (ufcSource) Name() string {
+	return ufcSourceName
+}
+
+// ListingUrls returns the athletes listing url starting from the given page.
+func (ufcSource) ListingUrls(startPage int) []string {
+	url := viper.GetString("base_url") + "/athletes/all"
+
+	if startPage > 0 {
+		url = fmt.Sprintf("%s?page=%d", url, startPage)
+	}
+
+	return []string{url}
+}
+
+// AthleteSelector returns the selector of the athlete profile links of the flipcards.
+func (ufcSource) AthleteSelector() string {
+	return "div[class*='flipcard__action'] a[href]"
+}
+
+// NextPageSelector returns the selector of the "Load more" link.
+func (ufcSource) NextPageSelector() string {
+	return "li.pager__item a[href]"
+}
+
+// ProfileSelector returns the selector of the hero profile block.
+func (ufcSource) ProfileSelector() string {
+	return "div[class='hero-profile-wrap']"
+}
+
+// ParseFighter sets basic information such as name, nickname, URL, and image, and then calls
+// SetDivision and SetStatistic functions to update division and general statistics. Finally, it calls
+// parseData to extract additional details about the fighter from the rest of the athlete page.
+func (ufcSource) ParseFighter(e *colly.HTMLElement) model.Fighter {
+	fighterEl := e.DOM.Parent()
+
+	profileEl := fighterEl.Find("div.hero-profile-wrap")
+	statString := profileEl.Find("p.hero-profile__division-body").Text()
+
+	fighter := model.Fighter{
+		Name:       strings.TrimSpace(profileEl.Find("h1.hero-profile__name").Text()),
+		NickName:   strings.TrimSpace(profileEl.Find("p.hero-profile__nickname").Text()),
+		FighterUrl: e.Request.URL.String(),
+		ImageUrl:   profileEl.Find(".hero-profile__image-wrap img").AttrOr("src", ""),
+	}
+
+	data.SetDivision(&fighter, profileEl.Find("p.hero-profile__division-title").Text())
+	data.SetStatistic(&fighter, statString)
+
+	parseData(&fighter, fighterEl)
+
+	return fighter
+}
+
+// LimitRule returns the random delay configured in the delay option.
+func (ufcSource) LimitRule() *colly.LimitRule {
+	return &colly.LimitRule{
+		DomainGlob:  "*",
+		RandomDelay: viper.GetDuration("delay"),
+	}
+}
@@ -0,0 +1,241 @@
+package scraper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	data "fightbettr.com/scraper/pkg"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+	"github.com/spf13/viper"
+)
+
+const ufcStatsSourceName = "ufcstats"
+
+// debutLayout is the layout of the octagon debut on ufc.com. Debuts found in other sources
+// are formatted with it, so the merged records look the same regardless of the source.
+const debutLayout = "Jan. 2, 2006"
+
+var (
+	statsRecordRe = regexp.MustCompile(`\d+-\d+-\d+`)
+	statsHeightRe = regexp.MustCompile(`(\d+)'\s*(\d+)"`)
+
+	// statsDateLayouts are the layouts of the dates of the fight history.
+	statsDateLayouts = []string{"Jan. 02, 2006", "Jan 02, 2006", "January 02, 2006"}
+)
+
+// now returns the current time. It is used to calculate the age from the date of birth.
+var now = time.Now
+
+// ufcStatsSource is the adapter of the fighter pages of ufcstats.com.
+// The listing is split by the first letter of the last name and every letter is a single page.
+// The fighter page has the career statistics and the fight history, which is used
+// to count the wins by method and to find the octagon debut.
+type ufcStatsSource struct{}
+
+// Name returns the name of the source.
+func (ufcStatsSource) Name() string {
+	return ufcStatsSourceName
+}
+
+// ListingUrls returns the listing urls of every letter. The start page is the index of the first letter.
+func (ufcStatsSource) ListingUrls(startPage int) []string {
+	base := viper.GetString("sources.ufcstats.base_url") + "/statistics/fighters"
+
+	var urls []string
+	for c := 'a' + rune(startPage); c <= 'z'; c++ {
+		urls = append(urls, fmt.Sprintf("%s?char=%c&page=all", base, c))
+	}
+
+	return urls
+}
+
+// AthleteSelector returns the selector of the fighter links of the listing table.
+// Every row links the fighter three times, so only the link of the first column is used.
+func (ufcStatsSource) AthleteSelector() string {
+	return "tr.b-statistics__table-row td:first-child a[href]"
+}
+
+// NextPageSelector returns an empty string, as every letter of the listing is a single page.
+func (ufcStatsSource) NextPageSelector() string {
+	return ""
+}
+
+// ProfileSelector returns the selector of the page body, as the fighter is parsed from several sections.
+func (ufcStatsSource) ProfileSelector() string {
+	return "body"
+}
+
+// ParseFighter parses the name, the record and the physical attributes of the fighter,
+// the career statistics and the fight history.
+func (ufcStatsSource) ParseFighter(e *colly.HTMLElement) model.Fighter {
+	page := e.DOM
+
+	fighter := model.Fighter{
+		Name:       squashSpaces(page.Find("span.b-content__title-highlight").First().Text()),
+		NickName:   squashSpaces(page.Find("p.b-content__Nick-name").First().Text()),
+		FighterUrl: e.Request.URL.String(),
+	}
+
+	data.SetStatistic(&fighter, statsRecordRe.FindString(page.Find("span.b-content__title-record").First().Text()))
+
+	page.Find("li.b-list__box-list-item").Each(func(index int, item *goquery.Selection) {
+		label := squashSpaces(item.Find("i.b-list__box-item-title").Text())
+		value := strings.TrimSpace(strings.TrimPrefix(squashSpaces(item.Text()), label))
+
+		if label == "" || value == "" || value == "--" {
+			return
+		}
+
+		parseStatsField(&fighter, label, value)
+	})
+
+	parseFightHistory(&fighter, page)
+
+	return fighter
+}
+
+// LimitRule returns the random delay configured in the sources.ufcstats.delay option.
+func (ufcStatsSource) LimitRule() *colly.LimitRule {
+	return &colly.LimitRule{
+		DomainGlob:  "*",
+		RandomDelay: viper.GetDuration("sources.ufcstats.delay"),
+	}
+}
+
+// parseStatsField sets the value of a labeled field of the fighter page to the fighter.
+func parseStatsField(f *model.Fighter, label, value string) {
+	switch label {
+	case "Height:":
+		m := statsHeightRe.FindStringSubmatch(value)
+		if m == nil {
+			l.Errorf("Height conversion error: '%s'", value)
+			return
+		}
+
+		feet, _ := strconv.Atoi(m[1])
+		inches, _ := strconv.Atoi(m[2])
+		f.Height = float32(feet*12 + inches)
+	case "Weight:":
+		f.Weight = parseStatsFloat(label, strings.TrimSuffix(value, " lbs."))
+	case "Reach:":
+		f.Reach = parseStatsFloat(label, strings.TrimSuffix(value, `"`))
+	case "DOB:":
+		dob, err := time.Parse("Jan 02, 2006", value)
+		if err != nil {
+			l.Errorf("DOB conversion error: %s", err)
+			return
+		}
+
+		f.Age = int8(age(dob, now()))
+	case "SLpM:":
+		f.Stats.SigStrLanded = parseStatsFloat(label, value)
+	case "SApM:":
+		f.Stats.SigStrAbs = parseStatsFloat(label, value)
+	case "TD Avg.:":
+		f.Stats.TakedownAvg = parseStatsFloat(label, value)
+	case "Sub. Avg.:":
+		f.Stats.SubmissionAvg = parseStatsFloat(label, value)
+	case "Str. Acc.:":
+		f.Stats.StrAccuracy = parseStatsPercent(label, value)
+	case "TD Acc.:":
+		f.Stats.TkdAccuracy = parseStatsPercent(label, value)
+	case "Str. Def:":
+		f.Stats.SigStrDefense = int8(parseStatsPercent(label, value))
+	case "TD Def.:":
+		f.Stats.TakedownDefense = int8(parseStatsPercent(label, value))
+	}
+}
+
+// parseFightHistory counts the wins by method and finds the octagon debut in the fight history table.
+// Upcoming fights are skipped.
+func parseFightHistory(f *model.Fighter, page *goquery.Selection) {
+	var debut time.Time
+
+	page.Find("tr.b-fight-details__table-row").Each(func(index int, row *goquery.Selection) {
+		cols := row.Find("td")
+		if cols.Length() < 8 {
+			return
+		}
+
+		result := strings.ToLower(squashSpaces(cols.Eq(0).Find(".b-flag__text").First().Text()))
+		if result == "" || result == "next" {
+			return
+		}
+
+		date := squashSpaces(cols.Eq(6).Find("p").Eq(1).Text())
+		if d, ok := parseStatsDate(date); ok {
+			if debut.IsZero() || d.Before(debut) {
+				debut = d
+			}
+		} else if date != "" {
+			l.Errorf("Fight date conversion error: '%s'", date)
+		}
+
+		if result != "win" {
+			return
+		}
+
+		method := strings.ToUpper(squashSpaces(cols.Eq(7).Find("p").First().Text()))
+		switch {
+		case strings.HasPrefix(method, "KO/TKO"):
+			f.Stats.WinByKO++
+		case strings.HasPrefix(method, "SUB"):
+			f.Stats.WinBySub++
+		case strings.Contains(method, "DEC"):
+			f.Stats.WinByDec++
+		}
+	})
+
+	if !debut.IsZero() {
+		f.OctagonDebut = debut.Format(debutLayout)
+		f.DebutTimestamp = int(debut.Unix())
+	}
+}
+
+// parseStatsFloat converts the value of the labeled field to a float. Conversion errors are logged.
+func parseStatsFloat(label, value string) float32 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(value), 32)
+	if err != nil {
+		l.Errorf("%s conversion error: %s", strings.TrimSuffix(label, ":"), err)
+		return 0
+	}
+
+	return float32(v)
+}
+
+// parseStatsPercent converts the percentage value of the labeled field to an integer. Conversion errors are logged.
+func parseStatsPercent(label, value string) int {
+	v, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(value), "%"))
+	if err != nil {
+		l.Errorf("%s conversion error: %s", strings.TrimSuffix(label, ":"), err)
+		return 0
+	}
+
+	return v
+}
+
+// parseStatsDate parses the date of the fight history in one of the known layouts.
+func parseStatsDate(value string) (time.Time, bool) {
+	for _, layout := range statsDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// age returns the number of full years between the date of birth and t.
+func age(dob, t time.Time) int {
+	years := t.Year() - dob.Year()
+	if t.Month() < dob.Month() || (t.Month() == dob.Month() && t.Day() < dob.Day()) {
+		years--
+	}
+
+	return years
+}
@@ -2,6 +2,7 @@ package scraperutil
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"strconv"
 	"time"
@@ -10,55 +11,40 @@ import (
 	"fightbettr.com/scraper/pkg/model"
 )
 
-func CreateNewCollection(c model.FightersCollection) {
-	l := logger.Get()
-
-	file, err := os.Create("./collection/fighters.json")
+// CreateNewCollection writes the fighters collection to the file at path, replacing the previous one.
+func CreateNewCollection(path string, c model.FightersCollection) error {
+	file, err := os.Create(path)
 	if err != nil {
-		l.Error("File creation error:", err)
-		return
+		return err
 	}
 	defer file.Close()
 
 	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(c); err != nil {
-		l.Error("Error encoding JSON:", err)
-		return
-	}
-}
 
-func AddToExistedCollection(c model.FightersCollection) {
-	l := logger.Get()
+	return encoder.Encode(c)
+}
 
-	file, err := os.Open("./collection/fighters.json")
-	if err != nil {
-		l.Error("File opening error:", err)
-		return
+// AddToExistedCollection merges the fighters collection into the collection file at path.
+// If the file does not exist yet, it is created.
+func AddToExistedCollection(path string, c model.FightersCollection) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return CreateNewCollection(path, c)
+	} else if err != nil {
+		return err
 	}
-	defer file.Close()
 
 	var existingFighters model.FightersCollection
 	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&existingFighters); err != nil {
-		l.Error("Error decoding JSON:", err)
-		return
+	err = decoder.Decode(&existingFighters)
+	file.Close()
+	if err != nil {
+		return err
 	}
 
 	existingFighters.Fighters = append(existingFighters.Fighters, c.Fighters...)
-	collection := getUniqueCollection(existingFighters)
-
-	file, err = os.Create("./collection/fighters.json")
-	if err != nil {
-		l.Error("File creation error:", err)
-		return
-	}
-	defer file.Close()
 
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(collection); err != nil {
-		l.Error("Error encoding JSON:", err)
-		return
-	}
+	return CreateNewCollection(path, getUniqueCollection(existingFighters))
 }
 
 func getUniqueCollection(c model.FightersCollection) model.FightersCollection {
@@ -102,3 +88,60 @@ func GetDebutTimestamp(octagonDebut string) int {
 
 	return int(parsedTime.Unix())
 }
+
+// CreateNewEventsCollection writes the events collection to the file at path, replacing the previous one.
+func CreateNewEventsCollection(path string, c model.EventsCollection) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	encoder := json.NewEncoder(file)
+
+	return encoder.Encode(c)
+}
+
+// AddToExistedEventsCollection merges the events collection into the events collection file at path.
+// Events are matched by their url, and the newly scraped event replaces the stored one,
+// so the results of completed bouts are picked up on the next run. If the file does not exist yet, it is created.
+func AddToExistedEventsCollection(path string, c model.EventsCollection) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return CreateNewEventsCollection(path, c)
+	} else if err != nil {
+		return err
+	}
+
+	var existingEvents model.EventsCollection
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&existingEvents)
+	file.Close()
+	if err != nil {
+		return err
+	}
+
+	existingEvents.Events = append(existingEvents.Events, c.Events...)
+
+	return CreateNewEventsCollection(path, getUniqueEvents(existingEvents))
+}
+
+// getUniqueEvents removes duplicated events keeping the last occurrence of every event url.
+func getUniqueEvents(c model.EventsCollection) model.EventsCollection {
+	positions := make(map[string]int)
+	uniqueEvents := make([]model.Event, 0, len(c.Events))
+
+	for _, event := range c.Events {
+		if i, exists := positions[event.EventUrl]; exists {
+			uniqueEvents[i] = event
+			continue
+		}
+
+		positions[event.EventUrl] = len(uniqueEvents)
+		uniqueEvents = append(uniqueEvents, event)
+	}
+
+	return model.EventsCollection{
+		Events: uniqueEvents,
+	}
+}
@@ -0,0 +1,72 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"fightbettr.com/scraper/internal/scraperutil"
+	"fightbettr.com/scraper/pkg/model"
+)
+
+// JSONFile writes fighters to a collection file in the {"Fighters": [...]} format
+// read by the fighters service.
+type JSONFile struct {
+	path string
+	add  bool
+}
+
+// NewJSONFile creates a sink that writes fighters to the collection file at path.
+// If add is true, fighters are merged into the existing collection instead of replacing it.
+func NewJSONFile(path string, add bool) *JSONFile {
+	return &JSONFile{path: path, add: add}
+}
+
+// Write writes the fighters to the collection file.
+func (s *JSONFile) Write(ctx context.Context, fighters []model.Fighter) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+
+	c := model.FightersCollection{Fighters: fighters}
+
+	if s.add {
+		return scraperutil.AddToExistedCollection(s.path, c)
+	}
+
+	return scraperutil.CreateNewCollection(s.path, c)
+}
+
+// Close does nothing, as the file is closed after every write.
+func (s *JSONFile) Close() error {
+	return nil
+}
+
+// NDJSON streams fighters as newline delimited JSON, one fighter per line.
+// The output can be piped to `fighters repo update --source -`.
+type NDJSON struct {
+	enc *json.Encoder
+}
+
+// NewNDJSON creates a sink that writes fighters to w.
+func NewNDJSON(w io.Writer) *NDJSON {
+	return &NDJSON{enc: json.NewEncoder(w)}
+}
+
+// Write writes every fighter as a separate line.
+func (s *NDJSON) Write(ctx context.Context, fighters []model.Fighter) error {
+	for _, f := range fighters {
+		if err := s.enc.Encode(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close does nothing, as the writer is owned by the caller.
+func (s *NDJSON) Close() error {
+	return nil
+}
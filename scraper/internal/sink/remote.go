@@ -0,0 +1,192 @@
+package sink
+
+import (
+	"context"
+
+	"fightbettr.com/fighters/pkg/importer"
+	fightersModel "fightbettr.com/fighters/pkg/model"
+	"fightbettr.com/gen"
+	"fightbettr.com/internal/grpcutil"
+	"fightbettr.com/pkg/discovery"
+	"fightbettr.com/pkg/grpcauth"
+	"fightbettr.com/pkg/pgxs"
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/model"
+	"google.golang.org/grpc"
+)
+
+// DB upserts fighters directly into the fighters database.
+type DB struct {
+	importer  *importer.Importer
+	batchSize int
+}
+
+// NewDB connects to the fighters database and creates a sink that upserts fighters in batches of batchSize.
+func NewDB(ctx context.Context, cfg *pgxs.Config, batchSize int) (*DB, error) {
+	imp, err := importer.Connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DB{importer: imp, batchSize: batchSize}, nil
+}
+
+// Write upserts the fighters by their fighter url. Every batch is imported in its own transaction.
+func (s *DB) Write(ctx context.Context, fighters []model.Fighter) error {
+	return batches(fighters, s.batchSize, func(batch []model.Fighter) error {
+		res, err := s.importer.Import(ctx, toFightersModel(batch))
+		if err != nil {
+			return err
+		}
+
+		logger.Get().Infow("fighters imported", "type", "db sink", "affected", res.Affected, "skipped", res.Skipped)
+
+		return nil
+	})
+}
+
+// Release marks the active fighters absent from the urls of a complete roster as released.
+func (s *DB) Release(ctx context.Context, urls []string) error {
+	released, err := s.importer.Release(ctx, urls)
+	if err != nil {
+		return err
+	}
+
+	logger.Get().Infow("fighters released", "type", "db sink", "released", released)
+
+	return nil
+}
+
+// Close closes the database connection pool.
+func (s *DB) Close() error {
+	s.importer.Close()
+	return nil
+}
+
+// GRPC pushes fighters to the ImportFighters method of the fighters service.
+type GRPC struct {
+	conn      *grpc.ClientConn
+	client    gen.FightersServiceClient
+	batchSize int
+	token     string
+}
+
+// NewGRPC connects to the fighters service found in the registry with the given dial options and creates
+// a sink that pushes fighters in batches of batchSize. The token, if set, is forwarded with every request;
+// the fighters service requires an admin token for ImportFighters unless it requires client certificates.
+func NewGRPC(ctx context.Context, registry discovery.Registry, batchSize int, token string, opts ...grpc.DialOption) (*GRPC, error) {
+	conn, err := grpcutil.ServiceConnection(ctx, "fighters-service", registry, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPC{
+		conn:      conn,
+		client:    gen.NewFightersServiceClient(conn),
+		batchSize: batchSize,
+		token:     token,
+	}, nil
+}
+
+// Write pushes the fighters to the fighters service. Every batch is sent as a separate request.
+func (s *GRPC) Write(ctx context.Context, fighters []model.Fighter) error {
+	if s.token != "" {
+		ctx = grpcauth.WithToken(ctx, s.token)
+	}
+
+	return batches(fighters, s.batchSize, func(batch []model.Fighter) error {
+		converted := toFightersModel(batch)
+
+		req := &gen.ImportFightersRequest{Fighters: make([]*gen.Fighter, len(converted))}
+		for i := range converted {
+			req.Fighters[i] = fightersModel.FighterToProto(&converted[i])
+		}
+
+		resp, err := s.client.ImportFighters(ctx, req)
+		if err != nil {
+			return err
+		}
+
+		logger.Get().Infow("fighters imported", "type", "grpc sink", "affected", resp.Affected, "skipped", resp.Skipped)
+
+		return nil
+	})
+}
+
+// Release asks the fighters service to mark the active fighters absent from the urls of a complete roster
+// as released.
+func (s *GRPC) Release(ctx context.Context, urls []string) error {
+	if s.token != "" {
+		ctx = grpcauth.WithToken(ctx, s.token)
+	}
+
+	resp, err := s.client.ReleaseMissingFighters(ctx, &gen.ReleaseMissingFightersRequest{FighterUrls: urls})
+	if err != nil {
+		return err
+	}
+
+	logger.Get().Infow("fighters released", "type", "grpc sink", "released", resp.Released)
+
+	return nil
+}
+
+// Close closes the connection to the fighters service.
+func (s *GRPC) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+
+	return s.conn.Close()
+}
+
+// toFightersModel converts the scraped fighters to the model of the fighters service.
+func toFightersModel(fighters []model.Fighter) []fightersModel.Fighter {
+	converted := make([]fightersModel.Fighter, len(fighters))
+
+	for i, f := range fighters {
+		s := f.Stats
+
+		converted[i] = fightersModel.Fighter{
+			Name:           f.Name,
+			NickName:       f.NickName,
+			Division:       fightersModel.Division(f.Division),
+			Status:         fightersModel.FighterStatus(f.Status),
+			Hometown:       f.Hometown,
+			TrainsAt:       f.TrainsAt,
+			FightingStyle:  f.FightingStyle,
+			Age:            f.Age,
+			Height:         f.Height,
+			Weight:         f.Weight,
+			OctagonDebut:   f.OctagonDebut,
+			DebutTimestamp: f.DebutTimestamp,
+			Reach:          f.Reach,
+			LegReach:       f.LegReach,
+			Wins:           f.Wins,
+			Loses:          f.Loses,
+			Draw:           f.Draw,
+			FighterUrl:     f.FighterUrl,
+			ImageUrl:       f.ImageUrl,
+			Stats: fightersModel.FighterStats{
+				TotalSigStrLanded:    s.TotalSigStrLanded,
+				TotalSigStrAttempted: s.TotalSigStrAttempted,
+				StrAccuracy:          s.StrAccuracy,
+				TotalTkdLanded:       s.TotalTkdLanded,
+				TotalTkdAttempted:    s.TotalTkdAttempted,
+				TkdAccuracy:          s.TkdAccuracy,
+				SigStrLanded:         s.SigStrLanded,
+				SigStrAbs:            s.SigStrAbs,
+				SigStrDefense:        s.SigStrDefense,
+				TakedownDefense:      s.TakedownDefense,
+				TakedownAvg:          s.TakedownAvg,
+				SubmissionAvg:        s.SubmissionAvg,
+				KnockdownAvg:         s.KnockdownAvg,
+				AvgFightTime:         s.AvgFightTime,
+				WinByKO:              s.WinByKO,
+				WinBySub:             s.WinBySub,
+				WinByDec:             s.WinByDec,
+			},
+		}
+	}
+
+	return converted
+}
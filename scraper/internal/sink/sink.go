@@ -0,0 +1,171 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	fightersCfg "fightbettr.com/fighters/pkg/cfg"
+	"fightbettr.com/pkg/discovery/backend"
+	"fightbettr.com/pkg/tlsx"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/spf13/viper"
+)
+
+// Names of the available sinks.
+const (
+	JSONSink   = "json"
+	NDJSONSink = "ndjson"
+	DBSink     = "db"
+	GRPCSink   = "grpc"
+)
+
+// Sink represents a destination of the scraped fighters.
+type Sink interface {
+	// Write writes the fighters to the destination.
+	Write(ctx context.Context, fighters []model.Fighter) error
+	// Close releases the resources held by the sink.
+	Close() error
+}
+
+// Releaser is implemented by the sinks that keep the roster of the fighters service.
+type Releaser interface {
+	// Release marks the active fighters whose fighter url is not in the urls of a complete scrape as released.
+	Release(ctx context.Context, urls []string) error
+}
+
+// New creates the sinks with the given names and combines them into a single Sink.
+// The sinks are configured from viper:
+//   - json writes the collection to output.path, merging it with the existing one if the add option is set;
+//   - ndjson streams one fighter per line to the standard output;
+//   - db upserts fighters into the fighters database configured in postgres.main;
+//   - grpc pushes fighters to the fighters service found in the registry at registry.addr.
+//
+// The db and grpc sinks send fighters in batches of output.batch_size.
+func New(ctx context.Context, names []string) (Sink, error) {
+	var sinks multi
+
+	for _, name := range names {
+		s, err := newSink(ctx, strings.TrimSpace(name))
+		if err != nil {
+			sinks.Close()
+			return nil, fmt.Errorf("%s sink: %w", name, err)
+		}
+
+		sinks = append(sinks, s)
+	}
+
+	if len(sinks) == 0 {
+		return nil, errors.New("no output sinks")
+	}
+
+	if len(sinks) == 1 {
+		return sinks[0], nil
+	}
+
+	return sinks, nil
+}
+
+// newSink creates a single sink by its name.
+func newSink(ctx context.Context, name string) (Sink, error) {
+	batchSize := viper.GetInt("output.batch_size")
+
+	switch name {
+	case JSONSink:
+		return NewJSONFile(viper.GetString("output.path"), viper.GetBool("add")), nil
+	case NDJSONSink:
+		return NewNDJSON(os.Stdout), nil
+	case DBSink:
+		return NewDB(ctx, fightersCfg.ViperPostgres(), batchSize)
+	case GRPCSink:
+		registry, err := backend.FromViper()
+		if err != nil {
+			return nil, err
+		}
+
+		opts, err := tlsx.DialOptions(ctx, tlsx.ViperConfig())
+		if err != nil {
+			return nil, err
+		}
+
+		return NewGRPC(ctx, registry, batchSize, viper.GetString("output.grpc.token"), opts...)
+	default:
+		return nil, fmt.Errorf("unknown sink '%s'", name)
+	}
+}
+
+// UsesStdout reports whether any of the named sinks writes to the standard output.
+// The progress of such a run must be printed elsewhere, so it does not mix with the data.
+func UsesStdout(names []string) bool {
+	for _, name := range names {
+		if strings.TrimSpace(name) == NDJSONSink {
+			return true
+		}
+	}
+
+	return false
+}
+
+// multi writes the fighters to every sink it contains.
+type multi []Sink
+
+// Write writes the fighters to every sink, even if some of them fail, and returns the joined errors.
+func (m multi) Write(ctx context.Context, fighters []model.Fighter) error {
+	var errs []error
+
+	for _, s := range m {
+		if err := s.Write(ctx, fighters); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Release releases the missing fighters in every sink that keeps the roster and returns the joined errors.
+func (m multi) Release(ctx context.Context, urls []string) error {
+	var errs []error
+
+	for _, s := range m {
+		if r, ok := s.(Releaser); ok {
+			if err := r.Release(ctx, urls); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Close closes every sink and returns the joined errors.
+func (m multi) Close() error {
+	var errs []error
+
+	for _, s := range m {
+		if err := s.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// batches calls fn for consecutive parts of fighters with at most size elements.
+// A size less than 1 results in a single batch.
+func batches(fighters []model.Fighter, size int, fn func(batch []model.Fighter) error) error {
+	if size < 1 {
+		size = len(fighters)
+	}
+
+	for start := 0; start < len(fighters); start += size {
+		end := min(start+size, len(fighters))
+
+		if err := fn(fighters[start:end]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"fightbettr.com/gen"
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestMain(m *testing.M) {
+	logger.Set(zap.NewNop().Sugar())
+
+	os.Exit(m.Run())
+}
+
+var testFighters = []model.Fighter{
+	{Name: "Jon Jones", FighterUrl: "https://www.ufc.com/athlete/jon-jones", Height: 76, Stats: model.FighterStats{WinByKO: 10}},
+	{Name: "Amanda Nunes", FighterUrl: "https://www.ufc.com/athlete/amanda-nunes", Height: 68},
+	{Name: "New Prospect", FighterUrl: "https://www.ufc.com/athlete/new-prospect"},
+}
+
+func TestJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "collection", "fighters.json")
+	ctx := context.Background()
+
+	readCollection := func() model.FightersCollection {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var c model.FightersCollection
+		require.NoError(t, json.Unmarshal(data, &c))
+
+		return c
+	}
+
+	require.NoError(t, NewJSONFile(path, false).Write(ctx, testFighters[:2]))
+	assert.Equal(t, testFighters[:2], readCollection().Fighters)
+
+	require.NoError(t, NewJSONFile(path, false).Write(ctx, testFighters[2:]))
+	assert.Equal(t, testFighters[2:], readCollection().Fighters)
+
+	require.NoError(t, NewJSONFile(path, true).Write(ctx, testFighters[:1]))
+	assert.Equal(t, []model.Fighter{testFighters[2], testFighters[0]}, readCollection().Fighters)
+}
+
+func TestNDJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, NewNDJSON(&buf).Write(context.Background(), testFighters))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(testFighters))
+
+	for i, line := range lines {
+		var f model.Fighter
+		require.NoError(t, json.Unmarshal([]byte(line), &f))
+		assert.Equal(t, testFighters[i], f)
+	}
+}
+
+// fakeFightersClient records the ImportFighters and ReleaseMissingFighters requests.
+type fakeFightersClient struct {
+	gen.FightersServiceClient
+	requests []*gen.ImportFightersRequest
+	released [][]string
+	err      error
+}
+
+func (c *fakeFightersClient) ImportFighters(ctx context.Context, req *gen.ImportFightersRequest, opts ...grpc.CallOption) (*gen.ImportFightersResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.requests = append(c.requests, req)
+
+	return &gen.ImportFightersResponse{Affected: int64(len(req.Fighters))}, nil
+}
+
+func (c *fakeFightersClient) ReleaseMissingFighters(ctx context.Context, req *gen.ReleaseMissingFightersRequest, opts ...grpc.CallOption) (*gen.ReleaseMissingFightersResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	c.released = append(c.released, req.FighterUrls)
+
+	return &gen.ReleaseMissingFightersResponse{}, nil
+}
+
+func TestGRPC(t *testing.T) {
+	tests := []struct {
+		name          string
+		batchSize     int
+		err           error
+		expectedSizes []int
+		expectedErr   error
+	}{
+		{name: "Batches", batchSize: 2, expectedSizes: []int{2, 1}},
+		{name: "Single batch", batchSize: 0, expectedSizes: []int{3}},
+		{name: "Error", batchSize: 2, err: errors.New("unavailable"), expectedErr: errors.New("unavailable")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			client := &fakeFightersClient{err: tc.err}
+			s := &GRPC{client: client, batchSize: tc.batchSize}
+
+			err := s.Write(context.Background(), testFighters)
+			assert.Equal(t, tc.expectedErr, err)
+
+			var sizes []int
+			for _, req := range client.requests {
+				sizes = append(sizes, len(req.Fighters))
+			}
+			assert.Equal(t, tc.expectedSizes, sizes)
+		})
+	}
+
+	client := &fakeFightersClient{}
+	require.NoError(t, (&GRPC{client: client}).Write(context.Background(), testFighters[:1]))
+
+	pushed := client.requests[0].Fighters[0]
+	assert.Equal(t, "Jon Jones", pushed.Name)
+	assert.Equal(t, testFighters[0].FighterUrl, pushed.FighterUrl)
+	assert.Equal(t, float32(76), pushed.Height)
+	assert.Equal(t, int32(10), pushed.Stats.WinByKO)
+}
+
+func TestMulti(t *testing.T) {
+	var first, second bytes.Buffer
+	failing := &GRPC{client: &fakeFightersClient{err: errors.New("unavailable")}}
+
+	s := multi{NewNDJSON(&first), failing, NewNDJSON(&second)}
+
+	err := s.Write(context.Background(), testFighters)
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, first.String(), second.String())
+	assert.NotEmpty(t, first.String())
+}
+
+func TestRelease(t *testing.T) {
+	urls := []string{testFighters[0].FighterUrl, testFighters[1].FighterUrl}
+
+	client := &fakeFightersClient{}
+	require.NoError(t, (&GRPC{client: client}).Release(context.Background(), urls))
+	assert.Equal(t, [][]string{urls}, client.released)
+
+	var buf bytes.Buffer
+	first, second := &fakeFightersClient{}, &fakeFightersClient{}
+	failing := &GRPC{client: &fakeFightersClient{err: errors.New("unavailable")}}
+
+	s := multi{&GRPC{client: first}, NewNDJSON(&buf), failing, &GRPC{client: second}}
+
+	err := s.Release(context.Background(), urls)
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, [][]string{urls}, first.released)
+	assert.Equal(t, [][]string{urls}, second.released)
+	assert.Empty(t, buf.String())
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(context.Background(), []string{"json", "csv"})
+	assert.EqualError(t, err, "csv sink: unknown sink 'csv'")
+
+	_, err = New(context.Background(), nil)
+	assert.Error(t, err)
+
+	s, err := New(context.Background(), []string{"ndjson"})
+	require.NoError(t, err)
+	assert.IsType(t, &NDJSON{}, s)
+
+	assert.True(t, UsesStdout([]string{"json", " ndjson"}))
+	assert.False(t, UsesStdout([]string{"json", "grpc"}))
+}
@@ -0,0 +1,197 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"fightbettr.com/scraper/pkg/model"
+)
+
+// Severity represents how serious a validation issue is.
+type Severity string
+
+const (
+	// SeverityError marks a record that is rejected and not saved.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a suspicious record that is saved, but should be reviewed.
+	SeverityWarning Severity = "warning"
+)
+
+// Issue represents a single problem found in a scraped record.
+type Issue struct {
+	Field    string   `json:"field"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	Value    any      `json:"value,omitempty"`
+}
+
+// Record represents a scraped fighter with the issues found in it.
+type Record struct {
+	Name       string  `json:"name"`
+	FighterUrl string  `json:"fighterUrl"`
+	Rejected   bool    `json:"rejected"`
+	Issues     []Issue `json:"issues"`
+}
+
+// Report represents the result of the validation of a scrape run.
+// Only rejected and suspicious fighters are listed in Records.
+type Report struct {
+	Total      int      `json:"total"`
+	Valid      int      `json:"valid"`
+	Rejected   int      `json:"rejected"`
+	Suspicious int      `json:"suspicious"`
+	Records    []Record `json:"records"`
+}
+
+// bounds represents the plausible range of a value. Zero values are treated as missing.
+type bounds struct {
+	min, max float64
+}
+
+var (
+	heightBounds   = bounds{55, 90}   // inches
+	weightBounds   = bounds{100, 300} // pounds
+	reachBounds    = bounds{55, 95}   // inches
+	legReachBounds = bounds{30, 55}   // inches
+	ageBounds      = bounds{18, 60}
+)
+
+// Fighter validates the fighter and returns the issues found in it.
+// Missing identity, impossible numbers and unparsed values, including the empty record the scraper
+// falls back to when the record is not parsed, are errors; missing or out-of-range physical attributes are warnings.
+func Fighter(f model.Fighter) []Issue {
+	var issues []Issue
+
+	add := func(field string, severity Severity, value any, format string, args ...any) {
+		issues = append(issues, Issue{Field: field, Severity: severity, Message: fmt.Sprintf(format, args...), Value: value})
+	}
+
+	if f.Name == "" {
+		add("name", SeverityError, nil, "name is required")
+	}
+
+	if f.FighterUrl == "" {
+		add("fighterUrl", SeverityError, nil, "fighter url is required")
+	}
+
+	checkRange := func(field string, v float64, b bounds) {
+		if v == 0 {
+			add(field, SeverityWarning, nil, "%s is missing", field)
+		} else if v < b.min || v > b.max {
+			add(field, SeverityWarning, v, "%s is out of the plausible range %g-%g", field, b.min, b.max)
+		}
+	}
+
+	checkRange("height", float64(f.Height), heightBounds)
+	checkRange("weight", float64(f.Weight), weightBounds)
+	checkRange("reach", float64(f.Reach), reachBounds)
+	checkRange("age", float64(f.Age), ageBounds)
+
+	if f.LegReach != 0 {
+		checkRange("legReach", float64(f.LegReach), legReachBounds)
+	}
+
+	if f.OctagonDebut != "" && f.DebutTimestamp == 0 {
+		add("debutTimestamp", SeverityError, f.OctagonDebut, "octagon debut '%s' is not parsed", f.OctagonDebut)
+	} else if f.OctagonDebut == "" {
+		add("octagonDebut", SeverityWarning, nil, "octagon debut is missing")
+	}
+
+	if f.Wins < 0 || f.Loses < 0 || f.Draw < 0 {
+		add("record", SeverityError, fmt.Sprintf("%d-%d-%d", f.Wins, f.Loses, f.Draw), "record must not be negative")
+	} else if f.Wins+f.Loses+f.Draw == 0 {
+		add("record", SeverityError, nil, "record is empty or not parsed")
+	}
+
+	s := f.Stats
+
+	percentages := []struct {
+		field string
+		value int
+	}{
+		{"stats.strAccuracy", s.StrAccuracy},
+		{"stats.tkdAccuracy", s.TkdAccuracy},
+		{"stats.sigStrDefense", int(s.SigStrDefense)},
+		{"stats.takedownDefense", int(s.TakedownDefense)},
+	}
+
+	for _, p := range percentages {
+		if p.value < 0 || p.value > 100 {
+			add(p.field, SeverityError, p.value, "%s must be between 0 and 100", p.field)
+		}
+	}
+
+	if s.TotalSigStrLanded > s.TotalSigStrAttempted {
+		add("stats.totalSigStrLanded", SeverityError, s.TotalSigStrLanded, "landed significant strikes exceed attempted (%d)", s.TotalSigStrAttempted)
+	}
+
+	if s.TotalTkdLanded > s.TotalTkdAttempted {
+		add("stats.totalTkdLanded", SeverityError, s.TotalTkdLanded, "landed takedowns exceed attempted (%d)", s.TotalTkdAttempted)
+	}
+
+	if methods := s.WinByKO + s.WinBySub + s.WinByDec; methods > f.Wins {
+		add("stats.winMethods", SeverityError, methods, "wins by method exceed wins (%d)", f.Wins)
+	}
+
+	return issues
+}
+
+// Fighters validates every fighter and returns the fighters without errors together with the report.
+func Fighters(fighters []model.Fighter) ([]model.Fighter, Report) {
+	report := Report{Total: len(fighters), Records: []Record{}}
+	valid := make([]model.Fighter, 0, len(fighters))
+
+	for _, f := range fighters {
+		issues := Fighter(f)
+		if len(issues) == 0 {
+			report.Valid++
+			valid = append(valid, f)
+			continue
+		}
+
+		record := Record{Name: f.Name, FighterUrl: f.FighterUrl, Issues: issues}
+		for _, issue := range issues {
+			if issue.Severity == SeverityError {
+				record.Rejected = true
+				break
+			}
+		}
+
+		if record.Rejected {
+			report.Rejected++
+		} else {
+			report.Suspicious++
+			report.Valid++
+			valid = append(valid, f)
+		}
+
+		report.Records = append(report.Records, record)
+	}
+
+	return valid, report
+}
+
+// ErrorRate returns the share of rejected records.
+func (r Report) ErrorRate() float64 {
+	if r.Total == 0 {
+		return 0
+	}
+
+	return float64(r.Rejected) / float64(r.Total)
+}
+
+// Write writes the report to the file as indented JSON.
+func (r Report) Write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
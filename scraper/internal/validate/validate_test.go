@@ -0,0 +1,154 @@
+package validate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// validFighter returns a fighter that passes validation without issues.
+func validFighter() model.Fighter {
+	return model.Fighter{
+		Name:           "Jon Jones",
+		Status:         "Active",
+		Age:            36,
+		Height:         76,
+		Weight:         248,
+		OctagonDebut:   "Aug. 9, 2008",
+		DebutTimestamp: 1218240000,
+		Reach:          84.5,
+		LegReach:       44,
+		Wins:           27,
+		Loses:          1,
+		FighterUrl:     "https://www.ufc.com/athlete/jon-jones",
+		Stats: model.FighterStats{
+			TotalSigStrLanded:    1463,
+			TotalSigStrAttempted: 2535,
+			StrAccuracy:          58,
+			TotalTkdLanded:       38,
+			TotalTkdAttempted:    85,
+			TkdAccuracy:          45,
+			SigStrDefense:        64,
+			TakedownDefense:      95,
+			WinByKO:              10,
+			WinBySub:             7,
+			WinByDec:             10,
+		},
+	}
+}
+
+func TestFighter(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(f *model.Fighter)
+		expected map[string]Severity
+	}{
+		{
+			name:     "valid",
+			modify:   func(f *model.Fighter) {},
+			expected: map[string]Severity{},
+		},
+		{
+			name:     "missing identity",
+			modify:   func(f *model.Fighter) { f.Name, f.FighterUrl = "", "" },
+			expected: map[string]Severity{"name": SeverityError, "fighterUrl": SeverityError},
+		},
+		{
+			name:     "implausible physical attributes",
+			modify:   func(f *model.Fighter) { f.Height, f.Weight, f.Reach, f.Age = 7, 1250, 0, 12 },
+			expected: map[string]Severity{"height": SeverityWarning, "weight": SeverityWarning, "reach": SeverityWarning, "age": SeverityWarning},
+		},
+		{
+			name:     "accuracy out of range",
+			modify:   func(f *model.Fighter) { f.Stats.StrAccuracy, f.Stats.TkdAccuracy = 580, -1 },
+			expected: map[string]Severity{"stats.strAccuracy": SeverityError, "stats.tkdAccuracy": SeverityError},
+		},
+		{
+			name:     "debut not parsed",
+			modify:   func(f *model.Fighter) { f.DebutTimestamp = 0 },
+			expected: map[string]Severity{"debutTimestamp": SeverityError},
+		},
+		{
+			name:     "debut missing",
+			modify:   func(f *model.Fighter) { f.OctagonDebut, f.DebutTimestamp = "", 0 },
+			expected: map[string]Severity{"octagonDebut": SeverityWarning},
+		},
+		{
+			name:     "win methods exceed wins",
+			modify:   func(f *model.Fighter) { f.Wins = 20 },
+			expected: map[string]Severity{"stats.winMethods": SeverityError},
+		},
+		{
+			name: "empty record",
+			modify: func(f *model.Fighter) {
+				f.Wins, f.Loses = 0, 0
+				f.Stats.WinByKO, f.Stats.WinBySub, f.Stats.WinByDec = 0, 0, 0
+			},
+			expected: map[string]Severity{"record": SeverityError},
+		},
+		{
+			name:     "landed exceed attempted",
+			modify:   func(f *model.Fighter) { f.Stats.TotalTkdLanded = 90 },
+			expected: map[string]Severity{"stats.totalTkdLanded": SeverityError},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			f := validFighter()
+			tc.modify(&f)
+
+			actual := map[string]Severity{}
+			for _, issue := range Fighter(f) {
+				actual[issue.Field] = issue.Severity
+			}
+
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestFighters(t *testing.T) {
+	suspicious := validFighter()
+	suspicious.FighterUrl = "https://www.ufc.com/athlete/suspicious"
+	suspicious.Reach = 0
+
+	rejected := validFighter()
+	rejected.FighterUrl = "https://www.ufc.com/athlete/rejected"
+	rejected.Stats.StrAccuracy = 580
+
+	valid, report := Fighters([]model.Fighter{validFighter(), suspicious, rejected})
+
+	assert.Len(t, valid, 2)
+	assert.Equal(t, 3, report.Total)
+	assert.Equal(t, 2, report.Valid)
+	assert.Equal(t, 1, report.Rejected)
+	assert.Equal(t, 1, report.Suspicious)
+	assert.InDelta(t, 1.0/3, report.ErrorRate(), 1e-9)
+
+	require.Len(t, report.Records, 2)
+	assert.Equal(t, suspicious.FighterUrl, report.Records[0].FighterUrl)
+	assert.False(t, report.Records[0].Rejected)
+	assert.Equal(t, rejected.FighterUrl, report.Records[1].FighterUrl)
+	assert.True(t, report.Records[1].Rejected)
+}
+
+func TestReportWrite(t *testing.T) {
+	_, report := Fighters([]model.Fighter{{}})
+	path := filepath.Join(t.TempDir(), "collection", "report.json")
+
+	require.NoError(t, report.Write(path))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var actual Report
+	require.NoError(t, json.Unmarshal(data, &actual))
+	assert.Equal(t, 1, actual.Rejected)
+	assert.Equal(t, 1.0, actual.ErrorRate())
+}
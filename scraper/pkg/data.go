@@ -12,8 +12,9 @@ const defaultStat = "0-0-0 (W-L-D)"
 
 // SetStatistic sets the statistical data for a Fighter based on the provided string 'stat'.
 // The function splits the input string, extracts individual parts, converts them to integers,
-// and sets the Wins, Loses, and Draw fields of the Fighter accordingly. If conversion errors occur,
-// it logs an error and sets the corresponding value to 0.
+// and sets the Wins, Loses, and Draw fields of the Fighter accordingly. If the string is empty or
+// any part can not be converted, it logs an error and falls back to the 0-0-0 record as a whole,
+// which is rejected by the validation of the scraped fighters.
 func SetStatistic(f *model.Fighter, stat string) {
 	l := logger.Get()
 
@@ -22,17 +23,22 @@ func SetStatistic(f *model.Fighter, stat string) {
 	}
 
 	parts := strings.Split(strings.Split(stat, " ")[0], "-")
-	var scores []int
+	if len(parts) != 3 {
+		l.Errorf("[%s] Record error: %d parts in '%s'", f.Name, len(parts), stat)
+		parts = []string{"0", "0", "0"}
+	}
+
+	scores := make([]int, len(parts))
 
-	for _, part := range parts {
+	for i, part := range parts {
 		num, err := strconv.Atoi(part)
 		if err != nil {
 			l.Errorf("[%s] Conversion error: %s, with part: '%s' of %s", f.Name, err, part, parts)
-			scores = append(scores, 0)
-		} else {
-			scores = append(scores, num)
+			scores = []int{0, 0, 0}
+			break
 		}
 
+		scores[i] = num
 	}
 
 	f.Wins = scores[0]
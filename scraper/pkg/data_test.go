@@ -0,0 +1,61 @@
+package data
+
+import (
+	"os"
+	"testing"
+
+	"fightbettr.com/scraper/pkg/logger"
+	"fightbettr.com/scraper/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestMain(m *testing.M) {
+	logger.Set(zap.NewNop().Sugar())
+
+	os.Exit(m.Run())
+}
+
+func TestSetStatistic(t *testing.T) {
+	tests := []struct {
+		name     string
+		stat     string
+		expected [3]int
+	}{
+		{name: "record", stat: "27-1-0 (W-L-D)", expected: [3]int{27, 1, 0}},
+		{name: "record with draws", stat: "22-5-1 (W-L-D)", expected: [3]int{22, 5, 1}},
+		{name: "empty record", stat: "", expected: [3]int{0, 0, 0}},
+		{name: "malformed part", stat: "12-x-0 (W-L-D)", expected: [3]int{0, 0, 0}},
+		{name: "incomplete record", stat: "12-3 (W-L-D)", expected: [3]int{0, 0, 0}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var f model.Fighter
+			SetStatistic(&f, tc.stat)
+
+			assert.Equal(t, tc.expected, [3]int{f.Wins, f.Loses, f.Draw})
+		})
+	}
+}
+
+func TestSetDivision(t *testing.T) {
+	tests := []struct {
+		division string
+		expected model.Division
+	}{
+		{division: "Flyweight Division", expected: model.Flyweight},
+		{division: "Light Heavyweight Division", expected: model.Lightheavyweight},
+		{division: "Heavyweight Division", expected: model.Heavyweight},
+		{division: "Women's Bantamweight Division", expected: model.WomensBantamweight},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.division, func(t *testing.T) {
+			f := model.Fighter{Division: -1}
+			SetDivision(&f, tc.division)
+
+			assert.Equal(t, tc.expected, f.Division)
+		})
+	}
+}
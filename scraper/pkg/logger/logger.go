@@ -47,6 +47,11 @@ func Initialize(flag int) error {
 	return nil
 }
 
+// Set replaces the logger. It is used to run the scraper without a log file, e.g. in tests.
+func Set(l *zap.SugaredLogger) {
+	logger = l
+}
+
 func Get() *zap.SugaredLogger {
 	return logger
 }
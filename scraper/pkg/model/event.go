@@ -0,0 +1,42 @@
+package model
+
+// FightOutcome represents the outcome of a completed fight
+type FightOutcome string
+
+const (
+	OutcomeRed       FightOutcome = "red"
+	OutcomeBlue      FightOutcome = "blue"
+	OutcomeDraw      FightOutcome = "draw"
+	OutcomeNoContest FightOutcome = "no_contest"
+)
+
+// Fight represents a bout of the event card with the urls of both athletes
+// and, for completed bouts, the result
+type Fight struct {
+	FighterRedName  string       `json:"fighterRedName"`
+	FighterRedUrl   string       `json:"fighterRedUrl"`
+	FighterBlueName string       `json:"fighterBlueName"`
+	FighterBlueUrl  string       `json:"fighterBlueUrl"`
+	WeightClass     string       `json:"weightClass"`
+	IsDone          bool         `json:"isDone"`
+	Outcome         FightOutcome `json:"outcome,omitempty"`
+	WinnerUrl       string       `json:"winnerUrl,omitempty"`
+	Method          string       `json:"method,omitempty"`
+	Round           int          `json:"round,omitempty"`
+	Time            string       `json:"time,omitempty"`
+}
+
+// Event represents an event with its date and the fight card
+type Event struct {
+	Name      string  `json:"name"`
+	Headline  string  `json:"headline"`
+	EventUrl  string  `json:"eventUrl"`
+	Timestamp int64   `json:"timestamp"`
+	IsDone    bool    `json:"isDone"`
+	Fights    []Fight `json:"fights"`
+}
+
+// EventsCollection represents a collection of events as a slice
+type EventsCollection struct {
+	Events []Event
+}
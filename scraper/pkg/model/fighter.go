@@ -95,6 +95,12 @@ type Fighter struct {
 	FighterUrl     string       `json:"fighterUrl"`
 	ImageUrl       string       `json:"imageUrl"`
 	Stats          FighterStats `json:"stats"`
+
+	// Provenance maps the fields of a record merged from several sources to the source that supplied them.
+	// Stats fields are prefixed with "stats.", e.g. "stats.strAccuracy".
+	Provenance map[string]string `json:"provenance,omitempty"`
+	// SourceUrls maps the name of every source the fighter was found in to the fighter url in that source.
+	SourceUrls map[string]string `json:"sourceUrls,omitempty"`
 }
 
 // FightersCollection represents a collection of fighters as a slice
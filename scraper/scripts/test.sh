@@ -0,0 +1,28 @@
+#!/bin/bash
+
+# Runs the scraper tests against the recorded fixtures.
+# Pass -update to rewrite the golden files after recording new fixtures with `scraper record`.
+
+packages=(
+    "./internal/checkpoint"
+    "./internal/daemon"
+    "./internal/fixture"
+    "./internal/merge"
+    "./internal/proxy"
+    "./internal/scraper"
+    "./internal/sink"
+    "./internal/validate"
+    "./pkg"
+)
+
+cmd="go test -v -coverprofile=coverage.out"
+
+for pkg in "${packages[@]}"; do
+    cmd+=" $pkg"
+done
+
+if [ "$1" == "-update" ]; then
+  cmd="go test ./internal/scraper -update"
+fi
+
+eval "$cmd"
@@ -24,7 +24,11 @@ CREATE TABLE IF NOT EXISTS public.fb_fighters (
     image_url text,
     wins integer DEFAULT 0 NOT NULL,
     loses integer DEFAULT 0 NOT NULL,
-    draw integer DEFAULT 0 NOT NULL
+    draw integer DEFAULT 0 NOT NULL,
+    state character varying(20) DEFAULT 'active' NOT NULL,
+    state_updated_at bigint,
+    deleted_at bigint,
+    CONSTRAINT fb_fighters_state_check CHECK (state IN ('active', 'retired', 'released', 'deceased'))
 );
 
 ALTER TABLE ONLY public.fb_fighters
@@ -78,4 +82,16 @@ ALTER TABLE ONLY public.fb_fighter_stats
     ADD CONSTRAINT fb_fighter_stats_pkey PRIMARY KEY (stat_id);
 
 ALTER TABLE ONLY public.fb_fighter_stats
-    ADD CONSTRAINT fb_fighter_stats_fighter_id_fkey FOREIGN KEY (fighter_id) REFERENCES public.fb_fighters(fighter_id);
\ No newline at end of file
+    ADD CONSTRAINT fb_fighter_stats_fighter_id_fkey FOREIGN KEY (fighter_id) REFERENCES public.fb_fighters(fighter_id);
+--- fb_fighter_snapshots table
+
+CREATE TABLE IF NOT EXISTS public.fb_fighter_snapshots (
+    snapshot_id serial NOT NULL,
+    fighter_id integer NOT NULL,
+    taken_at bigint NOT NULL,
+    fighter jsonb NOT NULL,
+    CONSTRAINT fb_fighter_snapshots_pkey PRIMARY KEY (snapshot_id),
+    CONSTRAINT fb_fighter_snapshots_fighter_id_fkey FOREIGN KEY (fighter_id) REFERENCES public.fb_fighters(fighter_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS fb_fighter_snapshots_fighter_id_taken_at_index ON public.fb_fighter_snapshots USING btree (fighter_id, taken_at);